@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup [FILE]",
+	Short: "Back up all mymonies data to a file",
+	Long: `The command backup writes all households, users, API tokens, imports,
+	records, tags, patterns, tag history and exchange rates to a backup archive,
+	or to standard output if FILE is - or missing. Login sessions are not backed
+	up.
+
+	The archive is a gzip compressed tar of a manifest.json and a JSON Lines file
+	of each table, with the row count and SHA-256 checksum of each file in the
+	manifest. Backups don't depend on the database: a PostgreSQL backup can be
+	restored to SQLite and the other way around.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		d, err := db.Backup()
+		if err != nil {
+			return fmt.Errorf("failed to read data: %v", err)
+		}
+		if len(args) == 0 || args[0] == "-" {
+			return backup.Write(os.Stdout, d)
+		}
+		f, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+		if err := backup.Write(f, d); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Backed up %d records of %d households to %v\n", len(d.Records), len(d.Households), args[0])
+		return nil
+	},
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore FILE",
+	Short: "Restore a backup to an empty database",
+	Long: `The command restore migrates the database schema and stores the data of a
+	backup archive written by mymonies backup, or read from standard input if
+	FILE is -. The database must be empty: restoring never merges or overwrites
+	data. The checksums of the archive are verified before anything is stored.
+
+	Rows get new IDs in the database, with references between them updated, so
+	IDs may differ from the backed up database.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		m, d, err := backup.Read(r)
+		if err != nil {
+			return fmt.Errorf("%v: %v", args[0], err)
+		}
+
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := db.Restore(d); err != nil {
+			return fmt.Errorf("failed to restore: %v", err)
+		}
+		fmt.Printf("Restored %d records of %d households from backup of %v\n",
+			len(d.Records), len(d.Households), m.Created.Format("2006-01-02 15:04:05 MST"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+
+	for _, c := range []*cobra.Command{backupCmd, restoreCmd} {
+		c.Flags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
+		configurable(c.Flags(), "conn")
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver"
+	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// addClientFlags adds the flags of commands calling the mymonies server.
+// The flags can also be set in the config file, e.g. with the keys
+// "mymonies" and "token", or in the environment variables
+// MYMONIES_MYMONIES and MYMONIES_TOKEN.
+func addClientFlags(flags *pflag.FlagSet, usage string) {
+	flags.String("mymonies", "http://127.0.0.1:8000", usage)
+	flags.String("token", "", "API token for the mymonies server, see mymonies token create")
+	flags.String("household", "", "ID of household to use instead of the first household of the user")
+	configurable(flags, "mymonies", "token", "household")
+}
+
+// newClient returns a mymonies client authenticated by the API token of the
+// command line, config file or environment.
+func newClient(cmd *cobra.Command) mymonies.Mymonies {
+	return mymonies.NewMymoniesProtobufClient(setting(cmd, "mymonies"), newHTTPClient(cmd))
+}
+
+// newHTTPClient returns an HTTP client for the mymonies server, like
+// newClient.
+func newHTTPClient(cmd *cobra.Command) *http.Client {
+	transport := &tokenTransport{
+		token:     setting(cmd, "token"),
+		household: setting(cmd, "household"),
+		base:      http.DefaultTransport,
+	}
+	return &http.Client{Transport: transport}
+}
+
+// tokenTransport adds the API token and the selected household to requests.
+type tokenTransport struct {
+	token     string
+	household string
+	base      http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the request.
+	r = r.Clone(r.Context())
+	if t.token != "" {
+		r.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	if t.household != "" {
+		r.Header.Set(mymoniesserver.HouseholdHeader, t.household)
+	}
+	return t.base.RoundTrip(r)
+}
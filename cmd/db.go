@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	"github.com/spf13/cobra"
+)
+
+// dbCmd represents the db command
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the mymonies database schema",
+}
+
+// dbMigrateCmd represents the db migrate command
+var dbMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long: `The command db migrate applies all pending schema migrations. The server
+	also migrates the schema automatically on startup.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := db.Migrate(); err != nil {
+			return err
+		}
+		fmt.Println("Schema is up to date")
+		return nil
+	},
+}
+
+// dbStatusCmd represents the db status command
+var dbStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List schema migrations and whether they are applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		status, err := db.MigrationStatus()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, s := range status {
+			applied := "pending"
+			if s.Applied {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%v\t%v\n", s.Version, s.Name, applied)
+		}
+		return w.Flush()
+	},
+}
+
+// dbRollbackCmd represents the db rollback command
+var dbRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Revert the latest schema migrations",
+	Long: `The command db rollback reverts the latest applied schema migrations.
+	Reverting migrations may permanently delete data.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, _ := cmd.Flags().GetInt("steps")
+		if steps < 1 {
+			return fmt.Errorf("--steps must be at least 1")
+		}
+		db, err := connectDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := db.Rollback(steps); err != nil {
+			return err
+		}
+		version, err := db.SchemaVersion()
+		if err != nil {
+			return err
+		}
+		fmt.Println("Schema is at version", version)
+		return nil
+	},
+}
+
+func connectDB(cmd *cobra.Command) (database.Storage, error) {
+	conn, _ := cmd.Flags().GetString("conn")
+	return database.Open(conn)
+}
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbMigrateCmd)
+	dbCmd.AddCommand(dbStatusCmd)
+	dbCmd.AddCommand(dbRollbackCmd)
+
+	dbCmd.PersistentFlags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
+	configurable(dbCmd.PersistentFlags(), "conn")
+	dbRollbackCmd.Flags().Int("steps", 1, "Number of migrations to revert")
+}
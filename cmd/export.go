@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/joneskoo/mymonies/pkg/export"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transactions from mymonies as CSV, JSON Lines or an accounting journal",
+	Long: `The command export writes the transactions of a mymonies server with their
+	tag names and imports to standard output or a file. The transactions can be
+	limited like in the web interface, e.g. --account FI1234 --month 2018-03.
+	For Finnish Excel, use --decimal-comma, which also separates fields with
+	semicolons.
+
+	The ledger, hledger and beancount formats write a plain-text accounting
+	journal with the imported accounts as asset accounts, e.g. Assets:FI1234,
+	and tags as expense or income accounts, e.g. Expenses:Groceries. Colons in
+	tag names separate sub-accounts. Every transaction has its mymonies id as
+	mymonies-id metadata, and exporting the same data gives the same journal.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		if _, ok := export.Formats[format]; !ok {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		q := url.Values{"format": {format}}
+		for _, name := range []string{"delimiter", "account", "month", "query"} {
+			if v, _ := cmd.Flags().GetString(name); v != "" {
+				q.Set(name, v)
+			}
+		}
+		if dc, _ := cmd.Flags().GetBool("decimal-comma"); dc {
+			q.Set("decimal_comma", "true")
+		}
+
+		address := strings.TrimSuffix(setting(cmd, "mymonies"), "/")
+		resp, err := newHTTPClient(cmd).Get(address + mymoniesserver.ExportPath + "?" + q.Encode())
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			var twerr struct{ Code, Msg string }
+			if err := json.NewDecoder(resp.Body).Decode(&twerr); err != nil || twerr.Msg == "" {
+				return fmt.Errorf("export failed: %v", resp.Status)
+			}
+			return fmt.Errorf("export failed: %v: %v", twerr.Code, twerr.Msg)
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" || output == "-" {
+			if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+				return fmt.Errorf("export failed: %v", err)
+			}
+			return nil
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		// An export failing midway is removed rather than left incomplete.
+		_, err = io.Copy(f, resp.Body)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(output)
+			return fmt.Errorf("export failed: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	addClientFlags(exportCmd.Flags(), "Export transactions from mymonies server")
+	exportCmd.Flags().StringP("output", "o", "", "Output file (default standard output)")
+	exportCmd.Flags().String("format", "csv", "Output format: csv, jsonl, ledger, hledger or beancount")
+	exportCmd.Flags().String("delimiter", "", "CSV field delimiter (default comma, or semicolon with --decimal-comma)")
+	exportCmd.Flags().Bool("decimal-comma", false, "Write CSV amounts with decimal comma")
+	exportCmd.Flags().String("account", "", "Export only transactions of account")
+	exportCmd.Flags().String("month", "", "Export only transactions of year-month, e.g. 2018-03")
+	exportCmd.Flags().String("query", "", "Export only transactions with a field matching query exactly")
+}
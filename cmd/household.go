@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	"github.com/spf13/cobra"
+)
+
+// householdCmd represents the household command
+var householdCmd = &cobra.Command{
+	Use:   "household",
+	Short: "Manage households and their members",
+	Long: `Households separate the data of users sharing a server. Imports,
+	records, tags and patterns belong to a household, and users can only access
+	the data of the households they are members of. Members are owners, who
+	can manage members, editors, who can import and tag records, or viewers.`,
+}
+
+// householdAddCmd represents the household add command
+var householdAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Add a household",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		owner, _ := cmd.Flags().GetString("owner")
+		var u *database.User
+		if owner != "" {
+			if u, err = db.GetUser(owner); err != nil {
+				return fmt.Errorf("failed to find user %q: %v", owner, err)
+			}
+		}
+		h, err := db.AddHousehold(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Added household %v with ID %v\n", h.Name, h.ID)
+		if u == nil {
+			return nil
+		}
+		if err := db.SetMember(h.ID, u.ID, database.RoleOwner); err != nil {
+			return err
+		}
+		fmt.Printf("Added %v to household %v as %v\n", u.Username, h.ID, database.RoleOwner)
+		return nil
+	},
+}
+
+// householdListCmd represents the household list command
+var householdListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List households and their members",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		households, err := db.ListHouseholds("")
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tMEMBER\tROLE")
+		for _, h := range households {
+			members, err := db.ListMembers(h.ID)
+			if err != nil {
+				return err
+			}
+			if len(members) == 0 {
+				fmt.Fprintf(w, "%v\t%v\t\t\n", h.ID, h.Name)
+			}
+			for _, m := range members {
+				fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", h.ID, h.Name, m.Username, m.Role)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+// householdSetCmd represents the household set command
+var householdSetCmd = &cobra.Command{
+	Use:   "set HOUSEHOLD_ID USERNAME",
+	Short: "Add a member to a household or change the role of a member",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		u, err := db.GetUser(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to find user %q: %v", args[1], err)
+		}
+		return setMember(db, args[0], u, cmd)
+	},
+}
+
+// householdRemoveCmd represents the household remove command
+var householdRemoveCmd = &cobra.Command{
+	Use:   "remove HOUSEHOLD_ID USERNAME",
+	Short: "Remove a member from a household",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		u, err := db.GetUser(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to find user %q: %v", args[1], err)
+		}
+		if err := db.RemoveMember(args[0], u.ID); err != nil {
+			return fmt.Errorf("failed to remove %v from household %v: %v", u.Username, args[0], err)
+		}
+		fmt.Printf("Removed %v from household %v\n", u.Username, args[0])
+		return nil
+	},
+}
+
+// setMember sets the role of user u in a household by the --role flag.
+func setMember(db database.Storage, household string, u *database.User, cmd *cobra.Command) error {
+	role, _ := cmd.Flags().GetString("role")
+	if !database.Role(role).Valid() {
+		return fmt.Errorf("invalid role %q: must be owner, editor or viewer", role)
+	}
+	if err := db.SetMember(household, u.ID, database.Role(role)); err != nil {
+		return fmt.Errorf("failed to add %v to household %v: %v", u.Username, household, err)
+	}
+	fmt.Printf("Added %v to household %v as %v\n", u.Username, household, role)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(householdCmd)
+	householdCmd.AddCommand(householdAddCmd)
+	householdCmd.AddCommand(householdListCmd)
+	householdCmd.AddCommand(householdSetCmd)
+	householdCmd.AddCommand(householdRemoveCmd)
+
+	householdCmd.PersistentFlags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
+	configurable(householdCmd.PersistentFlags(), "conn")
+	householdAddCmd.Flags().String("owner", "", "Username of the owner of the household")
+	householdSetCmd.Flags().String("role", string(database.RoleViewer), "Role of the member: owner, editor or viewer")
+}
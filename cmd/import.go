@@ -3,43 +3,56 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/joneskoo/mymonies/pkg/datasource"
+	"github.com/joneskoo/mymonies/pkg/datasource/journal"
 	"github.com/joneskoo/mymonies/pkg/datasource/nordea/pdf"
 	"github.com/joneskoo/mymonies/pkg/datasource/nordea/tsv"
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 	"github.com/spf13/cobra"
 )
 
-var serverAddress string
-
 // importCmd represents the import command
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import transaction records into mymonies",
 	Long: `The command import reads transactions from different formats and submits them
-	to a mymonies server.`,
-	Args: requiredFilesWithTypes(".txt", ".pdf"),
+	to a mymonies server.
+
+Beancount (.beancount, .bean) and hledger (.journal, .hledger, .ledger)
+journals are imported as one import per asset or liability account, e.g.
+Assets:FI1234 is imported as account FI1234. The expense or income account of
+a transaction is its tag, e.g. Expenses:Food:Groceries is tagged
+Food:Groceries, and missing tags are created. Entries that cannot be imported,
+such as entries split to several expense accounts, are reported and skipped.`,
+	Args: requiredFilesWithTypes(append([]string{".txt", ".pdf"}, journal.Extensions...)...),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		client := mymonies.NewMymoniesProtobufClient(serverAddress, &http.Client{})
+		client := newClient(cmd)
+		tags := &tagResolver{client: client}
 		for _, filename := range args {
-			f, err := parseFile(filename)
+			files, err := parseFile(filename)
 			if err != nil {
 				return fmt.Errorf("%v: %v", filename, err)
 			}
-			_, err = client.AddImport(ctx, &mymonies.AddImportReq{
-				Account:      f.Account(),
-				FileName:     f.FileName(),
-				Transactions: f.Transactions(),
-			})
-			if err != nil {
-				return fmt.Errorf("%v: %v", filename, err)
+			for _, f := range files {
+				if err := tags.resolve(ctx, f); err != nil {
+					return fmt.Errorf("%v: %v", filename, err)
+				}
+				_, err = client.AddImport(ctx, &mymonies.AddImportReq{
+					Account:      f.Account(),
+					FileName:     f.FileName(),
+					Currency:     f.Currency(),
+					Transactions: f.Transactions(),
+				})
+				if err != nil {
+					return fmt.Errorf("%v: %v", filename, err)
+				}
+				fmt.Println(f.FileName(), f.Account(), len(f.Transactions()), "transactions")
 			}
-			fmt.Println(f.FileName(), len(f.Transactions()), "transactions")
 		}
 		return nil
 	},
@@ -67,24 +80,89 @@ func requiredFilesWithTypes(types ...string) cobra.PositionalArgs {
 	}
 }
 
-func parseFile(filename string) (datasource.File, error) {
+func parseFile(filename string) ([]datasource.File, error) {
 	ext := filepath.Ext(filename)
+	var f datasource.File
+	var err error
 	switch ext {
 	case ".pdf":
-		return pdf.FromFile(filename)
+		f, err = pdf.FromFile(filename)
 	case ".tsv":
-		return tsv.FromFile(filename)
+		f, err = tsv.FromFile(filename)
 	case ".txt":
-		return tsv.FromFile(filename)
+		f, err = tsv.FromFile(filename)
+	case ".beancount", ".bean", ".journal", ".hledger", ".ledger":
+		return parseJournal(filename)
 	default:
 		return nil, fmt.Errorf("file type extension %q is not supported", ext)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return []datasource.File{f}, nil
+}
+
+// parseJournal reads the accounts of a journal and reports the entries
+// that cannot be imported.
+func parseJournal(filename string) ([]datasource.File, error) {
+	j, err := journal.FromFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range j.Skipped {
+		fmt.Fprintln(os.Stderr, "skipped:", s)
+	}
+	files := make([]datasource.File, len(j.Files))
+	for i, f := range j.Files {
+		files[i] = f
+	}
+	return files, nil
+}
 
+// tagResolver sets the tag ids of imported transactions by tag name,
+// creating the tags that don't exist.
+type tagResolver struct {
+	client mymonies.Mymonies
+	ids    map[string]string // by lower case name
+}
+
+func (r *tagResolver) resolve(ctx context.Context, f datasource.File) error {
+	tf, ok := f.(datasource.TaggedFile)
+	if !ok {
+		return nil
+	}
+	if r.ids == nil {
+		resp, err := r.client.ListTags(ctx, &mymonies.ListTagsReq{})
+		if err != nil {
+			return err
+		}
+		r.ids = make(map[string]string)
+		for _, t := range resp.Tags {
+			r.ids[strings.ToLower(t.Name)] = t.Id
+		}
+	}
+	transactions := tf.Transactions()
+	for i, name := range tf.Tags() {
+		if name == "" {
+			continue
+		}
+		id, ok := r.ids[strings.ToLower(name)]
+		if !ok {
+			resp, err := r.client.AddTag(ctx, &mymonies.AddTagReq{Name: name})
+			if err != nil {
+				return fmt.Errorf("add tag %q: %v", name, err)
+			}
+			id = resp.Tag.Id
+			r.ids[strings.ToLower(name)] = id
+		}
+		transactions[i].TagId = id
+	}
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(importCmd)
 
-	importCmd.PersistentFlags().StringVar(&serverAddress, "mymonies", "http://127.0.0.1:8000", "Store imported transactions to mymonies server")
+	addClientFlags(importCmd.PersistentFlags(), "Store imported transactions to mymonies server")
 	// importCmd.PersistentFlags().String("json", "", "Output imported data as JSON files into directory")
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/joneskoo/mymonies/pkg/auth/oidctest"
+	"github.com/spf13/cobra"
+)
+
+// oidcMockCmd represents the oidc-mock command
+var oidcMockCmd = &cobra.Command{
+	Use:   "oidc-mock",
+	Short: "Run a mock OpenID Connect provider for testing login",
+	Long: `The command oidc-mock runs an OpenID Connect provider that logs in a
+	fixed user without asking for credentials. It is meant for testing only:
+
+	  mymonies oidc-mock --email alice@example.com &
+	  mymonies server --oidc-issuer http://127.0.0.1:9000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		clientID, _ := cmd.Flags().GetString("client-id")
+		email, _ := cmd.Flags().GetString("email")
+		p, err := oidctest.New("http://"+listen, clientID, email)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Mock OpenID Connect provider http://%s logs in %s (sub %s)\n", listen, p.Email, p.Subject)
+		return http.ListenAndServe(listen, p)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(oidcMockCmd)
+
+	oidcMockCmd.Flags().String("listen", "127.0.0.1:9000", "HTTP listen address")
+	oidcMockCmd.Flags().String("client-id", "mymonies", "Accepted OpenID Connect client ID")
+	oidcMockCmd.Flags().String("email", "alice@example.com", "Email address of the user who logs in")
+}
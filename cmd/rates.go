@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/currency"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+	"github.com/spf13/cobra"
+)
+
+// ratesCmd represents the rates command
+var ratesCmd = &cobra.Command{
+	Use:   "rates",
+	Short: "Manage currency exchange rates",
+}
+
+// ratesImportCmd represents the rates import command
+var ratesImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import ECB euro foreign exchange reference rates into mymonies",
+	Long: `The command rates import reads euro foreign exchange reference rates
+	published by the European Central Bank and submits them to a mymonies server.
+	Both the CSV and XML formats are supported, e.g. eurofxref-hist.csv
+	extracted from eurofxref-hist.zip.`,
+	Args: requiredFilesWithTypes(".csv", ".xml"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		client := newClient(cmd)
+		for _, filename := range args {
+			rates, err := exchangerate.FromFile(filename)
+			if err != nil {
+				return fmt.Errorf("%v: %v", filename, err)
+			}
+			req := &mymonies.AddExchangeRatesReq{}
+			skipped := 0
+			for _, r := range rates {
+				// Historical files contain rates of currencies that
+				// have since been replaced by the euro.
+				if !currency.Valid(r.Currency) {
+					skipped++
+					continue
+				}
+				req.Rates = append(req.Rates, &mymonies.ExchangeRate{
+					Date:     r.Date.Format(time.RFC3339),
+					Currency: r.Currency,
+					Rate:     r.Rate.String(),
+				})
+			}
+			if _, err := client.AddExchangeRates(ctx, req); err != nil {
+				return fmt.Errorf("%v: %v", filename, err)
+			}
+			fmt.Println(filename, len(req.Rates), "exchange rates,", skipped, "skipped")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ratesCmd)
+	ratesCmd.AddCommand(ratesImportCmd)
+
+	addClientFlags(ratesCmd.PersistentFlags(), "Store exchange rates to mymonies server")
+}
@@ -5,9 +5,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -19,6 +21,9 @@ var rootCmd = &cobra.Command{
 	Short: "Spend categorization tool",
 	Long: `mymonies is an utility to tag bank transaction records and credit card
 statements by spend category.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyConfig(cmd)
+	},
 }
 
 func Execute() {
@@ -52,10 +57,92 @@ func initConfig() {
 		viper.SetConfigName(".mymonies")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	viper.SetEnvPrefix("mymonies")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv() // read in environment variables that match, e.g. MYMONIES_TOKEN
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	// If a config file is found, read it in. The message goes to standard
+	// error so that it does not mix with output, e.g. of mymonies backup.
+	err := viper.ReadInConfig()
+	if _, ok := err.(viper.ConfigFileNotFoundError); err != nil && !ok {
+		fmt.Fprintln(os.Stderr, "Failed to read config file:", err)
+		os.Exit(1)
+	}
+	if err == nil {
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	}
+}
+
+// configAnnotation marks flags that can be set in the config file or
+// environment.
+const configAnnotation = "mymonies_config"
+
+// configurable allows setting the named flags in the config file or
+// environment, with the flag name as the key. For example, the flag
+// --oidc-issuer can be set with oidc-issuer in the config file or
+// MYMONIES_OIDC_ISSUER. The precedence is, from highest to lowest: command
+// line flags, environment, config file and flag defaults.
+func configurable(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		if err := flags.SetAnnotation(name, configAnnotation, []string{"true"}); err != nil {
+			panic(err)
+		}
 	}
 }
+
+// applyConfig sets the configurable flags of cmd not given on the command
+// line from the environment or config file.
+func applyConfig(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed || f.Annotations[configAnnotation] == nil || !viper.IsSet(f.Name) {
+			return
+		}
+		value := configValue(f.Name)
+		if serr := f.Value.Set(value); serr != nil {
+			err = fmt.Errorf("invalid %v %q in %v: %v", f.Name, value, configSource(f.Name), serr)
+		}
+	})
+	return err
+}
+
+// configValue returns the value of key in the environment or config file.
+// Lists in the config file are joined with commas, e.g. for
+// trusted-proxies.
+func configValue(key string) string {
+	switch v := viper.Get(key).(type) {
+	case []interface{}:
+		values := make([]string, len(v))
+		for i := range v {
+			values[i] = fmt.Sprint(v[i])
+		}
+		return strings.Join(values, ",")
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return viper.GetString(key)
+}
+
+// configSource describes where the value of key is set: environment,
+// config file or default.
+func configSource(key string) string {
+	if _, ok := os.LookupEnv(envVar(key)); ok {
+		return "environment " + envVar(key)
+	}
+	if viper.InConfig(key) {
+		return "config file"
+	}
+	return "default"
+}
+
+// envVar returns the environment variable of key, e.g. MYMONIES_TOKEN.
+func envVar(key string) string {
+	return "MYMONIES_" + strings.ToUpper(strings.Replace(key, "-", "_", -1))
+}
+
+// setting returns the value of a flag as a string. Configurable flags not
+// given on the command line have the value of the environment or config
+// file, see configurable.
+func setting(cmd *cobra.Command, name string) string {
+	return cmd.Flags().Lookup(name).Value.String()
+}
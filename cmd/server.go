@@ -1,16 +1,33 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/joneskoo/mymonies/pkg/assets"
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/events"
+	"github.com/joneskoo/mymonies/pkg/logging"
+	"github.com/joneskoo/mymonies/pkg/metrics"
 	"github.com/joneskoo/mymonies/pkg/middleware"
 	"github.com/joneskoo/mymonies/pkg/mymoniesserver"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+	"github.com/joneskoo/mymonies/pkg/tlscert"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/twitchtv/twirp"
 	// Register statik assets (generated from 'public')
 	_ "github.com/joneskoo/mymonies/pkg/statik"
 )
@@ -22,23 +39,226 @@ var serverCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		conn, _ := cmd.Flags().GetString("conn")
 		listen, _ := cmd.Flags().GetString("listen")
-		logger := log.New(os.Stdout, "[mymonies] ", log.Lshortfile)
-		logger.Println("Listening on http://" + listen)
+		demo, _ := cmd.Flags().GetBool("demo")
+		requireAuth, _ := cmd.Flags().GetBool("auth")
+		logger, err := logging.New(os.Stdout, setting(cmd, "log-format"), setting(cmd, "log-level"))
+		if err != nil {
+			return err
+		}
+		var opts mymoniesserver.DBOptions
+		if err := durationSettings(cmd, map[string]*time.Duration{
+			"slow-query":           &opts.SlowQuery,
+			"db-conn-max-lifetime": &opts.ConnMaxLifetime,
+			"db-connect-timeout":   &opts.ConnectTimeout,
+		}); err != nil {
+			return err
+		}
+		trusted, err := middleware.ParseTrustedProxies(strings.Split(setting(cmd, "trusted-proxies"), ","))
+		if err != nil {
+			return err
+		}
+		certFile, keyFile := setting(cmd, "tls-cert"), setting(cmd, "tls-key")
+		if (certFile == "") != (keyFile == "") {
+			return fmt.Errorf("--tls-cert and --tls-key must be given together")
+		}
+		redirectListen, _ := cmd.Flags().GetString("http-redirect-listen")
+		if redirectListen != "" && certFile == "" {
+			return fmt.Errorf("--http-redirect-listen requires --tls-cert and --tls-key")
+		}
+		opts.MaxOpenConns, _ = cmd.Flags().GetInt("db-max-open-conns")
+		opts.MaxIdleConns, _ = cmd.Flags().GetInt("db-max-idle-conns")
+		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		maxRequestBody, _ := cmd.Flags().GetInt64("max-request-body")
+
+		// ctx is done on SIGINT or SIGTERM, when the server shuts down.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
-		server, err := mymoniesserver.New(conn, logger)
+		var db database.Storage
+		if demo {
+			db, err = mymoniesserver.OpenDemo(logger)
+			// The demo database has no users to log in as.
+			requireAuth = false
+		} else {
+			db, err = mymoniesserver.Open(ctx, conn, logger, opts)
+		}
 		if err != nil {
 			return err
 		}
-		h := handler(server)
-		return http.ListenAndServe(listen, h)
+		defer db.Close()
+
+		var a *auth.Auth
+		if requireAuth {
+			a = auth.New(db)
+			logger.Info("login required; add users with: mymonies user add")
+			if issuer := setting(cmd, "oidc-issuer"); issuer != "" {
+				err := a.EnableOIDC(auth.OIDCConfig{
+					Issuer:       issuer,
+					ClientID:     setting(cmd, "oidc-client-id"),
+					ClientSecret: setting(cmd, "oidc-client-secret"),
+					RedirectURL:  setting(cmd, "oidc-redirect-url"),
+					Claim:        setting(cmd, "oidc-claim"),
+				})
+				if err != nil {
+					return err
+				}
+				logger.Info("OpenID Connect login enabled", "issuer", issuer)
+			}
+		} else {
+			logger.Warn("authentication is disabled")
+		}
+		hub := events.NewHub()
+		m := metrics.New(db)
+		var servers []*http.Server
+		metricsListen, _ := cmd.Flags().GetString("metrics-listen")
+		if metricsListen != "" {
+			// Serve metrics only on the separate address, e.g. one
+			// reachable by the Prometheus server but not the internet.
+			l, err := net.Listen("tcp", metricsListen)
+			if err != nil {
+				return err
+			}
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", m.Handler())
+			srv := &http.Server{Handler: mux}
+			servers = append(servers, srv)
+			logger.Info("serving metrics on http://" + metricsListen + "/metrics")
+			go serve(logger, func() error { return srv.Serve(l) })
+		}
+
+		// In development mode the web interface is served from disk
+		// instead of the files compiled in with statik.
+		var live *assets.Live
+		if dev, _ := cmd.Flags().GetBool("dev"); dev {
+			dir := setting(cmd, "public-dir")
+			if live, err = assets.NewLive(dir, logger); err != nil {
+				return err
+			}
+			logger.Warn("development mode, serving the web interface from disk", "dir", dir)
+		}
+
+		h := handler(handlerConfig{
+			server:                mymoniesserver.New(db, hub, m, logger),
+			db:                    db,
+			hub:                   hub,
+			logger:                logger,
+			metrics:               m,
+			serveMetrics:          metricsListen == "",
+			auth:                  a,
+			shutdown:              ctx,
+			trusted:               trusted,
+			maxRequestBody:        maxRequestBody,
+			contentSecurityPolicy: setting(cmd, "content-security-policy"),
+			live:                  live,
+		})
+		l, err := net.Listen("tcp", listen)
+		if err != nil {
+			return err
+		}
+		srv := &http.Server{Handler: h}
+		// Event streams never end by themselves.
+		srv.RegisterOnShutdown(hub.Close)
+		if live != nil {
+			srv.RegisterOnShutdown(func() { live.Close() })
+		}
+		servers = append(servers, srv)
+		if certFile != "" {
+			certs, err := tlscert.NewReloader(certFile, keyFile, logger)
+			if err != nil {
+				return err
+			}
+			srv.TLSConfig = &tls.Config{
+				GetCertificate: certs.GetCertificate,
+				MinVersion:     tls.VersionTLS12,
+			}
+			logger.Info("listening on https://" + listen)
+			go serve(logger, func() error { return srv.ServeTLS(l, "", "") })
+		} else {
+			logger.Info("listening on http://" + listen)
+			go serve(logger, func() error { return srv.Serve(l) })
+		}
+		if redirectListen != "" {
+			_, port, _ := net.SplitHostPort(listen)
+			l, err := net.Listen("tcp", redirectListen)
+			if err != nil {
+				return err
+			}
+			redirect := &http.Server{Handler: middleware.RedirectHTTPS(port)}
+			servers = append(servers, redirect)
+			logger.Info("redirecting http://" + redirectListen + " to HTTPS")
+			go serve(logger, func() error { return redirect.Serve(l) })
+		}
+
+		<-ctx.Done()
+		// A second signal stops the server without waiting.
+		stop()
+		logger.Info("shutting down, waiting for requests to finish", "timeout", shutdownTimeout)
+		drain, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		for _, srv := range servers {
+			if err := srv.Shutdown(drain); err != nil {
+				return fmt.Errorf("shutdown: %v", err)
+			}
+		}
+		logger.Info("server stopped")
+		return nil
 	},
 }
 
+// serve serves HTTP requests with a Serve method of http.Server until the
+// server is shut down. The program exits if serving fails for another
+// reason.
+func serve(logger *slog.Logger, serve func() error) {
+	if err := serve(); err != http.ErrServerClosed {
+		logger.Error("server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// durationSettings parses duration settings, see setting.
+func durationSettings(cmd *cobra.Command, settings map[string]*time.Duration) error {
+	for name, d := range settings {
+		var err error
+		if *d, err = time.ParseDuration(setting(cmd, name)); err != nil {
+			return fmt.Errorf("invalid --%v: %v", name, err)
+		}
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
 
-	serverCmd.Flags().String("conn", "database=mymonies", "PostgreSQL connection string")
+	serverCmd.Flags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
 	serverCmd.Flags().String("listen", defaultListen(), "HTTP server listen address")
+	serverCmd.Flags().Bool("demo", false, "Serve example data from memory instead of a database, without authentication")
+	serverCmd.Flags().Bool("auth", true, "Require login for the API")
+	serverCmd.Flags().Bool("dev", false, "Development mode: serve the web interface from --public-dir on disk and reload open pages when the files change")
+	serverCmd.Flags().String("public-dir", "public", "Directory of the web interface files in development mode")
+	serverCmd.Flags().String("tls-cert", "", "Serve HTTPS with the PEM certificate chain of this file, reloaded when the file changes")
+	serverCmd.Flags().String("tls-key", "", "PEM private key file of the HTTPS certificate")
+	serverCmd.Flags().String("http-redirect-listen", "", "Listen address for plain HTTP redirecting to HTTPS, e.g. :80")
+	serverCmd.Flags().String("trusted-proxies", "", "Comma separated IP addresses or CIDR ranges of reverse proxies whose X-Forwarded-For and X-Forwarded-Proto headers are trusted")
+	serverCmd.Flags().String("log-format", "text", "Log format: text (key=value pairs) or json")
+	serverCmd.Flags().String("log-level", "info", "Minimum level of logged messages: debug, info, warn or error; debug logs SQL queries")
+	serverCmd.Flags().Duration("slow-query", 200*time.Millisecond, "Log SQL queries taking at least this long as warnings, 0 to disable")
+	serverCmd.Flags().Int("db-max-open-conns", 0, "Maximum number of open database connections, 0 for unlimited")
+	serverCmd.Flags().Int("db-max-idle-conns", 2, "Maximum number of idle database connections")
+	serverCmd.Flags().Duration("db-conn-max-lifetime", 0, "Close database connections after this long, 0 to keep them open")
+	serverCmd.Flags().Duration("db-connect-timeout", time.Minute, "Retry connecting to the database at startup for this long, 0 to try once")
+	serverCmd.Flags().Duration("shutdown-timeout", 30*time.Second, "Time to wait for requests to finish, e.g. imports, when shutting down on SIGINT or SIGTERM")
+	serverCmd.Flags().Int64("max-request-body", 32<<20, "Maximum size of API request bodies in bytes, e.g. of imports")
+	serverCmd.Flags().String("content-security-policy", middleware.DefaultContentSecurityPolicy, "Content-Security-Policy header of responses, empty for none")
+	serverCmd.Flags().String("metrics-listen", "", "Listen address for Prometheus metrics without authentication (default serve /metrics on the main listen address, requiring login or an API token)")
+	serverCmd.Flags().String("oidc-issuer", "", "Issuer URL of an OpenID Connect provider to log in with")
+	serverCmd.Flags().String("oidc-client-id", "mymonies", "OpenID Connect client ID")
+	serverCmd.Flags().String("oidc-client-secret", "", "OpenID Connect client secret, preferably set in the config file or MYMONIES_OIDC_CLIENT_SECRET")
+	serverCmd.Flags().String("oidc-redirect-url", "", "OpenID Connect redirect URL (default derived from the request, e.g. http://HOST/auth/oidc/callback)")
+	serverCmd.Flags().String("oidc-claim", "email", "ID token claim matching the mymonies username: email or sub")
+	// All options of the server can be set in the config file.
+	serverCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		configurable(serverCmd.Flags(), f.Name)
+	})
 }
 
 func defaultListen() string {
@@ -50,33 +270,104 @@ func defaultListen() string {
 
 }
 
-func handler(server mymonies.Mymonies) http.Handler {
+// handlerConfig is the configuration of the HTTP handler of the server.
+type handlerConfig struct {
+	server mymonies.Mymonies
+	// db is the storage of event subscriptions, exports and probes.
+	db database.Storage
+	// hub publishes the live updates of event streams.
+	hub *events.Hub
+	// logger logs requests and failed API calls.
+	logger *slog.Logger
+	// metrics measures API calls. The metrics are served at /metrics if
+	// serveMetrics is true, authenticated like the API.
+	metrics      *metrics.Metrics
+	serveMetrics bool
+	// auth authenticates API requests; if nil, the API is served without
+	// authentication.
+	auth *auth.Auth
+	// shutdown is done when the server is shutting down and no longer
+	// ready for new requests.
+	shutdown context.Context
+	// trusted are the reverse proxies whose forwarding headers are used.
+	trusted middleware.TrustedProxies
+	// maxRequestBody is the size limit of API request bodies in bytes.
+	maxRequestBody int64
+	// contentSecurityPolicy is the Content-Security-Policy header of
+	// responses, or "" for none.
+	contentSecurityPolicy string
+	// live serves the web interface from disk in development mode; if nil,
+	// the files embedded with statik are served.
+	live *assets.Live
+}
+
+// handler returns the HTTP handler of the server.
+func handler(c handlerConfig) http.Handler {
 	mux := http.NewServeMux()
 
-	// Twirp RPC handler with prometheus metrics
-	// hooks := prometheus.NewServerHooks(nil)
-	twirpHandler := mymonies.NewMymoniesServer(server, nil)
-	mux.Handle(mymonies.MymoniesPathPrefix, twirpHandler)
+	// Twirp RPC handler with prometheus metrics and error logging
+	hooks := twirp.ChainHooks(c.metrics.ServerHooks(), logging.ServerHooks(c.logger))
+	var twirpHandler http.Handler = mymonies.NewMymoniesServer(c.server, hooks)
+	twirpHandler = middleware.MaxRequestBody(c.maxRequestBody)(twirpHandler)
 
-	// Prometheus metrics endpoint
-	// mux.Handle("/metrics", promhttp.Handler())
+	// The API and the plain HTTP endpoints next to it share authentication
+	// and household selection.
+	endpoints := map[string]http.Handler{
+		mymonies.MymoniesPathPrefix: twirpHandler,
+		mymoniesserver.EventsPath:   mymoniesserver.Events(c.db, c.hub),
+		mymoniesserver.ExportPath:   mymoniesserver.Export(c.db, c.logger),
+	}
+	for path, h := range endpoints {
+		h = mymoniesserver.HouseholdFromHeader(h)
+		if c.auth != nil {
+			h = middleware.RequireAuthentication(c.auth)(h)
+		}
+		mux.Handle(path, h)
+	}
+	if c.auth != nil {
+		mux.Handle("/auth/", c.auth.Handler("/auth/"))
+	}
 
-	// Static file server
-	statikFS, err := fs.New()
-	if err != nil {
-		log.Fatal(err)
+	// Prometheus metrics endpoint. The metrics include activity of all
+	// households, so they are not public on the shared listener.
+	if c.serveMetrics {
+		var h http.Handler = c.metrics.Handler()
+		if c.auth != nil {
+			h = middleware.RequireAuthentication(c.auth)(h)
+		}
+		mux.Handle("/metrics", h)
 	}
-	mux.Handle("/", http.FileServer(statikFS))
 
-	// Apply middlewares
+	// Static file server, with long-lived caching of fingerprinted URLs
+	if c.live != nil {
+		mux.Handle("/", c.live)
+	} else {
+		statikFS, err := fs.New()
+		if err != nil {
+			log.Fatal(err)
+		}
+		mux.Handle("/", assets.New(statikFS))
+	}
+
+	// Apply middlewares, the first outermost
+	middlewares := []middleware.Middleware{
+		middleware.RequestID(),
+		middleware.ProxyHeaders(c.trusted),
+		middleware.RequestLogger(c.logger),
+		middleware.SecurityHeaders(c.contentSecurityPolicy),
+		middleware.Compress(),
+		// The default of responses other than static files.
+		middleware.SetResponseHeader("Cache-Control", "no-cache"),
+	}
 	var h http.Handler = mux
-	for _, mw := range middlewares {
-		h = mw(h)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
 	}
-	return h
-}
 
-var middlewares = []middleware.Middleware{
-	middleware.RequestLogger(os.Stdout),
-	middleware.SetResponseHeader("Cache-Control", "no-cache"),
+	// Probes are frequent and not logged.
+	probes := http.NewServeMux()
+	probes.Handle(mymoniesserver.HealthPath, mymoniesserver.Health())
+	probes.Handle(mymoniesserver.ReadyPath, mymoniesserver.Ready(c.shutdown, c.db))
+	probes.Handle("/", h)
+	return probes
 }
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	"github.com/spf13/cobra"
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage API tokens for scheduled imports",
+}
+
+// tokenCreateCmd represents the token create command
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create USERNAME",
+	Short: "Create an API token of a user",
+	Long: `The command token create creates an API token that authenticates as
+	the user, e.g. for mymonies import --token. The token is printed only once.
+	The scope limits what the token can do: import allows importing
+	transactions, exchange rates and tags, read allows listing and exporting
+	data and scraping metrics, and all allows everything the user can do.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		scope, _ := cmd.Flags().GetString("scope")
+		if !auth.Scope(scope).Valid() {
+			return fmt.Errorf("invalid scope %q: must be all, read or import", scope)
+		}
+		db, u, err := connectUser(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		token, _, err := auth.CreateToken(db, u.ID, name, auth.Scope(scope))
+		if err != nil {
+			return err
+		}
+		fmt.Println(token)
+		return nil
+	},
+}
+
+// tokenListCmd represents the token list command
+var tokenListCmd = &cobra.Command{
+	Use:   "list USERNAME",
+	Short: "List API tokens of a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, u, err := connectUser(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		tokens, err := db.ListTokens(u.ID)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSCOPE\tCREATED")
+		for _, t := range tokens {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.ID, t.Name, t.Scope, t.Created.Format("2006-01-02 15:04"))
+		}
+		return w.Flush()
+	},
+}
+
+// tokenRevokeCmd represents the token revoke command
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke USERNAME ID",
+	Short: "Revoke an API token of a user",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, u, err := connectUser(cmd, args[0])
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := db.DeleteToken(u.ID, args[1]); err != nil {
+			return fmt.Errorf("failed to revoke token %v: %v", args[1], err)
+		}
+		fmt.Println("Revoked token", args[1])
+		return nil
+	},
+}
+
+// connectUser connects to the database and finds a user.
+func connectUser(cmd *cobra.Command, username string) (database.Storage, *database.User, error) {
+	db, err := connectMigratedDB(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	u, err := db.GetUser(username)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to find user %q: %v", username, err)
+	}
+	return db, u, nil
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	tokenCmd.PersistentFlags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
+	configurable(tokenCmd.PersistentFlags(), "conn")
+	tokenCreateCmd.Flags().String("name", "import", "Name of the token, e.g. the host running scheduled imports")
+	tokenCreateCmd.Flags().String("scope", string(auth.ScopeImport), "Scope of the token: import, read or all")
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	"github.com/spf13/cobra"
+)
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage users who can log in to the server",
+}
+
+// userAddCmd represents the user add command
+var userAddCmd = &cobra.Command{
+	Use:   "add USERNAME",
+	Short: "Add a user",
+	Long: `The command user add adds a user who can log in to the server. The
+	password is read from the first line of standard input. The user is added
+	to the default household unless another household ID is given with
+	--household, or none with --household "". Users who only log in with
+	OpenID Connect are added with --no-password; their username must equal
+	the configured ID token claim, e.g. their email address.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if role, _ := cmd.Flags().GetString("role"); !database.Role(role).Valid() {
+			return fmt.Errorf("invalid role %q: must be owner, editor or viewer", role)
+		}
+		var hash string
+		if noPassword, _ := cmd.Flags().GetBool("no-password"); !noPassword {
+			var err error
+			if hash, err = readPassword(os.Stdin); err != nil {
+				return err
+			}
+		}
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		u := &database.User{Username: args[0], PasswordHash: hash}
+		if err := db.AddUser(u); err != nil {
+			return fmt.Errorf("failed to add user %q: %v", args[0], err)
+		}
+		fmt.Println("Added user", args[0])
+		household, _ := cmd.Flags().GetString("household")
+		if household == "" {
+			return nil
+		}
+		return setMember(db, household, u, cmd)
+	},
+}
+
+// userPasswdCmd represents the user passwd command
+var userPasswdCmd = &cobra.Command{
+	Use:   "passwd USERNAME",
+	Short: "Change the password of a user",
+	Long: `The command user passwd changes the password of a user and logs out
+	all sessions of the user. The password is read from the first line of
+	standard input.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hash, err := readPassword(os.Stdin)
+		if err != nil {
+			return err
+		}
+		db, err := connectMigratedDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		u, err := db.GetUser(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to find user %q: %v", args[0], err)
+		}
+		if err := db.SetPassword(u.ID, hash); err != nil {
+			return err
+		}
+		fmt.Println("Changed password of user", args[0])
+		return nil
+	},
+}
+
+// readPassword reads a password line from r and returns its hash.
+func readPassword(r io.Reader) (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return auth.HashPassword(strings.TrimRight(line, "\r\n"))
+}
+
+// connectMigratedDB connects to the database and migrates it, so that users
+// can be added before the server is started for the first time.
+func connectMigratedDB(cmd *cobra.Command) (database.Storage, error) {
+	db, err := connectDB(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userAddCmd)
+	userCmd.AddCommand(userPasswdCmd)
+
+	userCmd.PersistentFlags().String("conn", "database=mymonies", "PostgreSQL connection string, or sqlite:FILE for a SQLite database")
+	configurable(userCmd.PersistentFlags(), "conn")
+	userAddCmd.Flags().String("household", database.DefaultHousehold, "ID of household to add the user to")
+	userAddCmd.Flags().Bool("no-password", false, "Add the user without a password, for OpenID Connect login only")
+	userAddCmd.Flags().String("role", string(database.RoleOwner), "Role of the user in the household: owner, editor or viewer")
+}
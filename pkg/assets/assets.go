@@ -0,0 +1,204 @@
+// Package assets serves the static files of the web interface. The files
+// are loaded into memory on first use, with strong ETags and precompressed
+// brotli and gzip variants. References from HTML pages to the other files are
+// fingerprinted with the hash of the file, e.g. resources/js/mymonies.js?v=
+// 0123456789abcdef, so that fingerprinted URLs can be cached forever while
+// the pages themselves are revalidated on every load.
+package assets
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/joneskoo/mymonies/pkg/middleware"
+)
+
+// FingerprintParam is the query parameter of fingerprinted URLs.
+const FingerprintParam = "v"
+
+// Cache-Control of fingerprinted and other responses.
+const (
+	immutable   = "public, max-age=31536000, immutable"
+	revalidated = "no-cache"
+)
+
+// asset is a file with its precompressed variants.
+type asset struct {
+	modTime time.Time
+	hash    string
+	// content is the file content by content coding, "" for identity.
+	content map[string][]byte
+}
+
+// Handler serves the files of a file system.
+type Handler struct {
+	fsys http.FileSystem
+	// inject is added to the end of the body of pages, e.g. the live
+	// reload script.
+	inject []byte
+
+	mu     sync.Mutex
+	assets map[string]*asset // loaded files by name
+}
+
+// New returns a handler of the files of fsys, e.g. the statik file system
+// of the public directory. The files are loaded when first requested, as
+// fsys may not list directories.
+func New(fsys http.FileSystem) *Handler {
+	return &Handler{fsys: fsys, assets: map[string]*asset{}}
+}
+
+// load returns the file name, loading it on first use. Pages are
+// fingerprinted. h.mu must be held.
+func (h *Handler) load(name string) (*asset, error) {
+	if a, ok := h.assets[name]; ok {
+		return a, nil
+	}
+	f, err := h.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if path.Ext(name) == ".html" {
+		content = h.fingerprint(name, content)
+		if len(h.inject) > 0 {
+			content = injectBody(content, h.inject)
+		}
+	}
+	a := newAsset(name, fi.ModTime(), content)
+	h.assets[name] = a
+	return a, nil
+}
+
+// newAsset hashes content and compresses it, if it is compressible and
+// compression makes it smaller.
+func newAsset(name string, modTime time.Time, content []byte) *asset {
+	sum := sha256.Sum256(content)
+	a := &asset{
+		modTime: modTime,
+		hash:    hex.EncodeToString(sum[:8]),
+		content: map[string][]byte{"": content},
+	}
+	if !middleware.Compressible(mime.TypeByExtension(path.Ext(name))) {
+		return a
+	}
+	for coding, newWriter := range map[string]func(io.Writer) io.WriteCloser{
+		middleware.Brotli: func(w io.Writer) io.WriteCloser { return brotli.NewWriterLevel(w, brotli.BestCompression) },
+		middleware.Gzip: func(w io.Writer) io.WriteCloser {
+			gw, _ := gzip.NewWriterLevel(w, gzip.BestCompression)
+			return gw
+		},
+	} {
+		var buf bytes.Buffer
+		w := newWriter(&buf)
+		w.Write(content)
+		w.Close()
+		if buf.Len() < len(content) {
+			a.content[coding] = buf.Bytes()
+		}
+	}
+	return a
+}
+
+// reference matches the relative URL of a src or href attribute.
+var reference = regexp.MustCompile(`(\s(?:src|href)=")([^":?#]+)(")`)
+
+// fingerprint adds the hash of the referenced file to the references of the
+// page name to other files.
+func (h *Handler) fingerprint(name string, page []byte) []byte {
+	return reference.ReplaceAllFunc(page, func(m []byte) []byte {
+		parts := reference.FindSubmatch(m)
+		ref := string(parts[2])
+		target := ref
+		if !strings.HasPrefix(target, "/") {
+			target = path.Join(path.Dir(name), target)
+		}
+		a, err := h.load(target)
+		if err != nil {
+			return m
+		}
+		return []byte(fmt.Sprintf("%s%s?%s=%s%s", parts[1], ref, FingerprintParam, a.hash, parts[3]))
+	})
+}
+
+// injectBody adds inject to the end of the body of page.
+func injectBody(page, inject []byte) []byte {
+	i := bytes.LastIndex(page, []byte("</body>"))
+	if i < 0 {
+		i = len(page)
+	}
+	injected := append([]byte{}, page[:i]...)
+	injected = append(injected, inject...)
+	return append(injected, page[i:]...)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + r.URL.Path)
+	if strings.HasSuffix(r.URL.Path, "/") {
+		name = path.Join(name, "index.html")
+	}
+	h.mu.Lock()
+	a, err := h.load(name)
+	h.mu.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		http.Error(w, "failed to read file", http.StatusInternalServerError)
+		return
+	}
+	header := w.Header()
+	if v := r.URL.Query().Get(FingerprintParam); v != "" && v == a.hash {
+		header.Set("Cache-Control", immutable)
+	} else {
+		header.Set("Cache-Control", revalidated)
+	}
+	var codings []string
+	for _, coding := range []string{middleware.Brotli, middleware.Gzip} {
+		if _, ok := a.content[coding]; ok {
+			codings = append(codings, coding)
+		}
+	}
+	coding := ""
+	if len(codings) > 0 {
+		header.Add("Vary", "Accept-Encoding")
+		coding = middleware.NegotiateEncoding(r, codings...)
+	}
+	etag := a.hash
+	if coding != "" {
+		header.Set("Content-Encoding", coding)
+		etag += "-" + coding
+	}
+	header.Set("ETag", `"`+etag+`"`)
+	if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+		header.Set("Content-Type", ctype)
+	}
+	http.ServeContent(w, r, name, a.modTime, bytes.NewReader(a.content[coding]))
+}
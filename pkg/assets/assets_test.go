@@ -0,0 +1,89 @@
+package assets
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/andybalholm/brotli"
+)
+
+var script = strings.Repeat("console.log('mymonies');\n", 100)
+
+func newTestHandler() *Handler {
+	return New(http.FS(fstest.MapFS{
+		"index.html":             {Data: []byte(`<script src="resources/app.js"></script><script src="https://cdn.example.com/x.js"></script><link href="missing.css">`)},
+		"resources/app.js":       {Data: []byte(script)},
+		"resources/img/logo.png": {Data: []byte("\x89PNG")},
+	}))
+}
+
+func get(h http.Handler, target string, header map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", target, nil)
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler_Fingerprint(t *testing.T) {
+	h := newTestHandler()
+	page := get(h, "/", nil)
+	if page.Code != http.StatusOK || page.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("index = %v, Cache-Control %q, want 200 no-cache", page.Code, page.Header().Get("Cache-Control"))
+	}
+	body := page.Body.String()
+	m := regexp.MustCompile(`src="(resources/app\.js\?v=[0-9a-f]{16})"`).FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("index = %q, want fingerprinted reference to app.js", body)
+	}
+	for _, unchanged := range []string{`src="https://cdn.example.com/x.js"`, `href="missing.css"`} {
+		if !strings.Contains(body, unchanged) {
+			t.Errorf("index = %q, want %v unchanged", body, unchanged)
+		}
+	}
+
+	if w := get(h, "/"+m[1], nil); w.Header().Get("Cache-Control") != immutable || w.Body.String() != script {
+		t.Errorf("fingerprinted asset Cache-Control = %q, want %q", w.Header().Get("Cache-Control"), immutable)
+	}
+	if w := get(h, "/resources/app.js?v=outdated", nil); w.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("outdated fingerprint Cache-Control = %q, want no-cache", w.Header().Get("Cache-Control"))
+	}
+	if w := get(h, "/resources/", nil); w.Code != http.StatusNotFound {
+		t.Errorf("directory status = %v, want 404", w.Code)
+	}
+}
+
+func TestHandler_ETag(t *testing.T) {
+	h := newTestHandler()
+	w := get(h, "/resources/app.js", nil)
+	etag := w.Header().Get("ETag")
+	if !regexp.MustCompile(`^"[0-9a-f]{16}"$`).MatchString(etag) {
+		t.Fatalf("ETag = %q, want strong hash", etag)
+	}
+	if w := get(h, "/resources/app.js", map[string]string{"If-None-Match": etag}); w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match status = %v, want 304", w.Code)
+	}
+
+	br := get(h, "/resources/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	if br.Header().Get("Content-Encoding") != "br" || br.Header().Get("ETag") != strings.TrimSuffix(etag, `"`)+`-br"` {
+		t.Fatalf("brotli response Content-Encoding %q, ETag %q", br.Header().Get("Content-Encoding"), br.Header().Get("ETag"))
+	}
+	decoded, err := ioutil.ReadAll(brotli.NewReader(br.Body))
+	if err != nil || string(decoded) != script {
+		t.Errorf("decoded brotli response = %q, %v, want script", decoded, err)
+	}
+	if br.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Vary = %q, want Accept-Encoding", br.Header().Get("Vary"))
+	}
+
+	if w := get(h, "/resources/img/logo.png", map[string]string{"Accept-Encoding": "br"}); w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("image Content-Encoding = %q, want none", w.Header().Get("Content-Encoding"))
+	}
+}
@@ -0,0 +1,221 @@
+// This file contains the development mode serving the files from disk and
+// reloading the open pages when the files change.
+
+package assets
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Paths of the live reload event stream and the script of pages subscribing
+// to it.
+const (
+	ReloadPath       = "/dev/reload"
+	ReloadScriptPath = "/dev/reload.js"
+)
+
+// reloadScript reloads the page when the files change. After a reconnect,
+// e.g. when the server was restarted, files may have changed as well.
+const reloadScript = `(function () {
+    let connected = false;
+    const events = new EventSource('` + ReloadPath + `');
+    events.onopen = function () {
+        if (connected) {
+            location.reload();
+        }
+        connected = true;
+    };
+    events.addEventListener('reload', function () {
+        location.reload();
+    });
+})();
+`
+
+// settleTime is the time to wait for more changes before reloading, as
+// editors and build tools change several files or write a file in steps.
+const settleTime = 100 * time.Millisecond
+
+// Live serves the files of a directory like Handler, reloading them when
+// they change instead of keeping them in memory for good, e.g. to develop
+// the web interface without regenerating the statik files. Open pages are
+// told to reload with server-sent events of a script injected into the
+// pages.
+type Live struct {
+	*Handler
+	watcher *fsnotify.Watcher
+	logger  *slog.Logger
+
+	mu      sync.Mutex
+	clients map[chan struct{}]bool
+	closed  bool
+}
+
+// NewLive returns a live handler of the files of dir and starts watching
+// them for changes. Close stops watching.
+func NewLive(dir string, logger *slog.Logger) (*Live, error) {
+	if fi, err := os.Stat(dir); err != nil {
+		return nil, err
+	} else if !fi.IsDir() {
+		return nil, fmt.Errorf("%v is not a directory", dir)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	h := New(http.Dir(dir))
+	h.inject = []byte(`<script src="` + ReloadScriptPath + `"></script>`)
+	l := &Live{
+		Handler: h,
+		watcher: watcher,
+		logger:  logger,
+		clients: map[chan struct{}]bool{},
+	}
+	if err := l.watch(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	go l.run()
+	return l, nil
+}
+
+// watch watches dir and its subdirectories, as fsnotify watches only the
+// files directly in a directory.
+func (l *Live) watch(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		return l.watcher.Add(path)
+	})
+}
+
+// run reloads the files when they have changed and settled, until the
+// watcher is closed.
+func (l *Live) run() {
+	settled := time.NewTimer(settleTime)
+	settled.Stop()
+	for {
+		select {
+		case e, ok := <-l.watcher.Events:
+			if !ok {
+				return
+			}
+			if e.Op&fsnotify.Create != 0 {
+				if fi, err := os.Stat(e.Name); err == nil && fi.IsDir() {
+					if err := l.watch(e.Name); err != nil {
+						l.logger.Warn("failed to watch directory", "dir", e.Name, "error", err)
+					}
+				}
+			}
+			settled.Reset(settleTime)
+		case err, ok := <-l.watcher.Errors:
+			if !ok {
+				return
+			}
+			l.logger.Warn("failed to watch files", "error", err)
+		case <-settled.C:
+			l.reload()
+		}
+	}
+}
+
+// reload forgets the loaded files and notifies the open pages.
+func (l *Live) reload() {
+	l.Handler.mu.Lock()
+	l.Handler.assets = map[string]*asset{}
+	l.Handler.mu.Unlock()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Info("files changed, reloading pages", "pages", len(l.clients))
+	for ch := range l.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// A reload is pending already.
+		}
+	}
+}
+
+func (l *Live) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case ReloadPath:
+		l.serveEvents(w, r)
+	case ReloadScriptPath:
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		http.ServeContent(w, r, ReloadScriptPath, time.Time{}, bytes.NewReader([]byte(reloadScript)))
+	default:
+		l.Handler.ServeHTTP(w, r)
+	}
+}
+
+// serveEvents streams a reload event whenever the files change.
+func (l *Live) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan struct{}, 1)
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	l.clients[ch] = true
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.remove(ch)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, "retry: 1000\n\n")
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// Close stops watching the files and ends the event streams, e.g. when the
+// server shuts down.
+func (l *Live) Close() error {
+	l.mu.Lock()
+	l.closed = true
+	for ch := range l.clients {
+		l.remove(ch)
+	}
+	l.mu.Unlock()
+	return l.watcher.Close()
+}
+
+// remove closes and removes a client, if it has not been removed yet. The
+// caller must hold l.mu.
+func (l *Live) remove(ch chan struct{}) {
+	if l.clients[ch] {
+		delete(l.clients, ch)
+		close(ch)
+	}
+}
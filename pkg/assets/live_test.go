@@ -0,0 +1,76 @@
+package assets
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
+)
+
+func TestLive(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("index.html", `<html><body><script src="app.js"></script></body></html>`)
+	write("app.js", "console.log('old');")
+
+	live, err := NewLive(dir, logging.Discard())
+	if err != nil {
+		t.Fatal("NewLive() returned error:", err)
+	}
+	defer live.Close()
+	srv := httptest.NewServer(live)
+	defer srv.Close()
+
+	page := get(live, "/", nil).Body.String()
+	if !strings.Contains(page, `<script src="`+ReloadScriptPath+`"></script></body>`) {
+		t.Errorf("page = %q, want reload script injected", page)
+	}
+
+	resp, err := http.Get(srv.URL + ReloadPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewReader(resp.Body)
+	if line, _ := events.ReadString('\n'); !strings.HasPrefix(line, "retry:") {
+		t.Fatalf("first line of stream = %q, want retry", line)
+	}
+
+	write("app.js", "console.log('new');")
+	reloaded := make(chan string, 1)
+	go func() {
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil || strings.HasPrefix(line, "event:") {
+				reloaded <- line
+				return
+			}
+		}
+	}()
+	select {
+	case line := <-reloaded:
+		if line != "event: reload\n" {
+			t.Fatalf("event = %q, want reload", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload event after change")
+	}
+	if got := get(live, "/app.js", nil).Body.String(); got != "console.log('new');" {
+		t.Errorf("app.js after change = %q, want new content", got)
+	}
+
+	if _, err := NewLive(filepath.Join(dir, "missing"), logging.Discard()); !os.IsNotExist(err) {
+		t.Errorf("NewLive() of missing dir returned %v, want not exist", err)
+	}
+}
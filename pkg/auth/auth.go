@@ -0,0 +1,315 @@
+// Package auth implements password and OpenID Connect login, cookie sessions
+// and API tokens for the mymonies web server.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+const (
+	// SessionCookie is the name of the cookie holding the session token.
+	SessionCookie = "mymonies_session"
+	// CSRFCookie is the name of the cookie holding the CSRF token. Unlike
+	// the session cookie, scripts on the page can read it.
+	CSRFCookie = "mymonies_csrf"
+	// CSRFHeader is the request header that must repeat the CSRF token in
+	// POST requests.
+	CSRFHeader = "X-CSRF-Token"
+
+	// SessionDuration is how long a login session is valid.
+	SessionDuration = 30 * 24 * time.Hour
+
+	// MinPasswordLength is the minimum length of a password in bytes.
+	MinPasswordLength = 8
+)
+
+// ErrPasswordTooShort is returned by HashPassword for passwords shorter than
+// MinPasswordLength.
+var ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+
+// HashPassword returns the bcrypt hash of password.
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(hash), err
+}
+
+// CheckPassword reports whether password matches the bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// dummyHash is compared against when logging in as an unknown user, so that
+// the response time does not reveal which usernames exist.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("mymonies dummy password"), bcrypt.DefaultCost)
+
+// Store stores users, sessions and API tokens.
+type Store interface {
+	GetUser(username string) (*database.User, error)
+	AddSession(s *database.Session) error
+	GetSession(id string) (*database.Session, error)
+	DeleteSession(id string) error
+	TokenStore
+}
+
+// Auth serves the login endpoints and authenticates requests by the session
+// cookie or an API token.
+type Auth struct {
+	store Store
+	oidc  *oidcProvider // nil unless OpenID Connect login is enabled
+}
+
+// New returns Auth with users and sessions in store.
+func New(store Store) *Auth {
+	return &Auth{store: store}
+}
+
+// Handler returns the login endpoints under prefix, e.g. "/auth/":
+//
+//	POST prefix+"login"    log in with JSON {"username": ..., "password": ...}
+//	POST prefix+"logout"   log out and delete the session
+//	GET  prefix+"session"  return the logged in user
+//	GET  prefix+"tokens"   list API tokens of the logged in user
+//	POST prefix+"tokens"   create an API token with JSON {"name": ..., "scope": ...}
+//	POST prefix+"tokens/revoke"  revoke an API token with JSON {"id": ...}
+//	GET  prefix+"providers"      return the enabled login methods
+//	GET  prefix+"oidc/login"     redirect to the OpenID Connect provider
+//	GET  prefix+"oidc/callback"  log in with the OpenID Connect response
+func (a *Auth) Handler(prefix string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(prefix+"login", a.login)
+	mux.HandleFunc(prefix+"logout", a.logout)
+	mux.HandleFunc(prefix+"session", a.session)
+	mux.HandleFunc(prefix+"tokens", a.tokens)
+	mux.HandleFunc(prefix+"tokens/revoke", a.revokeToken)
+	mux.HandleFunc(prefix+"providers", a.providers)
+	mux.HandleFunc(prefix+"oidc/login", a.oidcLogin)
+	mux.HandleFunc(prefix+"oidc/callback", a.oidcCallback)
+	return mux
+}
+
+// sessionResp is the response of login and session endpoints.
+type sessionResp struct {
+	Username  string `json:"username"`
+	CSRFToken string `json:"csrf_token"`
+}
+
+func (a *Auth) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, twirp.NewError(twirp.BadRoute, "login requires POST"))
+		return
+	}
+	// Requiring JSON means a cross-site form cannot post here without a
+	// CORS preflight, which protects against login CSRF.
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/json" {
+		writeError(w, twirp.NewError(twirp.BadRoute, "login requires Content-Type application/json"))
+		return
+	}
+	var req struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		writeError(w, twirp.NewError(twirp.InvalidArgument, "invalid login request"))
+		return
+	}
+
+	user, err := a.store.GetUser(req.Username)
+	switch {
+	case err == database.ErrNotFound:
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+		writeError(w, twirp.NewError(twirp.Unauthenticated, "invalid username or password"))
+		return
+	case err != nil:
+		writeError(w, twirp.InternalErrorWith(err))
+		return
+	case !CheckPassword(user.PasswordHash, req.Password):
+		writeError(w, twirp.NewError(twirp.Unauthenticated, "invalid username or password"))
+		return
+	}
+
+	csrf, err := a.startSession(w, r, user)
+	if err != nil {
+		writeError(w, twirp.InternalErrorWith(err))
+		return
+	}
+	writeJSON(w, sessionResp{Username: user.Username, CSRFToken: csrf})
+}
+
+// startSession adds a session of user and sets the session and CSRF cookies.
+// It returns the CSRF token.
+func (a *Auth) startSession(w http.ResponseWriter, r *http.Request, user *database.User) (string, error) {
+	token, csrf := randomToken(), randomToken()
+	s := &database.Session{
+		ID:        sessionID(token),
+		UserID:    user.ID,
+		CSRFToken: csrf,
+		Expires:   time.Now().Add(SessionDuration).Truncate(time.Second),
+	}
+	if err := a.store.AddSession(s); err != nil {
+		return "", err
+	}
+	secure := isHTTPS(r)
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  s.Expires,
+		Secure:   secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookie,
+		Value:    csrf,
+		Path:     "/",
+		Expires:  s.Expires,
+		Secure:   secure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return csrf, nil
+}
+
+func (a *Auth) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, twirp.NewError(twirp.BadRoute, "logout requires POST"))
+		return
+	}
+	s, err := a.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := a.store.DeleteSession(s.ID); err != nil {
+		writeError(w, twirp.InternalErrorWith(err))
+		return
+	}
+	for _, name := range []string{SessionCookie, CSRFCookie} {
+		http.SetCookie(w, &http.Cookie{Name: name, Path: "/", MaxAge: -1})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Auth) session(w http.ResponseWriter, r *http.Request) {
+	s, err := a.authenticate(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, sessionResp{Username: s.Username, CSRFToken: s.CSRFToken})
+}
+
+// Authenticate returns r with the session or API token user in its context,
+// see UserFromContext. With a session cookie, POST requests must have the
+// CSRF token of the session in the CSRFHeader header. API tokens are given
+// in the Authorization header as bearer tokens. The error is a twirp.Error.
+func (a *Auth) Authenticate(r *http.Request) (*http.Request, error) {
+	if token, ok := bearerToken(r); ok {
+		u, twerr := a.authenticateToken(r, token)
+		if twerr != nil {
+			return nil, twerr
+		}
+		return r.WithContext(NewContext(r.Context(), u)), nil
+	}
+	s, twerr := a.authenticate(r)
+	if twerr != nil {
+		return nil, twerr
+	}
+	ctx := NewContext(r.Context(), &User{ID: s.UserID, Username: s.Username, Scope: ScopeAll})
+	return r.WithContext(ctx), nil
+}
+
+func (a *Auth) authenticate(r *http.Request) (*database.Session, twirp.Error) {
+	c, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return nil, twirp.NewError(twirp.Unauthenticated, "login required")
+	}
+	s, err := a.store.GetSession(sessionID(c.Value))
+	if err == database.ErrNotFound {
+		return nil, twirp.NewError(twirp.Unauthenticated, "session expired")
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		header := r.Header.Get(CSRFHeader)
+		if subtle.ConstantTimeCompare([]byte(header), []byte(s.CSRFToken)) != 1 {
+			return nil, twirp.NewError(twirp.PermissionDenied, "invalid CSRF token")
+		}
+	}
+	return s, nil
+}
+
+// User is an authenticated user.
+type User struct {
+	ID       string
+	Username string
+	Scope    Scope // Scope of the API token, or ScopeAll for sessions.
+}
+
+type userKey struct{}
+
+// NewContext returns a context with the authenticated user u.
+func NewContext(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// UserFromContext returns the user authenticated by Authenticate.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(userKey{}).(*User)
+	return u, ok
+}
+
+// randomToken returns a random URL safe token.
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// sessionID returns the stored session ID of a session token. Only hashes
+// are stored, so that a leaked database does not leak valid sessions.
+func sessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err as a Twirp JSON error response, so that clients
+// handle login errors like RPC errors.
+func writeError(w http.ResponseWriter, twerr twirp.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(twirp.ServerHTTPStatusFromErrorCode(twerr.Code()))
+	json.NewEncoder(w).Encode(struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}{string(twerr.Code()), twerr.Msg()})
+}
+
+// isHTTPS reports whether the client made r with HTTPS, directly or to a
+// trusted reverse proxy, see middleware.ProxyHeaders.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.URL.Scheme == "https"
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+func TestHashPassword(t *testing.T) {
+	if _, err := HashPassword("short"); err != ErrPasswordTooShort {
+		t.Errorf("HashPassword(short) error = %v, want %v", err, ErrPasswordTooShort)
+	}
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal("HashPassword() returned error:", err)
+	}
+	if !CheckPassword(hash, "correct horse") {
+		t.Error("CheckPassword() with correct password = false")
+	}
+	if CheckPassword(hash, "wrong horse") {
+		t.Error("CheckPassword() with wrong password = true")
+	}
+}
+
+func newAuth(t *testing.T) *Auth {
+	db := database.NewMemory()
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal("HashPassword() returned error:", err)
+	}
+	if err := db.AddUser(&database.User{Username: "alice", PasswordHash: hash}); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	return New(db)
+}
+
+func login(h http.Handler, username, password string) *httptest.ResponseRecorder {
+	body := `{"username": "` + username + `", "password": "` + password + `"}`
+	r := httptest.NewRequest("POST", "/auth/login", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestAuth(t *testing.T) {
+	a := newAuth(t)
+	h := a.Handler("/auth/")
+
+	for _, tc := range []struct{ username, password string }{
+		{"alice", "wrong horse"},
+		{"bob", "correct horse"},
+	} {
+		if w := login(h, tc.username, tc.password); w.Code != http.StatusUnauthorized {
+			t.Errorf("login as %v with %q status = %v, want %v", tc.username, tc.password, w.Code, http.StatusUnauthorized)
+		}
+	}
+
+	w := login(h, "alice", "correct horse")
+	if w.Code != http.StatusOK {
+		t.Fatalf("login status = %v, want %v: %v", w.Code, http.StatusOK, w.Body)
+	}
+	var resp sessionResp
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal("failed to decode login response:", err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 2 || cookies[0].Name != SessionCookie || !cookies[0].HttpOnly || cookies[1].Value != resp.CSRFToken {
+		t.Fatalf("login cookies = %v", cookies)
+	}
+
+	request := func(method, csrf string) *http.Request {
+		r := httptest.NewRequest(method, "/twirp/", nil)
+		r.AddCookie(cookies[0])
+		if csrf != "" {
+			r.Header.Set(CSRFHeader, csrf)
+		}
+		return r
+	}
+	tests := []struct {
+		name    string
+		r       *http.Request
+		wantErr bool
+	}{
+		{"no cookie", httptest.NewRequest("POST", "/twirp/", nil), true},
+		{"GET without CSRF token", request("GET", ""), false},
+		{"POST without CSRF token", request("POST", ""), true},
+		{"POST with wrong CSRF token", request("POST", "wrong"), true},
+		{"POST with CSRF token", request("POST", resp.CSRFToken), false},
+	}
+	for _, tt := range tests {
+		r, err := a.Authenticate(tt.r)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: Authenticate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if u, ok := UserFromContext(r.Context()); !ok || u.Username != "alice" {
+			t.Errorf("%v: UserFromContext() = %v, %v; want alice", tt.name, u, ok)
+		}
+	}
+
+	r := request("POST", resp.CSRFToken)
+	r.URL.Path = "/auth/logout"
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("logout status = %v, want %v", w.Code, http.StatusNoContent)
+	}
+	if _, err := a.Authenticate(request("GET", "")); err == nil {
+		t.Error("Authenticate() after logout returned no error")
+	}
+}
+
+func TestIsHTTPS(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	if isHTTPS(r) {
+		t.Error("isHTTPS() of HTTP request = true")
+	}
+	r.URL.Scheme = "https"
+	if !isHTTPS(r) {
+		t.Error("isHTTPS() of request forwarded by HTTPS proxy = false")
+	}
+	if r := httptest.NewRequest("GET", "https://example.com/", nil); !isHTTPS(r) {
+		t.Error("isHTTPS() of TLS request = false")
+	}
+}
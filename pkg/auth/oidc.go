@@ -0,0 +1,495 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// LoginPage is the login page of the web interface. OpenID Connect login
+// errors are shown there.
+const LoginPage = "/login.html"
+
+// oidcCookie holds the state, nonce and PKCE code verifier of an OpenID
+// Connect login between the redirect to the provider and the callback.
+const oidcCookie = "mymonies_oidc"
+
+// OIDCConfig configures login with an OpenID Connect provider.
+type OIDCConfig struct {
+	// Issuer is the issuer URL of the provider, e.g.
+	// "https://id.example.com". The provider configuration is discovered
+	// from Issuer + "/.well-known/openid-configuration".
+	Issuer string
+	// ClientID and ClientSecret are the client registered at the provider.
+	// ClientSecret is empty for public clients.
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the callback URL registered at the provider, e.g.
+	// "https://mymonies.example.com/auth/oidc/callback". If empty, it is
+	// derived from the login request.
+	RedirectURL string
+	// Claim is the ID token claim that must equal the mymonies username:
+	// "sub" or "email". Only verified email addresses are accepted.
+	Claim string
+	// Client makes the requests to the provider. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+// EnableOIDC enables login with an OpenID Connect provider in addition to
+// passwords. The provider is contacted on the first login, so that the
+// server starts even if the provider is down.
+func (a *Auth) EnableOIDC(cfg OIDCConfig) error {
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return errors.New("OpenID Connect requires an issuer and a client ID")
+	}
+	if cfg.Claim != "sub" && cfg.Claim != "email" {
+		return fmt.Errorf("invalid OpenID Connect claim %q: must be sub or email", cfg.Claim)
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	a.oidc = &oidcProvider{OIDCConfig: cfg}
+	return nil
+}
+
+func (a *Auth) providers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, struct {
+		Password bool `json:"password"`
+		OIDC     bool `json:"oidc"`
+	}{true, a.oidc != nil})
+}
+
+// oidcLogin redirects to the authorization endpoint of the provider. The
+// optional query parameter next is the page to return to after login.
+func (a *Auth) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	if a.oidc == nil {
+		http.NotFound(w, r)
+		return
+	}
+	d, err := a.oidc.discover(r.Context())
+	if err != nil {
+		oidcError(w, r, err)
+		return
+	}
+	next := r.FormValue("next")
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") {
+		next = "/"
+	}
+	state, nonce, verifier := randomToken(), randomToken(), randomToken()
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookie,
+		Value:    strings.Join([]string{state, nonce, verifier, base64.RawURLEncoding.EncodeToString([]byte(next))}, "."),
+		Path:     path.Dir(r.URL.Path) + "/",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		Secure:   isHTTPS(r),
+		HttpOnly: true,
+		// The callback is a top-level navigation from the provider.
+		SameSite: http.SameSiteLaxMode,
+	})
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.oidc.ClientID},
+		"redirect_uri":          {a.oidc.redirectURL(r)},
+		"scope":                 {"openid email"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, d.AuthorizationEndpoint+sep+q.Encode(), http.StatusFound)
+}
+
+// oidcCallback exchanges the authorization code for an ID token, and logs in
+// the user named by the configured claim.
+func (a *Auth) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	if a.oidc == nil {
+		http.NotFound(w, r)
+		return
+	}
+	c, err := r.Cookie(oidcCookie)
+	if err != nil {
+		oidcError(w, r, errors.New("login expired, try again"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcCookie, Path: path.Dir(r.URL.Path) + "/", MaxAge: -1})
+	parts := strings.Split(c.Value, ".")
+	if len(parts) != 4 {
+		oidcError(w, r, errors.New("login expired, try again"))
+		return
+	}
+	state, nonce, verifier := parts[0], parts[1], parts[2]
+	next, _ := base64.RawURLEncoding.DecodeString(parts[3])
+
+	if e := r.FormValue("error"); e != "" {
+		oidcError(w, r, fmt.Errorf("%s %s", e, r.FormValue("error_description")))
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.FormValue("state")), []byte(state)) != 1 {
+		oidcError(w, r, errors.New("invalid state"))
+		return
+	}
+	claims, err := a.oidc.exchange(r.Context(), r.FormValue("code"), verifier, a.oidc.redirectURL(r))
+	if err != nil {
+		oidcError(w, r, err)
+		return
+	}
+	if claims.Nonce != nonce {
+		oidcError(w, r, errors.New("invalid nonce in ID token"))
+		return
+	}
+	username, err := claims.username(a.oidc.Claim)
+	if err != nil {
+		oidcError(w, r, err)
+		return
+	}
+	user, err := a.store.GetUser(username)
+	if err == database.ErrNotFound {
+		oidcError(w, r, fmt.Errorf("no mymonies user %q", username))
+		return
+	} else if err != nil {
+		oidcError(w, r, err)
+		return
+	}
+	if _, err := a.startSession(w, r, user); err != nil {
+		oidcError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, string(next), http.StatusFound)
+}
+
+// oidcError redirects to the login page showing err, because the OpenID
+// Connect endpoints are browser navigations instead of API calls.
+func oidcError(w http.ResponseWriter, r *http.Request, err error) {
+	http.Redirect(w, r, LoginPage+"?error="+url.QueryEscape(err.Error()), http.StatusFound)
+}
+
+// oidcProvider is an OpenID Connect provider with its discovered
+// configuration and signing keys.
+type oidcProvider struct {
+	OIDCConfig
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey // by key ID
+}
+
+// oidcDiscovery is the provider configuration, see OpenID Connect Discovery
+// 1.0.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// redirectURL returns the configured redirect URL, or the callback URL next
+// to the login or callback endpoint of r.
+func (p *oidcProvider) redirectURL(r *http.Request) string {
+	if p.RedirectURL != "" {
+		return p.RedirectURL
+	}
+	scheme := "http"
+	if isHTTPS(r) {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + path.Dir(r.URL.Path) + "/callback"
+}
+
+// discover returns the provider configuration. It is fetched once.
+func (p *oidcProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	var d oidcDiscovery
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.Issuer, "/")+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, fmt.Errorf("OpenID Connect discovery failed: %v", err)
+	}
+	if d.Issuer != p.Issuer {
+		return nil, fmt.Errorf("OpenID Connect discovery returned issuer %q, want %q", d.Issuer, p.Issuer)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %v: %v", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// exchange exchanges the authorization code for an ID token at the token
+// endpoint, and returns the claims of the verified ID token.
+func (p *oidcProvider) exchange(ctx context.Context, code, verifier, redirectURL string) (*idTokenClaims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"client_id":     {p.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var tokenResp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("invalid token response: %v", err)
+	}
+	if tokenResp.Error != "" {
+		return nil, fmt.Errorf("token request failed: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	return p.verify(ctx, tokenResp.IDToken)
+}
+
+// idTokenClaims are the claims of an ID token used by mymonies.
+type idTokenClaims struct {
+	Issuer          string   `json:"iss"`
+	Subject         string   `json:"sub"`
+	Audience        audience `json:"aud"`
+	AuthorizedParty string   `json:"azp"`
+	Expires         int64    `json:"exp"`
+	Nonce           string   `json:"nonce"`
+	Email           string   `json:"email"`
+	EmailVerified   *bool    `json:"email_verified"`
+}
+
+// username returns the value of the claim mapped to usernames.
+func (c *idTokenClaims) username(claim string) (string, error) {
+	if claim == "sub" {
+		return c.Subject, nil
+	}
+	if c.Email == "" {
+		return "", errors.New("ID token has no email claim")
+	}
+	// A missing email_verified claim does not verify the address.
+	if c.EmailVerified == nil || !*c.EmailVerified {
+		return "", fmt.Errorf("email address %v is not verified", c.Email)
+	}
+	return c.Email, nil
+}
+
+// audience is the aud claim, which is a string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(a))
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// verify verifies the signature of a JWT ID token with the keys of the
+// provider, and returns its claims if it was issued to this client and has
+// not expired.
+func (p *oidcProvider) verify(ctx context.Context, token string) (*idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid ID token")
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid ID token signature")
+	}
+	key, err := p.key(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !verifySignature(header.Algorithm, key, hash[:], sig) {
+		return nil, errors.New("invalid ID token signature")
+	}
+
+	var claims idTokenClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	switch {
+	case claims.Issuer != p.Issuer:
+		return nil, fmt.Errorf("ID token issuer %q, want %q", claims.Issuer, p.Issuer)
+	case !claims.Audience.contains(p.ClientID):
+		return nil, errors.New("ID token was not issued to this client")
+	case claims.AuthorizedParty != "" && claims.AuthorizedParty != p.ClientID:
+		return nil, errors.New("ID token was authorized for another client")
+	case time.Now().Add(-time.Minute).Unix() > claims.Expires:
+		return nil, errors.New("ID token expired")
+	}
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errors.New("invalid ID token encoding")
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("invalid ID token: %v", err)
+	}
+	return nil
+}
+
+// verifySignature verifies a RS256 or ES256 signature of hash, the SHA-256
+// hash of the signed part of a JWT.
+func verifySignature(alg string, key crypto.PublicKey, hash, sig []byte) bool {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return alg == "RS256" && rsa.VerifyPKCS1v15(key, crypto.SHA256, hash, sig) == nil
+	case *ecdsa.PublicKey:
+		if alg != "ES256" || len(sig) != 64 {
+			return false
+		}
+		r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(key, hash, r, s)
+	}
+	return false
+}
+
+// key returns the signing key of the provider with the key ID. The keys are
+// fetched again for unknown key IDs, because providers rotate their keys.
+func (p *oidcProvider) key(ctx context.Context, id string) (crypto.PublicKey, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[id]; ok {
+		return key, nil
+	}
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, d.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to get OpenID Connect keys: %v", err)
+	}
+	p.keys = make(map[string]crypto.PublicKey)
+	for _, k := range jwks.Keys {
+		if key, err := k.publicKey(); err == nil {
+			p.keys[k.KeyID] = key
+		}
+	}
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown ID token key %q", id)
+	}
+	return key, nil
+}
+
+// jwk is a JSON Web Key, see RFC 7517.
+type jwk struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// publicKey returns the RSA or P-256 public key of k.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	if k.Use != "" && k.Use != "sig" {
+		return nil, errors.New("not a signing key")
+	}
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid P-256 key")
+		}
+		// ecdh checks that the point is on the curve.
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, errors.New("invalid P-256 key")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/auth/oidctest"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	idp := httptest.NewUnstartedServer(nil)
+	provider, err := oidctest.New("http://"+idp.Listener.Addr().String(), "mymonies", "alice@example.com")
+	if err != nil {
+		t.Fatal("oidctest.New() returned error:", err)
+	}
+	provider.Subject = "alice"
+	idp.Config.Handler = provider
+	idp.Start()
+	defer idp.Close()
+
+	tests := []struct {
+		claim        string
+		wantLocation string
+	}{
+		{"sub", "/accounts"},
+		{"email", LoginPage + "?error="},
+	}
+	for _, tt := range tests {
+		a := newAuth(t)
+		if err := a.EnableOIDC(OIDCConfig{Issuer: provider.Issuer, ClientID: "mymonies", Claim: tt.claim}); err != nil {
+			t.Fatal("EnableOIDC() returned error:", err)
+		}
+		srv := httptest.NewServer(a.Handler("/auth/"))
+
+		jar, _ := cookiejar.New(nil)
+		client := &http.Client{
+			Jar: jar,
+			// Follow redirects until leaving the login endpoints.
+			CheckRedirect: func(r *http.Request, via []*http.Request) error {
+				if r.URL.Host == srv.Listener.Addr().String() && !strings.HasPrefix(r.URL.Path, "/auth/") {
+					return http.ErrUseLastResponse
+				}
+				return nil
+			},
+		}
+		resp, err := client.Get(srv.URL + "/auth/oidc/login?next=/accounts")
+		if err != nil {
+			t.Fatalf("%v: login returned error: %v", tt.claim, err)
+		}
+		resp.Body.Close()
+		if loc := resp.Header.Get("Location"); !strings.HasPrefix(loc, tt.wantLocation) {
+			t.Errorf("%v: login redirected to %q, want %q", tt.claim, loc, tt.wantLocation)
+		}
+
+		r := httptest.NewRequest("GET", "/twirp/", nil)
+		for _, c := range jar.Cookies(resp.Request.URL) {
+			r.AddCookie(c)
+		}
+		_, err = a.Authenticate(r)
+		if loggedIn, want := err == nil, tt.wantLocation == "/accounts"; loggedIn != want {
+			t.Errorf("%v: Authenticate() after login error = %v, want logged in %v", tt.claim, err, want)
+		}
+		srv.Close()
+	}
+}
+
+func TestIDTokenClaims_username(t *testing.T) {
+	verified, unverified := true, false
+	tests := []struct {
+		name    string
+		claims  idTokenClaims
+		claim   string
+		want    string
+		wantErr bool
+	}{
+		{"sub", idTokenClaims{Subject: "alice"}, "sub", "alice", false},
+		{"verified email", idTokenClaims{Email: "alice@example.com", EmailVerified: &verified}, "email", "alice@example.com", false},
+		{"unverified email", idTokenClaims{Email: "alice@example.com", EmailVerified: &unverified}, "email", "", true},
+		{"missing email_verified", idTokenClaims{Email: "alice@example.com"}, "email", "", true},
+		{"missing email", idTokenClaims{Subject: "alice", EmailVerified: &verified}, "email", "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.claims.username(tt.claim)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("%v: username() = %q, %v; want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestJWK_publicKey_ec(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coord := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	x, y := key.X.FillBytes(make([]byte, 32)), key.Y.FillBytes(make([]byte, 32))
+	k := jwk{KeyType: "EC", Curve: "P-256", X: coord(x), Y: coord(y)}
+	got, err := k.publicKey()
+	if err != nil {
+		t.Fatal("publicKey() returned error:", err)
+	}
+	if !key.PublicKey.Equal(got) {
+		t.Errorf("publicKey() = %v, want %v", got, key.PublicKey)
+	}
+
+	// A point not on the curve.
+	y[31] ^= 1
+	k.Y = coord(y)
+	if _, err := k.publicKey(); err == nil {
+		t.Error("publicKey() of point not on the curve returned no error")
+	}
+}
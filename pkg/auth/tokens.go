@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// TokenPrefix starts every API token, so that leaked tokens are easy to
+// recognize.
+const TokenPrefix = "mym_"
+
+// Scope limits the RPC methods an API token can call.
+type Scope string
+
+// API token scopes.
+const (
+	// ScopeAll allows all methods.
+	ScopeAll Scope = "all"
+	// ScopeRead allows listing and exporting data.
+	ScopeRead Scope = "read"
+	// ScopeImport allows importing transactions, exchange rates and tags.
+	ScopeImport Scope = "import"
+)
+
+// Valid reports whether s is a known scope.
+func (s Scope) Valid() bool {
+	return s == ScopeAll || s == ScopeRead || s == ScopeImport
+}
+
+// Allows reports whether the scope allows calling an RPC method, e.g.
+// "AddImport", or an HTTP endpoint, e.g. "export" or "metrics".
+func (s Scope) Allows(method string) bool {
+	switch s {
+	case ScopeAll:
+		return true
+	case ScopeRead:
+		return strings.HasPrefix(method, "List") || method == "export" || method == "metrics"
+	case ScopeImport:
+		// Imported tags are matched to existing tags by name.
+		switch method {
+		case "AddImport", "AddExchangeRates", "AddTag", "ListTags":
+			return true
+		}
+	}
+	return false
+}
+
+// TokenStore stores API tokens.
+type TokenStore interface {
+	AddToken(t *database.Token) error
+	ListTokens(userID string) ([]*database.Token, error)
+	GetToken(hash string) (*database.Token, error)
+	DeleteToken(userID, id string) error
+}
+
+// CreateToken stores a new API token of a user. The token is returned only
+// once; only its hash is stored.
+func CreateToken(store TokenStore, userID, name string, scope Scope) (string, *database.Token, error) {
+	token := TokenPrefix + randomToken()
+	t := &database.Token{UserID: userID, Name: name, Hash: sessionID(token), Scope: string(scope)}
+	if err := store.AddToken(t); err != nil {
+		return "", nil, err
+	}
+	return token, t, nil
+}
+
+// bearerToken returns the token of an Authorization: Bearer header.
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(h, prefix), true
+}
+
+// authenticateToken returns the user of an API token, if the token scope
+// allows the RPC method named by the last element of the request path.
+// Requests with tokens don't need CSRF tokens, because browsers never send
+// them automatically.
+func (a *Auth) authenticateToken(r *http.Request, token string) (*User, twirp.Error) {
+	t, err := a.store.GetToken(sessionID(token))
+	if err == database.ErrNotFound {
+		return nil, twirp.NewError(twirp.Unauthenticated, "invalid API token")
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	scope := Scope(t.Scope)
+	if method := path.Base(r.URL.Path); !scope.Allows(method) {
+		return nil, twirp.NewError(twirp.PermissionDenied, "API token scope "+t.Scope+" does not allow "+method)
+	}
+	return &User{ID: t.UserID, Username: t.Username, Scope: scope}, nil
+}
+
+// tokenResp is an API token in responses of the token endpoints. The token
+// itself is only included when it is created.
+type tokenResp struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Scope   string    `json:"scope"`
+	Created time.Time `json:"created"`
+	Token   string    `json:"token,omitempty"`
+}
+
+func (a *Auth) tokens(w http.ResponseWriter, r *http.Request) {
+	s, twerr := a.authenticate(r)
+	if twerr != nil {
+		writeError(w, twerr)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		tokens, err := a.store.ListTokens(s.UserID)
+		if err != nil {
+			writeError(w, twirp.InternalErrorWith(err))
+			return
+		}
+		resp := []tokenResp{}
+		for _, t := range tokens {
+			resp = append(resp, tokenResp{ID: t.ID, Name: t.Name, Scope: t.Scope, Created: t.Created})
+		}
+		writeJSON(w, resp)
+	case http.MethodPost:
+		var req struct {
+			Name  string `json:"name"`
+			Scope Scope  `json:"scope"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, twirp.NewError(twirp.InvalidArgument, "invalid token request"))
+			return
+		}
+		if req.Name == "" {
+			writeError(w, twirp.RequiredArgumentError("name"))
+			return
+		}
+		if !req.Scope.Valid() {
+			writeError(w, twirp.InvalidArgumentError("scope", "must be all, read or import"))
+			return
+		}
+		token, t, err := CreateToken(a.store, s.UserID, req.Name, req.Scope)
+		if err != nil {
+			writeError(w, twirp.InternalErrorWith(err))
+			return
+		}
+		writeJSON(w, tokenResp{ID: t.ID, Name: t.Name, Scope: t.Scope, Created: t.Created, Token: token})
+	default:
+		writeError(w, twirp.NewError(twirp.BadRoute, "tokens requires GET or POST"))
+	}
+}
+
+func (a *Auth) revokeToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, twirp.NewError(twirp.BadRoute, "revoke requires POST"))
+		return
+	}
+	s, twerr := a.authenticate(r)
+	if twerr != nil {
+		writeError(w, twerr)
+		return
+	}
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+		writeError(w, twirp.NewError(twirp.InvalidArgument, "invalid revoke request"))
+		return
+	}
+	err := a.store.DeleteToken(s.UserID, req.ID)
+	if err == database.ErrNotFound {
+		writeError(w, twirp.InvalidArgumentError("id", "not found in database"))
+		return
+	} else if err != nil {
+		writeError(w, twirp.InternalErrorWith(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/twitchtv/twirp"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	a := newAuth(t)
+	u, err := a.store.GetUser("alice")
+	if err != nil {
+		t.Fatal("GetUser() returned error:", err)
+	}
+	token, _, err := CreateToken(a.store, u.ID, "cron", ScopeImport)
+	if err != nil {
+		t.Fatal("CreateToken() returned error:", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		method   string
+		wantCode twirp.ErrorCode
+	}{
+		{"import", token, "AddImport", twirp.NoError},
+		{"out of scope", token, "UpdateTag", twirp.PermissionDenied},
+		{"invalid token", TokenPrefix + "invalid", "AddImport", twirp.Unauthenticated},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/twirp/mymonies.Mymonies/"+tt.method, nil)
+		r.Header.Set("Authorization", "Bearer "+tt.token)
+		r, err := a.Authenticate(r)
+		if tt.wantCode != twirp.NoError {
+			if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != tt.wantCode {
+				t.Errorf("%v: Authenticate() error = %v, want %v", tt.name, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: Authenticate() returned error: %v", tt.name, err)
+			continue
+		}
+		if u, ok := UserFromContext(r.Context()); !ok || u.Username != "alice" || u.Scope != ScopeImport {
+			t.Errorf("%v: UserFromContext() = %v, %v; want alice with import scope", tt.name, u, ok)
+		}
+	}
+}
+
+func TestScope_Allows(t *testing.T) {
+	tests := []struct {
+		scope  Scope
+		method string
+		want   bool
+	}{
+		{ScopeRead, "ListTransactions", true},
+		{ScopeRead, "export", true},
+		{ScopeRead, "metrics", true},
+		{ScopeRead, "UpdateTag", false},
+		{ScopeImport, "metrics", false},
+		{ScopeAll, "metrics", true},
+	}
+	for _, tt := range tests {
+		if got := tt.scope.Allows(tt.method); got != tt.want {
+			t.Errorf("Scope(%v).Allows(%v) = %v, want %v", tt.scope, tt.method, got, tt.want)
+		}
+	}
+}
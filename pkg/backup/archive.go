@@ -0,0 +1,214 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"time"
+)
+
+// Format identifies mymonies backup manifests.
+const Format = "mymonies-backup"
+
+// ManifestName is the file name of the manifest in a backup archive.
+const ManifestName = "manifest.json"
+
+// Manifest describes a backup archive.
+type Manifest struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Tables  []Table   `json:"tables"`
+}
+
+// Table describes the file of a table in a backup archive.
+type Table struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Rows   int    `json:"rows"`
+	SHA256 string `json:"sha256"` // Hex encoded checksum of the file.
+}
+
+// table is the rows of a table, a pointer to a slice of row pointers.
+type table struct {
+	name string
+	rows interface{}
+}
+
+// tables returns the tables of d in the order they are written and
+// restored.
+func tables(d *Data) []table {
+	return []table{
+		{"households", &d.Households},
+		{"users", &d.Users},
+		{"household_members", &d.Members},
+		{"api_tokens", &d.Tokens},
+		{"tags", &d.Tags},
+		{"imports", &d.Imports},
+		{"records", &d.Records},
+		{"patterns", &d.Patterns},
+		{"tag_operations", &d.TagOperations},
+		{"tag_changes", &d.TagChanges},
+		{"exchange_rates", &d.ExchangeRates},
+	}
+}
+
+// Write writes d as a backup archive to w.
+func Write(w io.Writer, d *Data) error {
+	m := Manifest{Format: Format, Version: Version, Created: time.Now().UTC().Truncate(time.Second)}
+	var files [][]byte
+	for _, t := range tables(d) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		rows := reflect.ValueOf(t.rows).Elem()
+		for i := 0; i < rows.Len(); i++ {
+			if err := enc.Encode(rows.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		m.Tables = append(m.Tables, Table{
+			Name:   t.name,
+			File:   t.name + ".jsonl",
+			Rows:   rows.Len(),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+		files = append(files, buf.Bytes())
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeFile(tw, ManifestName, append(manifest, '\n'), m.Created); err != nil {
+		return err
+	}
+	for i, t := range m.Tables {
+		if err := writeFile(tw, t.File, files[i], m.Created); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Read reads a backup archive written by Write. It returns an error if the
+// archive is not a backup of a supported version, if a table file is
+// missing or does not match its checksum, or if the data fails Data.Check.
+func Read(r io.Reader) (*Manifest, *Data, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a backup archive: %v", err)
+	}
+	if hdr.Name != ManifestName {
+		return nil, nil, fmt.Errorf("not a backup archive: first file is %q, not %v", hdr.Name, ManifestName)
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, nil, fmt.Errorf("%v: %v", ManifestName, err)
+	}
+	if m.Format != Format {
+		return nil, nil, fmt.Errorf("not a backup archive: format is %q", m.Format)
+	}
+	if m.Version < 1 || m.Version > Version {
+		return nil, nil, fmt.Errorf("backup version %v is not supported, only versions up to %v are", m.Version, Version)
+	}
+
+	d := &Data{}
+	byFile := make(map[string]Table)
+	rowsByName := make(map[string]interface{})
+	for _, t := range tables(d) {
+		rowsByName[t.name] = t.rows
+	}
+	for _, t := range m.Tables {
+		if _, ok := rowsByName[t.Name]; !ok {
+			return nil, nil, fmt.Errorf("%v: unknown table %q", ManifestName, t.Name)
+		}
+		byFile[t.File] = t
+	}
+	read := make(map[string]bool)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		t, ok := byFile[hdr.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%v: file is not in %v", hdr.Name, ManifestName)
+		}
+		if err := readTable(tr, t, rowsByName[t.Name]); err != nil {
+			return nil, nil, fmt.Errorf("%v: %v", hdr.Name, err)
+		}
+		read[t.Name] = true
+	}
+	for _, t := range m.Tables {
+		if !read[t.Name] {
+			return nil, nil, fmt.Errorf("%v: file is missing", t.File)
+		}
+	}
+	if err := d.Check(); err != nil {
+		return nil, nil, fmt.Errorf("inconsistent backup: %v", err)
+	}
+	return &m, d, nil
+}
+
+// readTable decodes the JSON Lines of a table file into rows, a pointer to
+// a slice of row pointers.
+func readTable(r io.Reader, t Table, rows interface{}) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != t.SHA256 {
+		return fmt.Errorf("checksum does not match %v", ManifestName)
+	}
+	slice := reflect.ValueOf(rows).Elem()
+	rowType := slice.Type().Elem().Elem()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		row := reflect.New(rowType)
+		if err := dec.Decode(row.Interface()); err != nil {
+			return fmt.Errorf("row %d: %v", slice.Len()+1, err)
+		}
+		slice.Set(reflect.Append(slice, row))
+	}
+	if slice.Len() != t.Rows {
+		return fmt.Errorf("%d rows, %v has %d", slice.Len(), ManifestName, t.Rows)
+	}
+	return nil
+}
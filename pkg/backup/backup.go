@@ -0,0 +1,241 @@
+// Package backup reads and writes backups of all mymonies data in an archive
+// independent of the storage backend.
+//
+// A backup is a gzip compressed tar archive of a manifest.json and a JSON
+// Lines file of each table, e.g. records.jsonl. The manifest is the first
+// file and describes the format version, and the number of rows and the
+// SHA-256 checksum of each table file.
+package backup
+
+import (
+	"fmt"
+	"time"
+)
+
+// Version is the version of the backup format. Backups of newer versions
+// can't be read.
+const Version = 1
+
+// Data is the content of all tables of a mymonies database. IDs refer to
+// rows of the same backup; a restore assigns new IDs. Login sessions are
+// not backed up.
+type Data struct {
+	Households    []*Household
+	Users         []*User
+	Members       []*Member
+	Tokens        []*Token
+	Tags          []*Tag
+	Imports       []*Import
+	Records       []*Record
+	Patterns      []*Pattern
+	TagOperations []*TagOperation
+	TagChanges    []*TagChange
+	ExchangeRates []*ExchangeRate
+}
+
+// Household is a household, see database.Household.
+type Household struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created_at"`
+}
+
+// User is a user, see database.User.
+type User struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"password_hash"`
+	Created      time.Time `json:"created_at"`
+}
+
+// Member is the role of a user in a household.
+type Member struct {
+	HouseholdID string `json:"household_id"`
+	UserID      string `json:"user_id"`
+	Role        string `json:"role"`
+}
+
+// Token is an API token, see database.Token.
+type Token struct {
+	ID      string    `json:"id"`
+	UserID  string    `json:"user_id"`
+	Name    string    `json:"name"`
+	Hash    string    `json:"hash"`
+	Scope   string    `json:"scope"`
+	Created time.Time `json:"created_at"`
+}
+
+// Tag is a tag of a household.
+type Tag struct {
+	ID          string `json:"id"`
+	HouseholdID string `json:"household_id"`
+	Name        string `json:"name"`
+}
+
+// Import is an imported file of a household.
+type Import struct {
+	ID          string `json:"id"`
+	HouseholdID string `json:"household_id"`
+	FileName    string `json:"filename"`
+	Account     string `json:"account"`
+	Currency    string `json:"currency"`
+}
+
+// Record is an imported transaction record. Dates are YYYY-MM-DD, or empty.
+type Record struct {
+	ID              string `json:"id"`
+	ImportID        string `json:"import_id"`
+	TransactionDate string `json:"transaction_date,omitempty"`
+	ValueDate       string `json:"value_date,omitempty"`
+	PaymentDate     string `json:"payment_date,omitempty"`
+	Amount          string `json:"amount"`
+	Currency        string `json:"currency"`
+	PayeePayer      string `json:"payee_payer,omitempty"`
+	Account         string `json:"account,omitempty"`
+	Bic             string `json:"bic,omitempty"`
+	Transaction     string `json:"transaction,omitempty"`
+	Reference       string `json:"reference,omitempty"`
+	PayerReference  string `json:"payer_reference,omitempty"`
+	Message         string `json:"message,omitempty"`
+	CardNumber      string `json:"card_number,omitempty"`
+	TagID           string `json:"tag_id,omitempty"`
+	Version         int    `json:"version"`
+}
+
+// Pattern is a tagging pattern of a household.
+type Pattern struct {
+	ID          string `json:"id"`
+	HouseholdID string `json:"household_id"`
+	TagID       string `json:"tag_id,omitempty"`
+	Account     string `json:"account"`
+	Query       string `json:"query"`
+}
+
+// TagOperation is an operation of the tag audit log, see database.TagChange.
+type TagOperation struct {
+	ID          string    `json:"id"`
+	HouseholdID string    `json:"household_id"`
+	UserID      string    `json:"user_id,omitempty"`
+	Cause       string    `json:"cause"`
+	PatternID   string    `json:"pattern_id,omitempty"`
+	ImportID    string    `json:"import_id,omitempty"`
+	UndoOf      string    `json:"undo_of,omitempty"`
+	Created     time.Time `json:"created_at"`
+}
+
+// TagChange is a change of the tag of a record in an operation.
+type TagChange struct {
+	ID          string `json:"id"`
+	OperationID string `json:"operation_id"`
+	RecordID    string `json:"record_id"`
+	OldTagID    string `json:"old_tag_id,omitempty"`
+	NewTagID    string `json:"new_tag_id,omitempty"`
+}
+
+// ExchangeRate is a euro foreign exchange rate. The date is YYYY-MM-DD.
+type ExchangeRate struct {
+	Date     string `json:"date"`
+	Currency string `json:"currency"`
+	Rate     string `json:"rate"`
+}
+
+// Check returns an error if a row refers to a row missing from the backup,
+// or if an ID is not unique. Rows only refer to rows of the tables before
+// them in Data, and operations to earlier operations, so they can be
+// restored in order.
+func (d *Data) Check() error {
+	households, users := make(IDs), make(IDs)
+	tags, imports, records := make(IDs), make(IDs), make(IDs)
+	patterns, operations := make(IDs), make(IDs)
+	for _, h := range d.Households {
+		if err := households.add("household", h.ID); err != nil {
+			return err
+		}
+	}
+	for _, u := range d.Users {
+		if err := users.add("user", u.ID); err != nil {
+			return err
+		}
+	}
+	for _, m := range d.Members {
+		if err := check(households.ref("household", m.HouseholdID), users.ref("user", m.UserID)); err != nil {
+			return fmt.Errorf("member: %v", err)
+		}
+	}
+	for _, t := range d.Tokens {
+		if err := check(users.ref("user", t.UserID)); err != nil {
+			return fmt.Errorf("token %v: %v", t.ID, err)
+		}
+	}
+	for _, t := range d.Tags {
+		if err := check(households.ref("household", t.HouseholdID), tags.add("tag", t.ID)); err != nil {
+			return fmt.Errorf("tag %v: %v", t.ID, err)
+		}
+	}
+	for _, i := range d.Imports {
+		if err := check(households.ref("household", i.HouseholdID), imports.add("import", i.ID)); err != nil {
+			return fmt.Errorf("import %v: %v", i.ID, err)
+		}
+	}
+	for _, r := range d.Records {
+		if err := check(imports.ref("import", r.ImportID), tags.ref("tag", r.TagID), records.add("record", r.ID)); err != nil {
+			return fmt.Errorf("record %v: %v", r.ID, err)
+		}
+	}
+	for _, p := range d.Patterns {
+		if err := check(households.ref("household", p.HouseholdID), tags.ref("tag", p.TagID), patterns.add("pattern", p.ID)); err != nil {
+			return fmt.Errorf("pattern %v: %v", p.ID, err)
+		}
+	}
+	for _, o := range d.TagOperations {
+		err := check(households.ref("household", o.HouseholdID), users.ref("user", o.UserID),
+			patterns.ref("pattern", o.PatternID), imports.ref("import", o.ImportID),
+			operations.ref("operation", o.UndoOf), operations.add("operation", o.ID))
+		if err != nil {
+			return fmt.Errorf("tag operation %v: %v", o.ID, err)
+		}
+	}
+	for _, c := range d.TagChanges {
+		err := check(operations.ref("operation", c.OperationID), records.ref("record", c.RecordID),
+			tags.ref("tag", c.OldTagID), tags.ref("tag", c.NewTagID))
+		if err != nil {
+			return fmt.Errorf("tag change %v: %v", c.ID, err)
+		}
+	}
+	return nil
+}
+
+// check returns the first non-nil error.
+func check(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IDs maps the IDs of a backup to the IDs assigned by a restore. Empty IDs
+// map to empty IDs, i.e. missing references stay missing.
+type IDs map[string]string
+
+// Map returns the new ID of id. References of checked data are always
+// mapped when rows are restored in order, see Data.Check.
+func (m IDs) Map(id string) string {
+	return m[id]
+}
+
+func (m IDs) add(kind, id string) error {
+	if _, ok := m[id]; ok || id == "" {
+		return fmt.Errorf("%v id %q is not unique", kind, id)
+	}
+	m[id] = id
+	return nil
+}
+
+func (m IDs) ref(kind, id string) error {
+	if _, ok := m[id]; !ok && id != "" {
+		return fmt.Errorf("%v %q does not exist", kind, id)
+	}
+	return nil
+}
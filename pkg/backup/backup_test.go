@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testData() *Data {
+	created := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &Data{
+		Households: []*Household{{ID: "1", Name: "Default", Created: created}},
+		Users:      []*User{{ID: "3", Username: "alice", PasswordHash: "hash", Created: created}},
+		Members:    []*Member{{HouseholdID: "1", UserID: "3", Role: "owner"}},
+		Tags:       []*Tag{{ID: "5", HouseholdID: "1", Name: "groceries"}},
+		Imports:    []*Import{{ID: "1", HouseholdID: "1", FileName: "example.txt", Account: "FI1234", Currency: "EUR"}},
+		Records: []*Record{
+			{ID: "7", ImportID: "1", TransactionDate: "2018-03-02", Amount: "-12.50", Currency: "EUR", PayeePayer: "<Shop>", TagID: "5", Version: 2},
+		},
+		TagOperations: []*TagOperation{
+			{ID: "1", HouseholdID: "1", UserID: "3", Cause: "manual", Created: created},
+			{ID: "2", HouseholdID: "1", Cause: "undo", UndoOf: "1", Created: created},
+		},
+		TagChanges:    []*TagChange{{ID: "1", OperationID: "1", RecordID: "7", NewTagID: "5"}},
+		ExchangeRates: []*ExchangeRate{{Date: "2018-03-01", Currency: "USD", Rate: "1.2276"}},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, testData()); err != nil {
+		t.Fatal("Write() returned error:", err)
+	}
+	m, got, err := Read(&buf)
+	if err != nil {
+		t.Fatal("Read() returned error:", err)
+	}
+	if m.Format != Format || m.Version != Version || len(m.Tables) != 11 {
+		t.Errorf("Read() manifest = %+v", m)
+	}
+	if want := testData(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+}
+
+// rewrite returns the backup archive of testData with files changed by
+// change.
+func rewrite(t *testing.T, change func(name string, data []byte) []byte) *bytes.Buffer {
+	var orig bytes.Buffer
+	if err := Write(&orig, testData()); err != nil {
+		t.Fatal("Write() returned error:", err)
+	}
+	gr, err := gzip.NewReader(&orig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		data, _ := ioutil.ReadAll(tr)
+		if data = change(hdr.Name, data); data == nil {
+			continue
+		}
+		hdr.Size = int64(len(data))
+		tw.WriteHeader(hdr)
+		tw.Write(data)
+	}
+	tw.Close()
+	gw.Close()
+	return &buf
+}
+
+func TestRead_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(name string, data []byte) []byte
+		wantErr string
+	}{
+		{
+			name: "changed table",
+			change: func(name string, data []byte) []byte {
+				if name == "records.jsonl" {
+					return bytes.Replace(data, []byte("-12.50"), []byte("-99.50"), 1)
+				}
+				return data
+			},
+			wantErr: "records.jsonl: checksum does not match",
+		},
+		{
+			name: "missing table",
+			change: func(name string, data []byte) []byte {
+				if name == "tags.jsonl" {
+					return nil
+				}
+				return data
+			},
+			wantErr: "tags.jsonl: file is missing",
+		},
+		{
+			name: "newer version",
+			change: func(name string, data []byte) []byte {
+				if name == ManifestName {
+					return bytes.Replace(data, []byte(`"version": 1`), []byte(`"version": 2`), 1)
+				}
+				return data
+			},
+			wantErr: "backup version 2 is not supported",
+		},
+	}
+	for _, tt := range tests {
+		_, _, err := Read(rewrite(t, tt.change))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: Read() error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+	if _, _, err := Read(strings.NewReader("not gzip")); err == nil {
+		t.Error("Read() of other file returned no error")
+	}
+}
+
+func TestData_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(d *Data)
+		wantErr string
+	}{
+		{"valid", func(d *Data) {}, ""},
+		{"missing tag", func(d *Data) { d.Records[0].TagID = "6" }, `record 7: tag "6" does not exist`},
+		{"duplicate id", func(d *Data) { d.Tags = append(d.Tags, &Tag{ID: "5", HouseholdID: "1"}) }, `tag 5: tag id "5" is not unique`},
+		{"undo of later operation", func(d *Data) { d.TagOperations[0].UndoOf = "2" }, `tag operation 1: operation "2" does not exist`},
+	}
+	for _, tt := range tests {
+		d := testData()
+		tt.change(d)
+		var got string
+		if err := d.Check(); err != nil {
+			got = err.Error()
+		}
+		if got != tt.wantErr {
+			t.Errorf("%v: Check() error = %q, want %q", tt.name, got, tt.wantErr)
+		}
+	}
+}
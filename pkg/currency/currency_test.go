@@ -0,0 +1,46 @@
+package currency
+
+import "testing"
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"EUR", true},
+		{"SEK", true},
+		{"JPY", true},
+		{"eur", false},
+		{"", false},
+		{"EURO", false},
+		{"XXX", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := Valid(tt.code); got != tt.want {
+				t.Errorf("Valid(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		code   string
+		want   int
+		wantOk bool
+	}{
+		{"EUR", 2, true},
+		{"JPY", 0, true},
+		{"KWD", 3, true},
+		{"FOO", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, ok := MinorUnits(tt.code)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("MinorUnits(%q) = %v, %v, want %v, %v", tt.code, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -11,6 +11,19 @@ type File interface {
 	// identifying the account the records are from.
 	Account() string
 
+	// Currency is the ISO 4217 currency code of the account.
+	Currency() string
+
 	// Transactions returns the transaction records from the file.
 	Transactions() []*mymonies.Transaction
 }
+
+// TaggedFile is a File from a source that categorizes transactions, e.g. a
+// plain-text accounting journal.
+type TaggedFile interface {
+	File
+
+	// Tags returns the tag names of the transactions, in the same order as
+	// Transactions. An empty name means the transaction has no tag.
+	Tags() []string
+}
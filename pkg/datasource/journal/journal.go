@@ -0,0 +1,501 @@
+// Package journal implements a data source of plain-text accounting
+// journals of Beancount and hledger.
+//
+// Asset and liability accounts are the accounts transactions are imported
+// to, e.g. Assets:FI1234 is imported as account FI1234. The expense or
+// income account of a transaction is its tag, e.g. Expenses:Food:Groceries
+// is tagged Food:Groceries. Entries mymonies can't represent, such as
+// entries split to several expense accounts or postings of stocks and other
+// commodities than ISO 4217 currencies, are reported as skipped.
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/joneskoo/mymonies/pkg/currency"
+	"github.com/joneskoo/mymonies/pkg/money"
+	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// Extensions are the file name extensions of journals. Other extensions
+// than .beancount and .bean are read as hledger journals.
+var Extensions = []string{".beancount", ".bean", ".journal", ".hledger", ".ledger"}
+
+// Journal is the content of a journal file.
+type Journal struct {
+	// Files are the transactions of each asset and liability account, in
+	// the order of their first transaction in the journal.
+	Files []*File
+	// Skipped describes the entries that could not be imported, e.g.
+	// "example.journal:12: posting to Equity:Opening cannot be imported".
+	Skipped []string
+}
+
+// File is the transactions of an account in a journal. It implements
+// datasource.TaggedFile.
+type File struct {
+	filename     string
+	account      string
+	currency     string
+	transactions []*mymonies.Transaction
+	tags         []string
+}
+
+func (f *File) Account() string                       { return f.account }
+func (f *File) Currency() string                      { return f.currency }
+func (f *File) FileName() string                      { return f.filename }
+func (f *File) Transactions() []*mymonies.Transaction { return f.transactions }
+func (f *File) Tags() []string                        { return f.tags }
+
+// FromFile loads transactions from a Beancount or hledger journal,
+// depending on the file name extension.
+func FromFile(filename string) (*Journal, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %v", err)
+	}
+	defer f.Close()
+	ext := filepath.Ext(filename)
+	return Parse(f, filepath.Base(filename), ext == ".beancount" || ext == ".bean")
+}
+
+// Parse reads a journal in Beancount syntax, or else in hledger syntax.
+// The file name is used in the imported files and skipped entries.
+func Parse(r io.Reader, filename string, beancount bool) (*Journal, error) {
+	p := &parser{
+		journal:   &Journal{},
+		filename:  filename,
+		beancount: beancount,
+		files:     make(map[string]*File),
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.line++
+		p.parseLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	p.endEntry()
+	return p.journal, nil
+}
+
+type parser struct {
+	journal   *Journal
+	filename  string
+	beancount bool
+	files     map[string]*File // by account
+	line      int
+
+	entry    *entry
+	ignoring bool // Whether indented lines belong to an ignored directive.
+}
+
+// entry is a journal transaction.
+type entry struct {
+	line        int
+	date        time.Time
+	payee       string
+	narration   string
+	postings    []posting
+	unsupported string // Reason the entry can't be imported.
+}
+
+type posting struct {
+	account   string
+	amount    money.Amount
+	commodity string
+	missing   bool // Whether the amount is left out to be inferred.
+}
+
+func (p *parser) parseLine(line string) {
+	if strings.TrimSpace(line) == "" {
+		p.endEntry()
+		p.ignoring = false
+		return
+	}
+	if line[0] == ' ' || line[0] == '\t' {
+		p.parseIndented(strings.TrimSpace(line))
+		return
+	}
+
+	p.endEntry()
+	p.ignoring = true
+	fields := strings.Fields(line)
+	d, ok := parseDate(fields[0])
+	if !ok {
+		// Comments and directives, e.g. option, include or account.
+		return
+	}
+	rest := strings.TrimSpace(line[len(fields[0]):])
+	var e *entry
+	if p.beancount {
+		e = parseBeancountHeader(rest)
+	} else {
+		e = parseHledgerHeader(rest)
+	}
+	if e == nil {
+		// Dated directives, e.g. open, balance or price.
+		return
+	}
+	e.line = p.line
+	e.date = d
+	p.entry = e
+	p.ignoring = false
+}
+
+func (p *parser) parseIndented(line string) {
+	if p.ignoring || p.entry == nil {
+		return
+	}
+	if line[0] == ';' || line[0] == '#' || isMetadata(line) {
+		return
+	}
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = strings.TrimSpace(line[:i])
+	}
+	// Postings can have a flag like transactions.
+	if len(line) > 2 && (line[0] == '*' || line[0] == '!') && line[1] == ' ' {
+		line = strings.TrimSpace(line[2:])
+	}
+
+	var account, amount string
+	if p.beancount {
+		fields := strings.SplitN(line, " ", 2)
+		account = fields[0]
+		if len(fields) == 2 {
+			amount = strings.TrimSpace(fields[1])
+		}
+	} else {
+		// hledger account names can contain single spaces.
+		end := len(line)
+		if i := strings.Index(line, "  "); i >= 0 {
+			end = i
+		}
+		if i := strings.IndexByte(line[:end], '\t'); i >= 0 {
+			end = i
+		}
+		account, amount = line[:end], strings.TrimSpace(line[end:])
+		// Balance assertions don't change the amount.
+		if i := strings.IndexByte(amount, '='); i >= 0 {
+			amount = strings.TrimSpace(amount[:i])
+		}
+	}
+
+	e := p.entry
+	if strings.ContainsAny(account[:1], "([") {
+		e.unsupport("virtual posting to " + account)
+		return
+	}
+	if strings.ContainsAny(amount, "@{") {
+		e.unsupport("posting with cost or price to " + account)
+		return
+	}
+	post := posting{account: account, missing: amount == ""}
+	if !post.missing {
+		var err error
+		if post.amount, post.commodity, err = parseAmount(amount); err != nil {
+			e.unsupport(fmt.Sprintf("amount %q: %v", amount, err))
+			return
+		}
+		// Stocks and other commodities are not currencies mymonies
+		// could store.
+		if !currency.Valid(post.commodity) {
+			e.unsupport(fmt.Sprintf("posting of %v to %v; %v is not a currency", amount, account, post.commodity))
+			return
+		}
+	}
+	e.postings = append(e.postings, post)
+}
+
+func (e *entry) unsupport(reason string) {
+	if e.unsupported == "" {
+		e.unsupported = reason
+	}
+}
+
+// endEntry imports the current entry, or reports why it can't be imported.
+func (p *parser) endEntry() {
+	e := p.entry
+	p.entry = nil
+	if e == nil {
+		return
+	}
+	if err := p.addEntry(e); err != "" {
+		p.journal.Skipped = append(p.journal.Skipped, fmt.Sprintf("%s:%d: %s", p.filename, e.line, err))
+	}
+}
+
+// addEntry imports an entry, or returns why it can't be imported.
+func (p *parser) addEntry(e *entry) string {
+	if e.unsupported != "" {
+		return e.unsupported
+	}
+	if err := e.inferAmount(); err != "" {
+		return err
+	}
+
+	var assets, categories []posting
+	for _, post := range e.postings {
+		switch accountType(post.account) {
+		case assetAccount:
+			assets = append(assets, post)
+		case categoryAccount:
+			categories = append(categories, post)
+		default:
+			return "posting to " + post.account + " cannot be imported"
+		}
+	}
+	switch {
+	case len(assets) == 0:
+		return "no posting to an asset or liability account"
+	case len(categories) > 1:
+		return fmt.Sprintf("split to %d expense or income accounts; mymonies has no split transactions", len(categories))
+	case len(categories) == 1 && len(assets) > 1:
+		return "several asset or liability accounts with an expense or income account"
+	}
+
+	var tag string
+	if len(categories) == 1 {
+		tag = subAccount(categories[0].account)
+		if strings.EqualFold(tag, "Uncategorized") {
+			tag = ""
+		}
+	}
+	// Transfers between asset accounts are imported to each account.
+	for _, post := range assets {
+		p.add(post, &mymonies.Transaction{
+			TransactionDate: e.date.Format(time.RFC3339),
+			Amount:          post.amount.String(),
+			Currency:        post.commodity,
+			PayeePayer:      e.payee,
+			Message:         e.narration,
+		}, tag)
+	}
+	return ""
+}
+
+// inferAmount sets the amount of a posting without one to balance the
+// entry, or returns why the entry can't be imported.
+func (e *entry) inferAmount() string {
+	var missing *posting
+	var sum money.Amount
+	var commodity string
+	for i := range e.postings {
+		post := &e.postings[i]
+		if post.missing {
+			if missing != nil {
+				return "several postings without amount"
+			}
+			missing = post
+			continue
+		}
+		if commodity != "" && post.commodity != commodity {
+			return "several commodities"
+		}
+		commodity = post.commodity
+		var err error
+		if sum, err = sum.Add(post.amount); err != nil {
+			return "sum of amounts out of range"
+		}
+	}
+	if missing != nil {
+		missing.amount, missing.commodity, missing.missing = sum.Neg(), commodity, false
+	}
+	return ""
+}
+
+// add adds a transaction to the file of the account of post.
+func (p *parser) add(post posting, t *mymonies.Transaction, tag string) {
+	account := subAccount(post.account)
+	f := p.files[account]
+	if f == nil {
+		f = &File{filename: p.filename, account: account, currency: post.commodity}
+		p.files[account] = f
+		p.journal.Files = append(p.journal.Files, f)
+	}
+	f.transactions = append(f.transactions, t)
+	f.tags = append(f.tags, tag)
+}
+
+const (
+	otherAccount = iota
+	assetAccount
+	categoryAccount
+)
+
+// accountType returns the type of an account by its top-level account.
+func accountType(account string) int {
+	root := strings.ToLower(strings.SplitN(account, ":", 2)[0])
+	switch root {
+	case "assets", "liabilities":
+		return assetAccount
+	case "expenses", "income", "revenue", "revenues":
+		return categoryAccount
+	}
+	return otherAccount
+}
+
+// subAccount returns account without its top-level account, e.g. FI1234 of
+// Assets:FI1234.
+func subAccount(account string) string {
+	if i := strings.IndexByte(account, ':'); i >= 0 {
+		return account[i+1:]
+	}
+	return account
+}
+
+// parseDate parses a journal date, ignoring any hledger secondary date.
+func parseDate(s string) (time.Time, bool) {
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" || !unicode.IsDigit(rune(s[0])) {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{"2006-1-2", "2006/1/2", "2006.1.2"} {
+		if d, err := time.Parse(layout, s); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseBeancountHeader parses the rest of a dated line, e.g.
+// * "Payee" "Narration" #tag, or returns nil if it is not a transaction.
+func parseBeancountHeader(s string) *entry {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil
+	}
+	switch fields[0] {
+	case "*", "!", "txn":
+	default:
+		return nil
+	}
+	s = strings.TrimSpace(s[len(fields[0]):])
+	var strs []string
+	for strings.HasPrefix(s, `"`) {
+		str, rest, ok := unquote(s)
+		if !ok {
+			break
+		}
+		strs = append(strs, str)
+		s = strings.TrimSpace(rest)
+	}
+	e := &entry{}
+	switch len(strs) {
+	case 1:
+		e.narration = strs[0]
+	case 2:
+		e.payee, e.narration = strs[0], strs[1]
+	}
+	return e
+}
+
+// unquote returns the Beancount string at the start of s and the rest of s.
+func unquote(s string) (str, rest string, ok bool) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", s, false
+}
+
+// parseHledgerHeader parses the rest of a dated line, e.g.
+// * (code) Payee | Note ; comment.
+func parseHledgerHeader(s string) *entry {
+	if i := strings.IndexByte(s, ';'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "*") || strings.HasPrefix(s, "!") {
+		s = strings.TrimSpace(s[1:])
+	}
+	if strings.HasPrefix(s, "(") {
+		if i := strings.IndexByte(s, ')'); i >= 0 {
+			s = strings.TrimSpace(s[i+1:])
+		}
+	}
+	e := &entry{payee: s}
+	if i := strings.Index(s, "|"); i >= 0 {
+		e.payee, e.narration = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+	return e
+}
+
+// isMetadata reports whether an indented line is Beancount metadata, e.g.
+// mymonies-id: "42".
+func isMetadata(line string) bool {
+	i := strings.IndexByte(line, ':')
+	if i <= 0 || !unicode.IsLower(rune(line[0])) {
+		return false
+	}
+	for _, r := range line[:i] {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return false
+		}
+	}
+	// Account names contain a colon too, but no space after it.
+	return i+1 == len(line) || line[i+1] == ' '
+}
+
+// symbols are the commodity symbols of hledger amounts, e.g. €12.50.
+var symbols = map[string]string{"€": "EUR", "$": "USD", "£": "GBP"}
+
+// parseAmount parses an amount with a commodity, e.g. -12.50 EUR, EUR -12.50,
+// €-12.50 or 3 200,00 EUR.
+func parseAmount(s string) (money.Amount, string, error) {
+	var number, commodity strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r) || strings.ContainsRune("+-.,", r):
+			number.WriteRune(r)
+		case !unicode.IsSpace(r):
+			commodity.WriteRune(r)
+		}
+	}
+	c := commodity.String()
+	if code, ok := symbols[c]; ok {
+		c = code
+	}
+	if c == "" {
+		return money.Amount{}, "", fmt.Errorf("no commodity")
+	}
+	a, err := money.Parse(normalizeNumber(number.String()))
+	return a, c, err
+}
+
+// normalizeNumber removes digit group marks, e.g. 1,000.00 or 1,000 is
+// 1000.00 or 1000, and 1.000,00 or 1 000,00 is 1000.00. A comma is the
+// decimal mark if it follows a dot, or if it is the only comma and not
+// followed by exactly three digits.
+func normalizeNumber(s string) string {
+	dot, comma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	decimalComma := comma > dot && (dot >= 0 ||
+		strings.Count(s, ",") == 1 && len(s)-comma-1 != 3)
+	if decimalComma {
+		s = strings.Replace(s, ".", "", -1)
+		s = strings.Replace(s, ",", ".", -1)
+	} else {
+		s = strings.Replace(s, ",", "", -1)
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package journal
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/datasource"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+var _ datasource.TaggedFile = &File{}
+
+func TestFromFile(t *testing.T) {
+	tests := []struct {
+		filename    string
+		wantFiles   []*File
+		wantSkipped []string
+	}{
+		{
+			filename: "example.beancount",
+			wantFiles: []*File{
+				{
+					filename: "example.beancount",
+					account:  "FI1234",
+					currency: "EUR",
+					transactions: []*pb.Transaction{
+						{TransactionDate: "2018-03-01T00:00:00Z", Amount: "-12.50", Currency: "EUR", PayeePayer: `K-Market "City"`, Message: "Weekly shopping"},
+						{TransactionDate: "2018-03-02T00:00:00Z", Amount: "3200.00", Currency: "EUR", Message: "Salary March"},
+						{TransactionDate: "2018-03-03T00:00:00Z", Amount: "-40.00", Currency: "EUR", Message: "ATM"},
+					},
+					tags: []string{"Food:Groceries", "Salary", ""},
+				},
+				{
+					filename:     "example.beancount",
+					account:      "Cash",
+					currency:     "EUR",
+					transactions: []*pb.Transaction{{TransactionDate: "2018-03-03T00:00:00Z", Amount: "40.00", Currency: "EUR", Message: "ATM"}},
+					tags:         []string{""},
+				},
+			},
+			wantSkipped: []string{
+				"example.beancount:21: split to 2 expense or income accounts; mymonies has no split transactions",
+				"example.beancount:28: posting to Equity:Opening-Balances cannot be imported",
+				"example.beancount:32: posting with cost or price to Assets:FI1234",
+			},
+		},
+		{
+			filename: "example.journal",
+			wantFiles: []*File{
+				{
+					filename: "example.journal",
+					account:  "FI1234",
+					currency: "EUR",
+					transactions: []*pb.Transaction{
+						{TransactionDate: "2018-03-01T00:00:00Z", Amount: "-12.50", Currency: "EUR", PayeePayer: "K-Market", Message: "Weekly shopping"},
+						{TransactionDate: "2018-03-02T00:00:00Z", Amount: "3200.00", Currency: "EUR", PayeePayer: "Employer Oy"},
+						{TransactionDate: "2018-03-03T00:00:00Z", Amount: "-1.00", Currency: "EUR", PayeePayer: "Kiosk"},
+					},
+					tags: []string{"Food and Drink", "Salary", ""},
+				},
+			},
+			wantSkipped: []string{
+				"example.journal:17: several commodities",
+				"example.journal:21: virtual posting to (Budget:Food)",
+				"example.journal:26: posting of 10 HOOL to Assets:Broker; HOOL is not a currency",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := FromFile(filepath.Join("testdata", tt.filename))
+		if err != nil {
+			t.Fatalf("FromFile(%v) returned error: %v", tt.filename, err)
+		}
+		if !reflect.DeepEqual(got.Files, tt.wantFiles) {
+			for _, f := range got.Files {
+				t.Logf("got file %+v", *f)
+			}
+			t.Errorf("FromFile(%v) files differ", tt.filename)
+		}
+		if !reflect.DeepEqual(got.Skipped, tt.wantSkipped) {
+			t.Errorf("FromFile(%v) skipped =\n%v\nwant\n%v", tt.filename, strings.Join(got.Skipped, "\n"), strings.Join(tt.wantSkipped, "\n"))
+		}
+	}
+}
+
+func TestFromFile_missing(t *testing.T) {
+	if _, err := FromFile(filepath.Join("testdata", "missing.journal")); err == nil {
+		t.Error("FromFile() of missing file returned no error")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in, wantAmount, wantCommodity string
+		wantErr                       bool
+	}{
+		{in: "-12.50 EUR", wantAmount: "-12.50", wantCommodity: "EUR"},
+		{in: "USD 1,000.00", wantAmount: "1000.00", wantCommodity: "USD"},
+		{in: "€-1.000,50", wantAmount: "-1000.50", wantCommodity: "EUR"},
+		{in: "1,000 EUR", wantAmount: "1000", wantCommodity: "EUR"},
+		{in: "1,000,000 EUR", wantAmount: "1000000", wantCommodity: "EUR"},
+		{in: "1,000,000.00 EUR", wantAmount: "1000000.00", wantCommodity: "EUR"},
+		{in: "1.000,50 EUR", wantAmount: "1000.50", wantCommodity: "EUR"},
+		{in: "1 000,00 EUR", wantAmount: "1000.00", wantCommodity: "EUR"},
+		{in: "12,5 EUR", wantAmount: "12.5", wantCommodity: "EUR"},
+		{in: "12.50", wantErr: true},
+		{in: "EUR", wantErr: true},
+	}
+	for _, tt := range tests {
+		a, c, err := parseAmount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (a.String() != tt.wantAmount || c != tt.wantCommodity) {
+			t.Errorf("parseAmount(%q) = %v %v, want %v %v", tt.in, a, c, tt.wantAmount, tt.wantCommodity)
+		}
+	}
+}
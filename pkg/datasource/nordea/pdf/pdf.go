@@ -2,15 +2,14 @@ package pdf
 
 import (
 	"fmt"
-	"math"
 	"path/filepath"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joneskoo/mymonies/pkg/datasource"
+	"github.com/joneskoo/mymonies/pkg/money"
 
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 	"rsc.io/pdf"
@@ -34,7 +33,7 @@ func FromFile(filename string) (datasource.File, error) {
 }
 
 func parseLines(lines []string) (account string, transactions []*mymonies.Transaction, err error) {
-	var billTotal float64
+	var billTotal money.Amount
 	var dueDate time.Time
 	for _, line := range lines {
 		switch {
@@ -63,27 +62,27 @@ func parseLines(lines []string) (account string, transactions []*mymonies.Transa
 
 	p := new(safeParser)
 	transactions = make([]*mymonies.Transaction, 0)
+	var sum money.Amount
 	for _, line := range lines {
 		if transactionPattern.MatchString(line) {
 			match := transactionPattern.FindStringSubmatch(line)
+			amount := p.amount(match[5], "amount")
+			p.add(&sum, amount)
 			transactions = append(transactions, &mymonies.Transaction{
 				TransactionDate: date(fixYear(p.date(match[1], "transaction date"), dueDate)),
 				ValueDate:       date(fixYear(p.date(match[2], "interest date"), dueDate)),
 				Transaction:     match[3],
 				PayeePayer:      match[4],
-				Amount:          p.amount(match[5], "amount"),
+				Amount:          amount.String(),
+				Currency:        currency,
 			})
 		}
 	}
 	if p.err != nil {
 		return "", nil, p.err
 	}
-	sum := 0.0
-	for _, tx := range transactions {
-		sum += tx.Amount
-	}
-	if math.Abs(billTotal-sum) > 0.009 {
-		return "", nil, fmt.Errorf("transaction amounts (%.2f) != bill total (%.2f)", sum, billTotal)
+	if sum.Cmp(billTotal) != 0 {
+		return "", nil, fmt.Errorf("transaction amounts (%v) != bill total (%v)", sum, billTotal)
 	}
 	return account, transactions, nil
 }
@@ -95,6 +94,10 @@ var (
 	transactionPattern   = regexp.MustCompile(`^(?P<Date>\d+\.\d+\.) +(?P<InterestDate>\d+\.\d+\.) +(?P<Transaction>[^ ]{12}) +(?P<Payee>.*[^ ]) +(?P<Amount>\d+\.\d\d-?) *$`)
 )
 
+// Credit card bills are billed in euros. Transactions in foreign currencies
+// are listed with the converted euro amount.
+const currency = "EUR"
+
 type bill struct {
 	file         string
 	account      string
@@ -106,6 +109,7 @@ func (b bill) Account() string {
 	account := "************" + b.account[12:]
 	return account
 }
+func (b bill) Currency() string                      { return currency }
 func (b bill) Transactions() []*mymonies.Transaction { return b.transactions }
 
 type safeParser struct {
@@ -123,7 +127,17 @@ func (p *safeParser) date(v, field string) (t time.Time) {
 	return
 }
 
-func (p *safeParser) amount(v, field string) (a float64) {
+func (p *safeParser) add(sum *money.Amount, a money.Amount) {
+	if p.err != nil {
+		return
+	}
+	*sum, p.err = sum.Add(a)
+	if p.err != nil {
+		p.err = fmt.Errorf("sum of amounts: %v", p.err)
+	}
+}
+
+func (p *safeParser) amount(v, field string) (a money.Amount) {
 	if p.err != nil {
 		return
 	}
@@ -178,16 +192,12 @@ func extractText(file string) ([]string, error) {
 	return lines, nil
 }
 
-func parseAmount(amount string) (float64, error) {
+func parseAmount(amount string) (money.Amount, error) {
 	amount = strings.Replace(amount, " ", "", -1)
 	if strings.HasSuffix(amount, "-") {
 		amount = "-" + amount[:len(amount)-1]
 	}
-	res, err := strconv.ParseFloat(amount, 64)
-	if err != nil {
-		return 0.0, err
-	}
-	return res, nil
+	return money.Parse(amount)
 }
 
 // fixYear fixes timestamp t be in the year window before reference
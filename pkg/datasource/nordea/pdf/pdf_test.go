@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/joneskoo/mymonies/pkg/datasource"
+	"github.com/joneskoo/mymonies/pkg/money"
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 )
 
@@ -97,9 +98,9 @@ func Test_parseLines(t *testing.T) {
 			}},
 			wantAccount: "1234567890123456/HOLDER CARD",
 			wantTransactions: []*mymonies.Transaction{
-				&mymonies.Transaction{TransactionDate: "2016-11-10T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: 13.37, PayeePayer: "HESBURGER", Transaction: "012765012765"},
-				&mymonies.Transaction{TransactionDate: "2016-11-19T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: 14.29, PayeePayer: "IKEA - HAPARANDA", Transaction: "133713371337"},
-				&mymonies.Transaction{TransactionDate: "2016-12-02T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: -4.99, PayeePayer: "ITUNES.COM/BILL          /HYVITYS", Transaction: "101010010010"},
+				&mymonies.Transaction{TransactionDate: "2016-11-10T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: "13.37", PayeePayer: "HESBURGER", Transaction: "012765012765", Currency: "EUR"},
+				&mymonies.Transaction{TransactionDate: "2016-11-19T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: "14.29", PayeePayer: "IKEA - HAPARANDA", Transaction: "133713371337", Currency: "EUR"},
+				&mymonies.Transaction{TransactionDate: "2016-12-02T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: "-4.99", PayeePayer: "ITUNES.COM/BILL          /HYVITYS", Transaction: "101010010010", Currency: "EUR"},
 			},
 		},
 
@@ -150,7 +151,7 @@ func Test_parseLines(t *testing.T) {
 			}},
 			wantAccount: "1234567890123456/HOLDER CARD",
 			wantTransactions: []*mymonies.Transaction{
-				&mymonies.Transaction{TransactionDate: "2016-11-10T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: 13.38, PayeePayer: "HESBURGER", Transaction: "012765012765"},
+				&mymonies.Transaction{TransactionDate: "2016-11-10T00:00:00Z", ValueDate: "2017-01-02T00:00:00Z", Amount: "13.38", PayeePayer: "HESBURGER", Transaction: "012765012765", Currency: "EUR"},
 			},
 		},
 
@@ -215,19 +216,18 @@ func Test_safeParser_amount(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		wantA   float64
+		wantA   money.Amount
 		wantErr bool
 	}{
 		{
 			name:  "valid",
 			args:  args{v: "10.00"},
-			wantA: 10.0,
+			wantA: money.New(1000, 2),
 		},
 
 		{
 			name:    "malformed amount",
 			args:    args{v: "10.a"},
-			wantA:   0.0,
 			wantErr: true,
 		},
 	}
@@ -298,25 +298,30 @@ func Test_parseAmount(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    float64
+		want    money.Amount
 		wantErr bool
 	}{
 		{
 			name: "valid",
 			args: args{"10.00"},
-			want: 10.0,
+			want: money.New(1000, 2),
 		},
 
 		{
 			name: "negative",
 			args: args{"10.00-"},
-			want: -10.0,
+			want: money.New(-1000, 2),
+		},
+
+		{
+			name: "thousands separator",
+			args: args{"1 234.50"},
+			want: money.New(123450, 2),
 		},
 
 		{
 			name:    "malformed amount",
 			args:    args{"10.a"},
-			want:    0.0,
 			wantErr: true,
 		},
 	}
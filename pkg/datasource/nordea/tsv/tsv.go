@@ -8,11 +8,11 @@ import (
 	"io"
 	"io/ioutil"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joneskoo/mymonies/pkg/datasource"
+	"github.com/joneskoo/mymonies/pkg/money"
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 )
 
@@ -65,6 +65,7 @@ type File struct {
 }
 
 func (f File) Account() string                       { return f.account }
+func (f File) Currency() string                      { return currency }
 func (f File) FileName() string                      { return filepath.Base(f.filename) }
 func (f File) Transactions() []*mymonies.Transaction { return f.transactions }
 
@@ -86,6 +87,9 @@ var fields = []string{
 
 const dateFormat = "02.01.2006"
 
+// Nordea TSV exports do not include a currency; accounts are in euros.
+const currency = "EUR"
+
 func date(t time.Time) string {
 	return t.UTC().Truncate(24 * time.Hour).Format(time.RFC3339)
 }
@@ -105,6 +109,7 @@ func fromSlice(r []string) (t *mymonies.Transaction, err error) {
 		PayerReference:  r[9],
 		Message:         joinMessage(r[10]),
 		CardNumber:      r[11],
+		Currency:        currency,
 		// Receipt:         r[12], // receipt column ignored
 	}
 	if p.err != nil {
@@ -158,15 +163,17 @@ func (p *safeParser) date(v, field string) string {
 	return t.UTC().Truncate(24 * time.Hour).Format(time.RFC3339)
 }
 
-func (p *safeParser) amount(v, field string) (a float64) {
+func (p *safeParser) amount(v, field string) string {
 	if p.err != nil {
-		return
+		return ""
 	}
-	a, p.err = strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+	var a money.Amount
+	a, p.err = money.Parse(strings.Replace(v, ",", ".", 1))
 	if p.err != nil {
 		p.err = fmt.Errorf("bad %v format: %v", field, p.err)
+		return ""
 	}
-	return
+	return a.String()
 }
 
 var helsinki *time.Location
@@ -41,12 +41,13 @@ func TestFromFile(t *testing.T) {
 						TransactionDate: mustParseRFC3339("2015-03-23T00:00:00+02:00"),
 						ValueDate:       mustParseRFC3339("2015-03-22T00:00:00+02:00"),
 						PaymentDate:     mustParseRFC3339("2015-03-22T00:00:00+02:00"),
-						Amount:          -30.00,
+						Amount:          "-30.00",
 						PayeePayer:      "Payee ry",
 						Account:         "FI1012345600007890",
 						Bic:             "ASDFFIHHXXX",
 						Transaction:     "Itsepalvelu",
 						Reference:       "1 27650",
+						Currency:        "EUR",
 					},
 				},
 			},
@@ -89,12 +90,13 @@ func Test_fromSlice(t *testing.T) {
 				TransactionDate: mustParseRFC3339("2015-03-23T00:00:00+02:00"),
 				ValueDate:       mustParseRFC3339("2015-03-22T00:00:00+02:00"),
 				PaymentDate:     mustParseRFC3339("2015-03-22T00:00:00+02:00"),
-				Amount:          -30.00,
+				Amount:          "-30.00",
 				PayeePayer:      "Payee ry",
 				Account:         "FI1012345600007890",
 				Bic:             "ASDFFIHHXXX",
 				Transaction:     "Itsepalvelu",
 				Reference:       "1 27650",
+				Currency:        "EUR",
 			},
 			false,
 		},
@@ -106,10 +108,11 @@ func Test_fromSlice(t *testing.T) {
 				TransactionDate: mustParseRFC3339("2016-12-07T00:00:00+02:00"),
 				ValueDate:       mustParseRFC3339("2016-12-07T00:00:00+02:00"),
 				PaymentDate:     mustParseRFC3339("2016-12-05T00:00:00+02:00"),
-				Amount:          50,
+				Amount:          "50.00",
 				PayeePayer:      "EXAMPLE PERSON NAME",
 				Transaction:     "Pano",
 				Message:         "Merry xmas and happy new year to you and your family. ",
+				Currency:        "EUR",
 			},
 			false,
 		},
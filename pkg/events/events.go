@@ -0,0 +1,110 @@
+// Package events implements an in-process publish/subscribe hub of data
+// change events, e.g. for pushing live updates to the browser.
+package events
+
+import "sync"
+
+// Types of change events.
+const (
+	// Import is published when transactions are imported.
+	Import = "import"
+	// Tag is published when the tags of transactions change, including
+	// undoing a change.
+	Tag = "tag"
+	// Pattern is published when a pattern is added and the transactions
+	// matching it are tagged.
+	Pattern = "pattern"
+)
+
+// Event is a change of the data of a household.
+type Event struct {
+	Type      string `json:"type"`
+	Household string `json:"-"`
+	// Changed transactions of Tag events. Empty if unknown, e.g. when an
+	// operation is undone.
+	TransactionIDs []string `json:"transaction_ids,omitempty"`
+	// Tag operation of Tag and Pattern events, to tell changes made by
+	// this client from changes made by others.
+	OperationID string `json:"operation_id,omitempty"`
+}
+
+// subscriberBuffer is the number of events buffered for a subscriber before
+// it is considered too slow and unsubscribed.
+const subscriberBuffer = 64
+
+// Hub delivers published events to the subscribers of the household. The
+// zero value is a hub without subscribers. A nil *Hub discards events.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]string // household by channel
+	closed      bool
+}
+
+// NewHub returns a hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{}
+}
+
+// Subscribe returns a channel receiving the events of household, and a
+// function to cancel the subscription. The channel is closed when the
+// subscription is cancelled, or if the subscriber falls behind and misses
+// events; the subscriber should then reload its data. After Close, the
+// channel is closed already.
+func (h *Hub) Subscribe(household string) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers == nil {
+		h.subscribers = make(map[chan Event]string)
+	}
+	h.subscribers[ch] = household
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(ch)
+	}
+}
+
+// Publish sends e to the subscribers of e.Household without blocking.
+func (h *Hub) Publish(e Event) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, household := range h.subscribers {
+		if household != e.Household {
+			continue
+		}
+		select {
+		case ch <- e:
+		default:
+			h.remove(ch)
+		}
+	}
+}
+
+// Close cancels all subscriptions, e.g. to end event streams when the
+// server shuts down. Subscribers see their channels closed as if they fell
+// behind, and later subscriptions are closed when made.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subscribers {
+		h.remove(ch)
+	}
+}
+
+// remove closes and removes a subscriber, if it has not been removed yet.
+// The caller must hold h.mu.
+func (h *Hub) remove(ch chan Event) {
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
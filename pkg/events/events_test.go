@@ -0,0 +1,73 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHub(t *testing.T) {
+	h := NewHub()
+	mine, cancel := h.Subscribe("1")
+	other, cancelOther := h.Subscribe("2")
+	defer cancelOther()
+
+	e := Event{Type: Tag, Household: "1", TransactionIDs: []string{"3"}, OperationID: "4"}
+	h.Publish(e)
+	if got := <-mine; !reflect.DeepEqual(got, e) {
+		t.Errorf("subscriber received %v, want %v", got, e)
+	}
+	select {
+	case got := <-other:
+		t.Errorf("subscriber of other household received %v", got)
+	default:
+	}
+
+	cancel()
+	cancel()
+	if _, ok := <-mine; ok {
+		t.Error("channel open after cancel")
+	}
+	h.Publish(e)
+}
+
+func TestHub_slowSubscriber(t *testing.T) {
+	h := NewHub()
+	ch, cancel := h.Subscribe("1")
+	defer cancel()
+	for i := 0; i <= subscriberBuffer; i++ {
+		h.Publish(Event{Type: Import, Household: "1"})
+	}
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("slow subscriber received %d events before close, want %d", n, subscriberBuffer)
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	h := NewHub()
+	a, cancelA := h.Subscribe("1")
+	b, cancelB := h.Subscribe("2")
+	h.Close()
+	for _, ch := range []<-chan Event{a, b} {
+		if _, ok := <-ch; ok {
+			t.Error("channel open after Close")
+		}
+	}
+	cancelA()
+	cancelB()
+
+	c, cancelC := h.Subscribe("1")
+	h.Publish(Event{Type: Import, Household: "1"})
+	if _, ok := <-c; ok {
+		t.Error("channel subscribed after Close is open")
+	}
+	cancelC()
+}
+
+func TestHub_nil(t *testing.T) {
+	var h *Hub
+	h.Publish(Event{Type: Import})
+}
@@ -0,0 +1,129 @@
+package exchangerate
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/money"
+)
+
+// FromFile loads euro foreign exchange reference rates published by the
+// European Central Bank. Both the CSV (eurofxref-hist.csv) and XML
+// (eurofxref-hist.xml) formats are supported, including the files with
+// rates of a single day.
+func FromFile(filename string) ([]Rate, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch ext := filepath.Ext(filename); ext {
+	case ".csv":
+		return ParseECBCSV(f)
+	case ".xml":
+		return ParseECBXML(f)
+	default:
+		return nil, fmt.Errorf("file type extension %q is not supported", ext)
+	}
+}
+
+// ecbDateFormats are the date formats used in the ECB CSV files. The
+// historical file uses ISO dates, while the daily file spells out the month.
+var ecbDateFormats = []string{"2006-01-02", "02 January 2006"}
+
+func parseECBDate(v string) (t time.Time, err error) {
+	for _, layout := range ecbDateFormats {
+		t, err = time.Parse(layout, v)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return t, fmt.Errorf("bad date format %q", v)
+}
+
+// ParseECBCSV parses ECB reference rates in CSV format. The first row is
+// a header with the currency codes; each following row contains the rates
+// of one day. Rates marked N/A are skipped.
+func ParseECBCSV(r io.Reader) ([]Rate, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %v", err)
+	}
+	if len(header) < 2 || header[0] != "Date" {
+		return nil, fmt.Errorf("unknown format, header must start with Date")
+	}
+	var rates []Rate
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		date, err := parseECBDate(strings.TrimSpace(row[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		for i := 1; i < len(row) && i < len(header); i++ {
+			code, v := strings.TrimSpace(header[i]), strings.TrimSpace(row[i])
+			if code == "" || v == "" || v == "N/A" {
+				continue
+			}
+			rate, err := money.Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad %v rate: %v", line, code, err)
+			}
+			rates = append(rates, Rate{Date: date, Currency: code, Rate: rate})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates found")
+	}
+	return rates, nil
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBXML parses ECB reference rates in the gesmes XML format.
+func ParseECBXML(r io.Reader) ([]Rate, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, fmt.Errorf("could not decode XML: %v", err)
+	}
+	var rates []Rate
+	for _, day := range env.Days {
+		date, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("bad date format %q", day.Time)
+		}
+		for _, r := range day.Rates {
+			rate, err := money.Parse(r.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("%v: bad %v rate: %v", day.Time, r.Currency, err)
+			}
+			rates = append(rates, Rate{Date: date, Currency: r.Currency, Rate: rate})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates found")
+	}
+	return rates, nil
+}
@@ -0,0 +1,106 @@
+// Package exchangerate converts amounts of money between currencies using
+// daily reference exchange rates.
+package exchangerate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/currency"
+	"github.com/joneskoo/mymonies/pkg/money"
+)
+
+// Base is the currency that all exchange rates are quoted against.
+// The European Central Bank publishes reference rates against the euro.
+const Base = "EUR"
+
+// MaxFallback is how many days before the requested date a rate is looked
+// up when no rate was published on the date itself. Reference rates are
+// not published on weekends and public holidays.
+const MaxFallback = 7
+
+// ErrNoRate is returned when no exchange rate is available for a currency
+// on or shortly before the requested date.
+var ErrNoRate = errors.New("exchangerate: no exchange rate available")
+
+// Rate is a reference exchange rate: on Date one euro was worth Rate units
+// of Currency.
+type Rate struct {
+	Date     time.Time
+	Currency string
+	Rate     money.Amount
+}
+
+// Source looks up reference exchange rates.
+type Source interface {
+	// ExchangeRate returns the latest rate of currency published on
+	// date or at most MaxFallback days before it. If there is no such
+	// rate, it returns ErrNoRate.
+	ExchangeRate(currency string, date time.Time) (Rate, error)
+}
+
+// Converter converts amounts between currencies. It caches the rates it
+// has looked up, so a Converter should be used for one request only.
+type Converter struct {
+	src   Source
+	cache map[rateKey]Rate
+}
+
+type rateKey struct {
+	currency string
+	date     time.Time
+}
+
+// NewConverter returns a converter looking up rates from src.
+func NewConverter(src Source) *Converter {
+	return &Converter{src: src, cache: make(map[rateKey]Rate)}
+}
+
+// Convert converts amount a from currency from to currency to using the
+// rates of date. The result is rounded to the minor unit of to.
+func (c *Converter) Convert(a money.Amount, from, to string, date time.Time) (money.Amount, error) {
+	scale, ok := currency.MinorUnits(to)
+	if !ok {
+		return money.Amount{}, fmt.Errorf("exchangerate: invalid currency %q", to)
+	}
+	if from == to {
+		return a.WithMinScale(scale), nil
+	}
+	fromRate, err := c.rate(from, date)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	toRate, err := c.rate(to, date)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	// a [from] / fromRate [from/EUR] * toRate [to/EUR]
+	r := a.Rat()
+	r.Quo(r, fromRate.Rat())
+	r.Mul(r, toRate.Rat())
+	return money.FromRat(r, scale)
+}
+
+func (c *Converter) rate(code string, date time.Time) (money.Amount, error) {
+	if code == Base {
+		return money.New(1, 0), nil
+	}
+	date = date.UTC().Truncate(24 * time.Hour)
+	key := rateKey{code, date}
+	r, ok := c.cache[key]
+	if !ok {
+		var err error
+		r, err = c.src.ExchangeRate(code, date)
+		if err == ErrNoRate {
+			return money.Amount{}, fmt.Errorf("%v for %v on %v", err, code, date.Format("2006-01-02"))
+		} else if err != nil {
+			return money.Amount{}, err
+		}
+		if r.Rate.Sign() <= 0 {
+			return money.Amount{}, fmt.Errorf("exchangerate: invalid rate %v for %v", r.Rate, code)
+		}
+		c.cache[key] = r
+	}
+	return r.Rate, nil
+}
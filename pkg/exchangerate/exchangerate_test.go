@@ -0,0 +1,104 @@
+package exchangerate
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/money"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var histRates = []Rate{
+	{day("2024-01-05"), "USD", money.MustParse("1.0921")},
+	{day("2024-01-05"), "JPY", money.MustParse("158.13")},
+	{day("2024-01-05"), "SEK", money.MustParse("11.0565")},
+	{day("2024-01-04"), "USD", money.MustParse("1.0953")},
+	{day("2024-01-04"), "JPY", money.MustParse("158.37")},
+	{day("2024-01-04"), "SEK", money.MustParse("11.1645")},
+}
+
+func TestFromFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []Rate
+		wantErr bool
+	}{
+		{name: "missing file", file: "", wantErr: true},
+		{name: "historical csv", file: "eurofxref-hist.csv", want: histRates},
+		{name: "historical xml", file: "eurofxref-hist.xml", want: histRates},
+		{name: "daily csv", file: "eurofxref.csv", want: histRates[:3]},
+		{name: "invalid csv", file: "invalid.csv", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromFile(filepath.Join("testdata", tt.file))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeSource implements Source with a fixed list of rates.
+type fakeSource []Rate
+
+func (s fakeSource) ExchangeRate(currency string, date time.Time) (Rate, error) {
+	var found *Rate
+	for i, r := range s {
+		if r.Currency != currency || r.Date.After(date) || date.Sub(r.Date) > MaxFallback*24*time.Hour {
+			continue
+		}
+		if found == nil || r.Date.After(found.Date) {
+			found = &s[i]
+		}
+	}
+	if found == nil {
+		return Rate{}, ErrNoRate
+	}
+	return *found, nil
+}
+
+func TestConverter_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		from, to string
+		date     string
+		want     string
+		wantErr  bool
+	}{
+		{name: "same currency", amount: "10", from: "EUR", to: "EUR", date: "2024-01-05", want: "10.00"},
+		{name: "to base", amount: "110.57", from: "SEK", to: "EUR", date: "2024-01-05", want: "10.00"},
+		{name: "from base", amount: "10.00", from: "EUR", to: "USD", date: "2024-01-04", want: "10.95"},
+		{name: "cross rate", amount: "100.00", from: "USD", to: "JPY", date: "2024-01-05", want: "14479"},
+		{name: "weekend uses friday", amount: "10.00", from: "EUR", to: "USD", date: "2024-01-07", want: "10.92"},
+		{name: "too old rate", amount: "10.00", from: "EUR", to: "USD", date: "2024-01-20", wantErr: true},
+		{name: "unknown currency", amount: "10.00", from: "NOK", to: "EUR", date: "2024-01-05", wantErr: true},
+		{name: "invalid target", amount: "10.00", from: "EUR", to: "XYZ", date: "2024-01-05", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter(fakeSource(histRates))
+			got, err := c.Convert(money.MustParse(tt.amount), tt.from, tt.to, day(tt.date))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Convert() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
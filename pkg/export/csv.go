@@ -0,0 +1,98 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvHeader is the header row of CSV exports.
+var csvHeader = []string{
+	"id",
+	"transaction_date",
+	"value_date",
+	"payment_date",
+	"amount",
+	"currency",
+	"payee_payer",
+	"account",
+	"bic",
+	"transaction",
+	"reference",
+	"payer_reference",
+	"message",
+	"card_number",
+	"tag_id",
+	"tag",
+	"import_id",
+	"import_file_name",
+	"import_account",
+}
+
+type csvWriter struct {
+	w            *csv.Writer
+	decimalComma bool
+	header       bool // Whether the header row has been written.
+}
+
+func newCSV(w io.Writer, opts Options) Writer {
+	cw := csv.NewWriter(w)
+	switch {
+	case opts.Delimiter != 0:
+		cw.Comma = opts.Delimiter
+	case opts.DecimalComma:
+		cw.Comma = ';'
+	}
+	return &csvWriter{w: cw, decimalComma: opts.DecimalComma}
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.w.Write(csvHeader)
+}
+
+func (w *csvWriter) Write(r *Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	amount := r.Amount
+	if w.decimalComma {
+		amount = strings.Replace(amount, ".", ",", 1)
+	}
+	var file, account string
+	if r.Import != nil {
+		file, account = r.Import.FileName, r.Import.Account
+	}
+	return w.w.Write([]string{
+		r.Id,
+		date(r.TransactionDate),
+		date(r.ValueDate),
+		date(r.PaymentDate),
+		amount,
+		r.Currency,
+		r.PayeePayer,
+		r.Account,
+		r.Bic,
+		r.Transaction.Transaction,
+		r.Reference,
+		r.PayerReference,
+		r.Message,
+		r.CardNumber,
+		r.TagId,
+		r.Tag,
+		r.ImportId,
+		file,
+		account,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
@@ -0,0 +1,65 @@
+// Package export writes transaction records in file formats for other
+// tools, e.g. spreadsheets and plain-text accounting.
+//
+// Every transaction is exported as one line or entry with its tag. There are
+// no split lines, as mymonies has no split transactions.
+package export
+
+import (
+	"io"
+	"strings"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// Record is an exported transaction with its tag name and import.
+type Record struct {
+	*pb.Transaction
+	Tag    string // Empty if the transaction has no tag.
+	Import *database.Import
+}
+
+// Writer writes records in an export format.
+type Writer interface {
+	Write(r *Record) error
+	// Close writes any buffered data. It does not close the underlying
+	// io.Writer.
+	Close() error
+}
+
+// Options are options of the export formats.
+type Options struct {
+	// Delimiter is the CSV field delimiter. The default is comma, or
+	// semicolon with DecimalComma.
+	Delimiter rune
+	// DecimalComma writes CSV amounts with decimal comma, e.g. "-12,50"
+	// for Finnish Excel.
+	DecimalComma bool
+}
+
+// Format is an export file format.
+type Format struct {
+	ContentType string
+	Extension   string // File name extension, e.g. ".csv".
+	New         func(w io.Writer, opts Options) Writer
+}
+
+// Formats are the export formats by name.
+var Formats = map[string]Format{
+	"csv":   {ContentType: "text/csv; charset=utf-8", Extension: ".csv", New: newCSV},
+	"jsonl": {ContentType: "application/x-ndjson", Extension: ".jsonl", New: newJSONLines},
+	// The ledger-cli and hledger journal formats are the same.
+	"ledger":    {ContentType: "text/plain; charset=utf-8", Extension: ".ledger", New: newLedger},
+	"hledger":   {ContentType: "text/plain; charset=utf-8", Extension: ".journal", New: newLedger},
+	"beancount": {ContentType: "text/plain; charset=utf-8", Extension: ".beancount", New: newBeancount},
+}
+
+// date returns the date of an RFC 3339 timestamp with zero time, e.g.
+// "2018-03-01" of "2018-03-01T00:00:00Z".
+func date(timestamp string) string {
+	if i := strings.IndexByte(timestamp, 'T'); i >= 0 {
+		return timestamp[:i]
+	}
+	return timestamp
+}
@@ -0,0 +1,96 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+var testRecords = []*Record{
+	{
+		Transaction: &pb.Transaction{
+			Id:              "2",
+			TransactionDate: "2018-03-02T00:00:00Z",
+			Amount:          "-12.50",
+			Currency:        "EUR",
+			PayeePayer:      "Shop; Ltd",
+			TagId:           "1",
+			ImportId:        "1",
+		},
+		Tag:    "groceries",
+		Import: &database.Import{ID: "1", FileName: "march.txt", Account: "FI1234", Currency: "EUR"},
+	},
+	{
+		Transaction: &pb.Transaction{Id: "3", PaymentDate: "2018-04-01T00:00:00Z", Amount: "1000.00", Currency: "EUR", Message: "Salary"},
+	},
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format string
+		opts   Options
+		want   string
+	}{
+		{
+			format: "csv",
+			want: "id,transaction_date,value_date,payment_date,amount,currency,payee_payer,account,bic,transaction,reference,payer_reference,message,card_number,tag_id,tag,import_id,import_file_name,import_account\n" +
+				"2,2018-03-02,,,-12.50,EUR,Shop; Ltd,,,,,,,,1,groceries,1,march.txt,FI1234\n" +
+				"3,,,2018-04-01,1000.00,EUR,,,,,,,Salary,,,,,,\n",
+		},
+		{
+			format: "csv",
+			opts:   Options{DecimalComma: true},
+			want: "id;transaction_date;value_date;payment_date;amount;currency;payee_payer;account;bic;transaction;reference;payer_reference;message;card_number;tag_id;tag;import_id;import_file_name;import_account\n" +
+				"2;2018-03-02;;;-12,50;EUR;\"Shop; Ltd\";;;;;;;;1;groceries;1;march.txt;FI1234\n" +
+				"3;;;2018-04-01;1000,00;EUR;;;;;;;Salary;;;;;;\n",
+		},
+		{
+			format: "jsonl",
+			want: `{"id":"2","transaction_date":"2018-03-02","amount":"-12.50","currency":"EUR","payee_payer":"Shop; Ltd","tag_id":"1","tag":"groceries","import":{"id":"1","file_name":"march.txt","account":"FI1234","currency":"EUR"}}` + "\n" +
+				`{"id":"3","payment_date":"2018-04-01","amount":"1000.00","currency":"EUR","message":"Salary"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		w := Formats[tt.format].New(&buf, tt.opts)
+		for _, r := range testRecords {
+			if err := w.Write(r); err != nil {
+				t.Fatalf("%v: Write() returned error: %v", tt.format, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%v: Close() returned error: %v", tt.format, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%v %+v export =\n%s\nwant\n%s", tt.format, tt.opts, got, tt.want)
+		}
+	}
+}
+
+// failingWriter fails every write, like a client that went away.
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWrite }
+
+func TestFormats_writeError(t *testing.T) {
+	for name, format := range Formats {
+		w := format.New(failingWriter{}, Options{})
+		var err error
+		for _, r := range testRecords {
+			if err = w.Write(r); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		if err != errWrite {
+			t.Errorf("%v: export to a failing writer returned %v, want %v", name, err, errWrite)
+		}
+	}
+}
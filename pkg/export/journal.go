@@ -0,0 +1,265 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/joneskoo/mymonies/pkg/money"
+)
+
+// IDKey is the metadata key of the mymonies transaction id in journal
+// exports. Tools can use it to replace previously exported transactions.
+const IDKey = "mymonies-id"
+
+// journalWriter writes plain-text accounting journals of ledger-cli and
+// hledger, or Beancount. The imported account of a transaction is an asset
+// account, e.g. Assets:FI1234, and its tag is an expense or income account
+// by the sign of the amount, e.g. Expenses:Groceries. Colons in tag names
+// separate sub-accounts, e.g. "food:groceries" is Expenses:Food:Groceries.
+//
+// Every transaction is one entry with two postings. There are no split
+// postings, as mymonies has no split transactions: a transaction has a
+// single tag.
+//
+// Transactions are written sorted by date and id when the writer is
+// closed, so that exporting the same data gives the same journal. Close
+// returns the first error writing the journal.
+type journalWriter struct {
+	w         io.Writer
+	beancount bool
+	records   []*Record
+}
+
+func newLedger(w io.Writer, _ Options) Writer {
+	return &journalWriter{w: w}
+}
+
+func newBeancount(w io.Writer, _ Options) Writer {
+	return &journalWriter{w: w, beancount: true}
+}
+
+func (w *journalWriter) Write(r *Record) error {
+	w.records = append(w.records, r)
+	return nil
+}
+
+// journalEntry is a transaction with two postings balancing each other.
+type journalEntry struct {
+	date     string
+	id       string
+	payee    string
+	message  string
+	currency string
+	asset    string
+	other    string       // Expense or income account.
+	amount   money.Amount // Amount of the asset posting.
+}
+
+func (w *journalWriter) Close() error {
+	var entries []journalEntry
+	var skipped []string
+	for _, r := range w.records {
+		e, ok := w.entry(r)
+		if !ok {
+			skipped = append(skipped, r.Id)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].date != entries[j].date {
+			return entries[i].date < entries[j].date
+		}
+		return idLess(entries[i].id, entries[j].id)
+	})
+	sort.Slice(skipped, func(i, j int) bool { return idLess(skipped[i], skipped[j]) })
+
+	ew := &errWriter{w: w.w}
+	if w.beancount {
+		writeBeancountOpens(ew, entries)
+	}
+	for _, id := range skipped {
+		ew.printf("; Skipped transaction %v without date or valid amount.\n", id)
+	}
+	for _, e := range entries {
+		if w.beancount {
+			writeBeancountEntry(ew, e)
+		} else {
+			writeLedgerEntry(ew, e)
+		}
+	}
+	return ew.err
+}
+
+// entry returns the journal entry of a record, or false if it has no date
+// or its amount is invalid.
+func (w *journalWriter) entry(r *Record) (journalEntry, bool) {
+	var d string
+	for _, ts := range []string{r.TransactionDate, r.ValueDate, r.PaymentDate} {
+		if ts != "" {
+			d = date(ts)
+			break
+		}
+	}
+	amount, err := money.Parse(r.Amount)
+	if d == "" || err != nil {
+		return journalEntry{}, false
+	}
+
+	account := r.Account
+	if r.Import != nil {
+		account = r.Import.Account
+	}
+	root := "Expenses"
+	if amount.Sign() > 0 {
+		root = "Income"
+	}
+	tag := r.Tag
+	if tag == "" {
+		tag = "Uncategorized"
+	}
+	return journalEntry{
+		date:     d,
+		id:       r.Id,
+		payee:    r.PayeePayer,
+		message:  r.Message,
+		currency: r.Currency,
+		asset:    w.account("Assets", account),
+		other:    w.account(root, tag),
+		amount:   amount,
+	}, true
+}
+
+// account returns the account name of root and the colon-separated
+// sub-accounts of name.
+func (w *journalWriter) account(root, name string) string {
+	parts := []string{root}
+	for _, p := range strings.Split(name, ":") {
+		if w.beancount {
+			p = beancountComponent(p)
+		} else {
+			p = ledgerComponent(p)
+		}
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 1 {
+		parts = append(parts, "Unknown")
+	}
+	return strings.Join(parts, ":")
+}
+
+// ledgerComponent returns s as a ledger account name component. Two spaces
+// would end the account name, so spaces are collapsed.
+func ledgerComponent(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
+
+// beancountComponent returns s as a Beancount account name component,
+// which starts with a capital letter or a number and contains only letters,
+// numbers and dashes.
+func beancountComponent(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		} else {
+			dash = true
+		}
+	}
+	r := []rune(b.String())
+	if len(r) == 0 {
+		return ""
+	}
+	r[0] = unicode.ToUpper(r[0])
+	if !unicode.IsUpper(r[0]) && !unicode.IsDigit(r[0]) {
+		return "X" + string(r)
+	}
+	return string(r)
+}
+
+func writeLedgerEntry(w *errWriter, e journalEntry) {
+	w.printf("%s %s\n", e.date, oneLine(e.payee))
+	w.printf("    ; %s: %s\n", IDKey, e.id)
+	if e.message != "" {
+		w.printf("    ; %s\n", oneLine(e.message))
+	}
+	w.printf("    %s  %s %s\n", e.asset, e.amount, e.currency)
+	w.printf("    %s  %s %s\n\n", e.other, e.amount.Neg(), e.currency)
+}
+
+// writeBeancountOpens writes the open directives of the accounts of
+// entries, dated on their first use.
+func writeBeancountOpens(w *errWriter, entries []journalEntry) {
+	opened := make(map[string]string)
+	for _, e := range entries {
+		for _, a := range []string{e.asset, e.other} {
+			if d, ok := opened[a]; !ok || e.date < d {
+				opened[a] = e.date
+			}
+		}
+	}
+	accounts := make([]string, 0, len(opened))
+	for a := range opened {
+		accounts = append(accounts, a)
+	}
+	sort.Strings(accounts)
+	for _, a := range accounts {
+		w.printf("%s open %s\n", opened[a], a)
+	}
+	if len(accounts) > 0 {
+		w.printf("\n")
+	}
+}
+
+func writeBeancountEntry(w *errWriter, e journalEntry) {
+	w.printf("%s * %s %s\n", e.date, beancountString(e.payee), beancountString(e.message))
+	w.printf("  %s: %s\n", IDKey, beancountString(e.id))
+	w.printf("  %s  %s %s\n", e.asset, e.amount, e.currency)
+	w.printf("  %s  %s %s\n\n", e.other, e.amount.Neg(), e.currency)
+}
+
+// beancountString returns s as a quoted Beancount string.
+func beancountString(s string) string {
+	s = strings.Replace(oneLine(s), `\`, `\\`, -1)
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// oneLine returns s with line breaks replaced by spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// idLess orders numeric ids by value.
+func idLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// errWriter writes formatted text until the first error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) printf(format string, args ...interface{}) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, format, args...)
+	}
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func TestJournalFormats(t *testing.T) {
+	card := &database.Import{ID: "1", Account: "4920 0000 0000 0000", Currency: "EUR"}
+	records := []*Record{
+		{
+			Transaction: &pb.Transaction{Id: "10", TransactionDate: "2018-03-02T00:00:00Z", Amount: "-12.50", Currency: "EUR", PayeePayer: `K-Market "City"`},
+			Tag:         "food:groceries",
+			Import:      card,
+		},
+		{
+			Transaction: &pb.Transaction{Id: "9", PaymentDate: "2018-03-02T00:00:00Z", Amount: "3200.00", Currency: "EUR", PayeePayer: "Employer Oy", Message: "Salary\nMarch"},
+			Tag:         "salary",
+			Import:      card,
+		},
+		{
+			Transaction: &pb.Transaction{Id: "8", TransactionDate: "2018-03-01T00:00:00Z", Amount: "-1.00", Currency: "EUR", PayeePayer: "Kiosk"},
+			Import:      card,
+		},
+		{Transaction: &pb.Transaction{Id: "7", Amount: "-5.00", Currency: "EUR"}},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "ledger",
+			want: `; Skipped transaction 7 without date or valid amount.
+2018-03-01 Kiosk
+    ; mymonies-id: 8
+    Assets:4920 0000 0000 0000  -1.00 EUR
+    Expenses:Uncategorized  1.00 EUR
+
+2018-03-02 Employer Oy
+    ; mymonies-id: 9
+    ; Salary March
+    Assets:4920 0000 0000 0000  3200.00 EUR
+    Income:Salary  -3200.00 EUR
+
+2018-03-02 K-Market "City"
+    ; mymonies-id: 10
+    Assets:4920 0000 0000 0000  -12.50 EUR
+    Expenses:Food:Groceries  12.50 EUR
+
+`,
+		},
+		{
+			format: "beancount",
+			want: `2018-03-01 open Assets:4920-0000-0000-0000
+2018-03-02 open Expenses:Food:Groceries
+2018-03-01 open Expenses:Uncategorized
+2018-03-02 open Income:Salary
+
+; Skipped transaction 7 without date or valid amount.
+2018-03-01 * "Kiosk" ""
+  mymonies-id: "8"
+  Assets:4920-0000-0000-0000  -1.00 EUR
+  Expenses:Uncategorized  1.00 EUR
+
+2018-03-02 * "Employer Oy" "Salary March"
+  mymonies-id: "9"
+  Assets:4920-0000-0000-0000  3200.00 EUR
+  Income:Salary  -3200.00 EUR
+
+2018-03-02 * "K-Market \"City\"" ""
+  mymonies-id: "10"
+  Assets:4920-0000-0000-0000  -12.50 EUR
+  Expenses:Food:Groceries  12.50 EUR
+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		w := Formats[tt.format].New(&buf, Options{})
+		for _, r := range records {
+			if err := w.Write(r); err != nil {
+				t.Fatalf("%v: Write() returned error: %v", tt.format, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("%v: Close() returned error: %v", tt.format, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%v export =\n%s\nwant\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestBeancountComponent(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"groceries", "Groceries"},
+		{"FI21 1234 5600 0007 85", "FI21-1234-5600-0007-85"},
+		{"kahvi & pulla", "Kahvi-pulla"},
+		{"äiti", "Äiti"},
+		{"_", ""},
+	}
+	for _, tt := range tests {
+		if got := beancountComponent(tt.in); got != tt.want {
+			t.Errorf("beancountComponent(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonRecord is a record of JSON Lines exports.
+type jsonRecord struct {
+	ID              string      `json:"id"`
+	TransactionDate string      `json:"transaction_date,omitempty"`
+	ValueDate       string      `json:"value_date,omitempty"`
+	PaymentDate     string      `json:"payment_date,omitempty"`
+	Amount          string      `json:"amount"`
+	Currency        string      `json:"currency"`
+	PayeePayer      string      `json:"payee_payer,omitempty"`
+	Account         string      `json:"account,omitempty"`
+	BIC             string      `json:"bic,omitempty"`
+	Transaction     string      `json:"transaction,omitempty"`
+	Reference       string      `json:"reference,omitempty"`
+	PayerReference  string      `json:"payer_reference,omitempty"`
+	Message         string      `json:"message,omitempty"`
+	CardNumber      string      `json:"card_number,omitempty"`
+	TagID           string      `json:"tag_id,omitempty"`
+	Tag             string      `json:"tag,omitempty"`
+	Import          *jsonImport `json:"import,omitempty"`
+}
+
+type jsonImport struct {
+	ID       string `json:"id"`
+	FileName string `json:"file_name,omitempty"`
+	Account  string `json:"account"`
+	Currency string `json:"currency"`
+}
+
+type jsonLinesWriter struct {
+	enc *json.Encoder
+}
+
+func newJSONLines(w io.Writer, _ Options) Writer {
+	return &jsonLinesWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *jsonLinesWriter) Write(r *Record) error {
+	jr := jsonRecord{
+		ID:              r.Id,
+		TransactionDate: date(r.TransactionDate),
+		ValueDate:       date(r.ValueDate),
+		PaymentDate:     date(r.PaymentDate),
+		Amount:          r.Amount,
+		Currency:        r.Currency,
+		PayeePayer:      r.PayeePayer,
+		Account:         r.Account,
+		BIC:             r.Bic,
+		Transaction:     r.Transaction.Transaction,
+		Reference:       r.Reference,
+		PayerReference:  r.PayerReference,
+		Message:         r.Message,
+		CardNumber:      r.CardNumber,
+		TagID:           r.TagId,
+		Tag:             r.Tag,
+	}
+	if imp := r.Import; imp != nil {
+		jr.Import = &jsonImport{ID: imp.ID, FileName: imp.FileName, Account: imp.Account, Currency: imp.Currency}
+	}
+	// Encode writes each record on its own line.
+	return w.enc.Encode(jr)
+}
+
+func (w *jsonLinesWriter) Close() error { return nil }
@@ -0,0 +1,96 @@
+// Package logging configures the structured, leveled logging of the
+// mymonies server and carries the ID of the request being served in
+// contexts, so that everything logged while serving a request can be
+// correlated.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+)
+
+// New returns a logger writing records of level and above to w. Format is
+// text, for logfmt style key=value pairs, or json. Levels are debug, info,
+// warn and error. Records logged with a context of a request include its
+// ID as request_id.
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q, want debug, info, warn or error", level)
+	}
+	opts := &slog.HandlerOptions{Level: l}
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "text":
+		h = slog.NewTextHandler(w, opts)
+	case "json":
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q, want text or json", format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// Discard returns a logger that discards all records.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+type contextKey int
+
+const requestIDKey contextKey = 0
+
+// NewContext returns a copy of ctx with the ID of a request.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the ID of the request of ctx, or empty if ctx is not
+// the context of a request.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// contextHandler adds the request ID of the context to records.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// ServerHooks returns Twirp server hooks logging failed API calls. Internal
+// errors are logged at error level and other errors, such as invalid
+// arguments, at debug level.
+func ServerHooks(logger *slog.Logger) *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			level := slog.LevelDebug
+			switch err.Code() {
+			case twirp.Internal, twirp.Unknown, twirp.DataLoss:
+				level = slog.LevelError
+			}
+			method, _ := twirp.MethodName(ctx)
+			logger.Log(ctx, level, "API call failed", "method", method, "code", err.Code(), "error", err.Msg())
+			return ctx
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "json", "info")
+	if err != nil {
+		t.Fatal("New() returned error:", err)
+	}
+	ctx := NewContext(context.Background(), "abc")
+	logger.DebugContext(ctx, "hidden")
+	logger.With("user", "alice").InfoContext(ctx, "hello", "n", 1)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("log is not one JSON record: %v\n%s", err, buf.Bytes())
+	}
+	for k, want := range map[string]interface{}{
+		"level":      "INFO",
+		"msg":        "hello",
+		"user":       "alice",
+		"n":          1.0,
+		"request_id": "abc",
+	} {
+		if got[k] != want {
+			t.Errorf("%v = %v, want %v", k, got[k], want)
+		}
+	}
+
+	buf.Reset()
+	logger, err = New(&buf, "text", "debug")
+	if err != nil {
+		t.Fatal("New() returned error:", err)
+	}
+	logger.Debug("query")
+	if got := buf.String(); !strings.Contains(got, "level=DEBUG msg=query") || strings.Contains(got, "request_id") {
+		t.Errorf("text log = %q, want debug record without request_id", got)
+	}
+}
+
+func TestNew_invalid(t *testing.T) {
+	if _, err := New(nil, "xml", "info"); err == nil {
+		t.Error("New() with format xml returned no error")
+	}
+	if _, err := New(nil, "text", "verbose"); err == nil {
+		t.Error("New() with level verbose returned no error")
+	}
+}
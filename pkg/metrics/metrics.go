@@ -0,0 +1,171 @@
+// Package metrics implements the Prometheus metrics of the mymonies server:
+// RPC latencies and errors, imported and automatically tagged records,
+// untagged records and the database connection pool.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// Metrics collects the metrics of a server in its own registry. A nil
+// *Metrics discards counts.
+type Metrics struct {
+	registry        *prometheus.Registry
+	rpcDuration     *prometheus.HistogramVec
+	importedRecords prometheus.Counter
+	autoTagged      *prometheus.CounterVec
+}
+
+// New returns metrics of a server with data in db, including the Go
+// runtime and process metrics.
+func New(db database.Storage) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mymonies_rpc_duration_seconds",
+			Help:    "Duration of API calls by method and Twirp error code, ok if successful.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		importedRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "mymonies_imported_records_total",
+			Help: "Number of imported transaction records.",
+		}),
+		autoTagged: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mymonies_auto_tagged_records_total",
+			Help: "Number of records tagged automatically by cause: import or pattern.",
+		}, []string{"cause"}),
+	}
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.rpcDuration,
+		m.importedRecords,
+		m.autoTagged,
+		storageCollector{db},
+	)
+	return m
+}
+
+// Handler returns the HTTP handler serving the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Imported counts imported records, of which tagged were tagged on import.
+func (m *Metrics) Imported(records, tagged int) {
+	if m == nil {
+		return
+	}
+	m.importedRecords.Add(float64(records))
+	m.AutoTagged(database.CauseImport, tagged)
+}
+
+// AutoTagged counts records tagged automatically.
+func (m *Metrics) AutoTagged(cause database.Cause, records int) {
+	if m == nil {
+		return
+	}
+	m.autoTagged.WithLabelValues(string(cause)).Add(float64(records))
+}
+
+type contextKey int
+
+const (
+	startKey contextKey = iota
+	codeKey
+)
+
+// ServerHooks returns Twirp server hooks observing the duration and error
+// code of each call.
+func (m *Metrics) ServerHooks() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			return context.WithValue(ctx, startKey, time.Now()), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			return context.WithValue(ctx, codeKey, string(err.Code()))
+		},
+		ResponseSent: func(ctx context.Context) {
+			start, ok := ctx.Value(startKey).(time.Time)
+			if !ok {
+				return
+			}
+			method, ok := twirp.MethodName(ctx)
+			if !ok {
+				method = "unknown"
+			}
+			code, ok := ctx.Value(codeKey).(string)
+			if !ok {
+				code = "ok"
+			}
+			m.rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+		},
+	}
+}
+
+var (
+	untaggedDesc = prometheus.NewDesc("mymonies_untagged_records",
+		"Number of records without a tag by household ID.", []string{"household"}, nil)
+	maxOpenDesc = prometheus.NewDesc("mymonies_db_max_open_connections",
+		"Maximum number of open database connections, 0 if unlimited.", nil, nil)
+	openDesc = prometheus.NewDesc("mymonies_db_open_connections",
+		"Number of open database connections, in use and idle.", nil, nil)
+	inUseDesc = prometheus.NewDesc("mymonies_db_in_use_connections",
+		"Number of database connections in use.", nil, nil)
+	idleDesc = prometheus.NewDesc("mymonies_db_idle_connections",
+		"Number of idle database connections.", nil, nil)
+	waitCountDesc = prometheus.NewDesc("mymonies_db_wait_count_total",
+		"Number of times waited for a database connection.", nil, nil)
+	waitDurationDesc = prometheus.NewDesc("mymonies_db_wait_duration_seconds_total",
+		"Time blocked waiting for a database connection.", nil, nil)
+)
+
+// storageCollector collects the number of untagged records and, for SQL
+// databases, the connection pool statistics when metrics are scraped.
+type storageCollector struct {
+	db database.Storage
+}
+
+// pool is implemented by storages with a connection pool, i.e. *sqlx.DB.
+type pool interface {
+	Stats() sql.DBStats
+}
+
+func (c storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- untaggedDesc
+	if _, ok := c.db.(pool); ok {
+		for _, d := range []*prometheus.Desc{maxOpenDesc, openDesc, inUseDesc, idleDesc, waitCountDesc, waitDurationDesc} {
+			ch <- d
+		}
+	}
+}
+
+func (c storageCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.db.CountUntagged()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(untaggedDesc, err)
+	}
+	for household, n := range counts {
+		ch <- prometheus.MustNewConstMetric(untaggedDesc, prometheus.GaugeValue, float64(n), household)
+	}
+	if p, ok := c.db.(pool); ok {
+		s := p.Stats()
+		ch <- prometheus.MustNewConstMetric(maxOpenDesc, prometheus.GaugeValue, float64(s.MaxOpenConnections))
+		ch <- prometheus.MustNewConstMetric(openDesc, prometheus.GaugeValue, float64(s.OpenConnections))
+		ch <- prometheus.MustNewConstMetric(inUseDesc, prometheus.GaugeValue, float64(s.InUse))
+		ch <- prometheus.MustNewConstMetric(idleDesc, prometheus.GaugeValue, float64(s.Idle))
+		ch <- prometheus.MustNewConstMetric(waitCountDesc, prometheus.CounterValue, float64(s.WaitCount))
+		ch <- prometheus.MustNewConstMetric(waitDurationDesc, prometheus.CounterValue, s.WaitDuration.Seconds())
+	}
+}
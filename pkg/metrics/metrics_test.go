@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func TestMetrics(t *testing.T) {
+	db := database.NewMemory()
+	_, err := db.AddImport(database.DefaultHousehold, "", &pb.AddImportReq{
+		Account:  "FI1234",
+		Currency: "EUR",
+		Transactions: []*pb.Transaction{
+			{Amount: "-1.00", Currency: "EUR"},
+			{Amount: "-2.00", Currency: "EUR"},
+		},
+	})
+	if err != nil {
+		t.Fatal("db.AddImport() returned error:", err)
+	}
+	m := New(db)
+	m.Imported(2, 1)
+	m.AutoTagged(database.CausePattern, 3)
+
+	hooks := m.ServerHooks()
+	call := func(method string, err twirp.Error) {
+		ctx := ctxsetters.WithMethodName(context.Background(), method)
+		ctx, _ = hooks.RequestReceived(ctx)
+		if err != nil {
+			ctx = hooks.Error(ctx, err)
+		}
+		hooks.ResponseSent(ctx)
+	}
+	call("ListTags", nil)
+	call("AddTag", twirp.RequiredArgumentError("name"))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(w.Body)
+	for _, want := range []string{
+		`mymonies_rpc_duration_seconds_count{code="ok",method="ListTags"} 1`,
+		`mymonies_rpc_duration_seconds_count{code="invalid_argument",method="AddTag"} 1`,
+		`mymonies_imported_records_total 2`,
+		`mymonies_auto_tagged_records_total{cause="import"} 1`,
+		`mymonies_auto_tagged_records_total{cause="pattern"} 3`,
+		`mymonies_untagged_records{household="1"} 2`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetrics_nil(t *testing.T) {
+	var m *Metrics
+	m.Imported(1, 1)
+	m.AutoTagged(database.CausePattern, 1)
+}
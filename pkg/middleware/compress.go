@@ -0,0 +1,206 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings of compressed responses.
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+)
+
+// minCompressSize is the smallest response of known length worth
+// compressing.
+const minCompressSize = 1024
+
+// NegotiateEncoding returns the first of the content codings the client
+// accepts by the Accept-Encoding header of r, or "" for no compression.
+func NegotiateEncoding(r *http.Request, codings ...string) string {
+	accepted := map[string]bool{}
+	wildcard := false
+	for _, h := range r.Header.Values("Accept-Encoding") {
+		for _, part := range strings.Split(h, ",") {
+			coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			ok := true
+			if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+				v, err := strconv.ParseFloat(q, 64)
+				ok = err == nil && v > 0
+			}
+			if coding == "*" {
+				wildcard = ok
+				continue
+			}
+			if _, seen := accepted[coding]; !seen {
+				accepted[coding] = ok
+			}
+		}
+	}
+	for _, coding := range codings {
+		if ok, seen := accepted[coding]; ok || !seen && wildcard {
+			return coding
+		}
+	}
+	return ""
+}
+
+// Compressible reports whether responses of contentType benefit from
+// compression, e.g. text, JSON and protobuf but not images or archives.
+func Compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		// Events are small and must reach the client as soon as they
+		// are flushed.
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	}
+	for _, s := range []string{"json", "javascript", "xml", "protobuf"} {
+		if strings.Contains(mediaType, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Compress compresses responses with brotli or gzip, as accepted by the
+// client. Responses that are already encoded, e.g. precompressed static
+// assets, short or not compressible are sent as they are. A strong ETag of
+// a compressed response is made weak, as the compressed bytes are not the
+// ones the ETag was computed of.
+func Compress() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			coding := NegotiateEncoding(r, Brotli, Gzip)
+			if coding == "" || r.Method == http.MethodHead {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, coding: coding}
+			defer cw.Close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// compressWriter compresses the response if it is compressible, decided
+// when the header is written.
+type compressWriter struct {
+	http.ResponseWriter
+	coding      string
+	wroteHeader bool
+	encoder     io.WriteCloser // nil if the response is not compressed
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.wroteHeader = true
+	header := w.Header()
+	contentType := header.Get("Content-Type")
+	if !Compressible(contentType) {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if !strings.Contains(header.Get("Vary"), "Accept-Encoding") {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	short := err == nil && length < minCompressSize
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified ||
+		header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" || short {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	header.Del("Content-Length")
+	header.Del("Accept-Ranges")
+	header.Set("Content-Encoding", w.coding)
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+	w.encoder = newEncoder(w.coding, w.ResponseWriter)
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.encoder.Write(b)
+}
+
+// Flush flushes the compressed data written so far to the client.
+func (w *compressWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped ResponseWriter for http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close finishes the compressed stream.
+func (w *compressWriter) Close() error {
+	if w.encoder == nil {
+		return nil
+	}
+	err := w.encoder.Close()
+	releaseEncoder(w.coding, w.encoder)
+	w.encoder = nil
+	return err
+}
+
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, 4) }}
+)
+
+// newEncoder returns a pooled compressor of coding writing to w.
+func newEncoder(coding string, w io.Writer) io.WriteCloser {
+	if coding == Brotli {
+		bw := brotliWriters.Get().(*brotli.Writer)
+		bw.Reset(w)
+		return bw
+	}
+	gw := gzipWriters.Get().(*gzip.Writer)
+	gw.Reset(w)
+	return gw
+}
+
+// releaseEncoder returns a closed encoder of newEncoder to its pool.
+func releaseEncoder(coding string, enc io.WriteCloser) {
+	if coding == Brotli {
+		brotliWriters.Put(enc)
+		return
+	}
+	gzipWriters.Put(enc)
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"br;q=0, gzip", "gzip"},
+		{"br;q=0.5", "br"},
+		{"*", "br"},
+		{"*, br;q=0", "gzip"},
+		{"identity", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		if got := NegotiateEncoding(r, Brotli, Gzip); got != tt.want {
+			t.Errorf("NegotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	long := strings.Repeat(`{"amount":"12.34"}`, 100)
+	h := Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("ETag", `"abc"`)
+			w.Write([]byte(long))
+		case "/short":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", "2")
+			w.Write([]byte("{}"))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(long))
+		case "/encoded":
+			w.Header().Set("Content-Type", "text/css")
+			w.Header().Set("Content-Encoding", "br")
+			w.Write([]byte(long))
+		}
+	}))
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/json", "gzip, br")
+	if w.Header().Get("Content-Encoding") != "br" || w.Header().Get("ETag") != `W/"abc"` || w.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatalf("header = %v, want brotli with weak ETag", w.Header())
+	}
+	if b, err := ioutil.ReadAll(brotli.NewReader(w.Body)); err != nil || string(b) != long {
+		t.Errorf("decoded brotli body = %q, %v", b, err)
+	}
+
+	w = get("/json", "gzip")
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadAll(zr); err != nil || string(b) != long {
+		t.Errorf("decoded gzip body = %q, %v", b, err)
+	}
+
+	for path, body := range map[string]string{"/short": "{}", "/image": long, "/encoded": long} {
+		w := get(path, "br")
+		if got := w.Header().Get("Content-Encoding"); path != "/encoded" && got != "" {
+			t.Errorf("%v Content-Encoding = %q, want none", path, got)
+		}
+		if w.Body.String() != body {
+			t.Errorf("%v body = %q, want it unchanged", path, w.Body.String())
+		}
+	}
+}
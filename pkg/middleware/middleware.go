@@ -2,10 +2,15 @@
 package middleware
 
 import (
-	"io"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"time"
 
-	"github.com/gorilla/handlers"
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
 )
 
 // Middleware wraps a Handler with some pre- and/or post actions.
@@ -21,9 +26,130 @@ func SetResponseHeader(header, value string) Middleware {
 	}
 }
 
-// RequestLogger logs requests to out in Apache Common Log Format (CLF).
-func RequestLogger(out io.Writer) Middleware {
+// RequestIDHeader is the request and response header of request IDs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID gives each request an ID in its context, see logging.RequestID,
+// and in the X-Request-ID response header. The ID of the request header is
+// kept if it is valid, e.g. set by a reverse proxy, so that its logs can be
+// correlated with the logs of the proxy.
+func RequestID() Middleware {
 	return func(h http.Handler) http.Handler {
-		return handlers.LoggingHandler(out, h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			h.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// validRequestID reports whether id is safe to log as is: up to 64 letters,
+// digits, dashes, dots and underscores.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '.', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// RequestLogger logs each request to logger when it has been served, with
+// the status code, response size and duration. Server errors are logged at
+// error level and other requests at info level.
+func RequestLogger(logger *slog.Logger) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(rw, r)
+			level := slog.LevelInfo
+			if rw.status >= 500 {
+				level = slog.LevelError
+			}
+			logger.Log(r.Context(), level, "request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", rw.status,
+				"bytes", rw.size,
+				"duration", time.Since(start),
+				"remote", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
+			)
+		})
+	}
+}
+
+// responseRecorder records the status code and size of a response.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+// Flush flushes the response, e.g. of server-sent events.
+func (w *responseRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped ResponseWriter for http.ResponseController.
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Authenticator authenticates requests. Authenticate returns the request to
+// serve, e.g. with the user in its context, or an error if the request is
+// not authenticated.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*http.Request, error)
+}
+
+// RequireAuthentication rejects requests a does not authenticate with a Twirp
+// error response. Errors that are not twirp.Error are reported as
+// unauthenticated.
+func RequireAuthentication(a Authenticator) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r, err := a.Authenticate(r)
+			if err != nil {
+				twerr, ok := err.(twirp.Error)
+				if !ok {
+					twerr = twirp.NewError(twirp.Unauthenticated, err.Error())
+				}
+				writeTwirpError(w, twirp.ServerHTTPStatusFromErrorCode(twerr.Code()), twerr)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
 	}
 }
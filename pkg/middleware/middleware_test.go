@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "text", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requestID string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = logging.RequestID(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("hello"))
+	})
+	h2 := RequestID()(RequestLogger(logger)(h))
+
+	w := httptest.NewRecorder()
+	h2.ServeHTTP(w, httptest.NewRequest("GET", "/path", nil))
+	if len(requestID) != 16 || w.Header().Get(RequestIDHeader) != requestID {
+		t.Errorf("request ID = %q, header %q, want same 16 hex digits", requestID, w.Header().Get(RequestIDHeader))
+	}
+	got := buf.String()
+	for _, want := range []string{"msg=request", "method=GET", "path=/path", "status=418", "bytes=5", "request_id=" + requestID} {
+		if !strings.Contains(got, want) {
+			t.Errorf("log %q does not contain %q", got, want)
+		}
+	}
+
+	for id, keep := range map[string]bool{
+		"proxy-123.a_b":         true,
+		"":                      false,
+		"with space":            false,
+		"x\nlevel=ERROR":        false,
+		strings.Repeat("a", 65): false,
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set(RequestIDHeader, id)
+		h2.ServeHTTP(httptest.NewRecorder(), r)
+		if (requestID == id) != keep {
+			t.Errorf("request ID of header %q = %q, want kept %v", id, requestID, keep)
+		}
+	}
+}
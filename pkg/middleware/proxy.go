@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies are the addresses of reverse proxies whose forwarding
+// headers are trusted.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses IP addresses and CIDR ranges, e.g. 127.0.0.1
+// and 10.0.0.0/8.
+func ParseTrustedProxies(addrs []string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, s := range addrs {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %v", s, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %v", s, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+// Contains reports whether addr is a trusted proxy.
+func (p TrustedProxies) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProxyHeaders uses the X-Forwarded-For and X-Forwarded-Proto headers of
+// requests from trusted proxies. The client address replaces
+// r.RemoteAddr, e.g. in the request log, and r.URL.Scheme is set to https
+// for requests the client made with HTTPS, e.g. for secure cookies. The
+// client is the last address of X-Forwarded-For that is not a trusted
+// proxy, so that clients can't spoof it. Headers of other requests are
+// ignored.
+func ProxyHeaders(trusted TrustedProxies) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(trusted) == 0 || !trusted.Contains(remoteIP(r)) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			r2 := r.Clone(r.Context())
+			if client, ok := forwardedFor(r.Header.Values("X-Forwarded-For"), trusted); ok {
+				r2.RemoteAddr = net.JoinHostPort(client.String(), "0")
+			}
+			proto := r.Header.Get("X-Forwarded-Proto")
+			// The first proxy is the one the client connected to.
+			if i := strings.IndexByte(proto, ','); i >= 0 {
+				proto = proto[:i]
+			}
+			switch strings.ToLower(strings.TrimSpace(proto)) {
+			case "https":
+				r2.URL.Scheme = "https"
+			case "http":
+				r2.URL.Scheme = "http"
+			}
+			h.ServeHTTP(w, r2)
+		})
+	}
+}
+
+// remoteIP returns the IP address of the remote end of the connection of
+// r, or the zero Addr if it is not an IP address.
+func remoteIP(r *http.Request) netip.Addr {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, _ := netip.ParseAddr(host)
+	return addr
+}
+
+// forwardedFor returns the client address of X-Forwarded-For headers: the
+// last address that is not a trusted proxy, or the first address if all
+// are trusted.
+func forwardedFor(headers []string, trusted TrustedProxies) (netip.Addr, bool) {
+	var addrs []string
+	for _, h := range headers {
+		addrs = append(addrs, strings.Split(h, ",")...)
+	}
+	var client netip.Addr
+	for i := len(addrs) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(addrs[i]))
+		if err != nil {
+			// Addresses before a malformed one can't be trusted.
+			break
+		}
+		client = addr.Unmap()
+		if !trusted.Contains(client) {
+			break
+		}
+	}
+	return client, client.IsValid()
+}
+
+// RedirectHTTPS returns a handler redirecting requests to the same URL with
+// HTTPS on httpsPort, e.g. to serve on port 80 next to HTTPS on port 443.
+func RedirectHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = strings.Trim(r.Host, "[]")
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		u := *r.URL
+		u.Scheme = "https"
+		u.Host = host
+		http.Redirect(w, r, u.String(), http.StatusPermanentRedirect)
+	})
+}
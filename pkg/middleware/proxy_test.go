@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProxyHeaders(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"127.0.0.1", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatal("ParseTrustedProxies() returned error:", err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		proto      string
+		wantRemote string
+		wantScheme string
+	}{
+		{"no proxy", "192.0.2.1:1234", nil, "", "192.0.2.1:1234", ""},
+		{"untrusted proxy", "192.0.2.1:1234", []string{"198.51.100.1"}, "https", "192.0.2.1:1234", ""},
+		{"trusted proxy", "127.0.0.1:1234", []string{"198.51.100.1"}, "https", "198.51.100.1:0", "https"},
+		{"chain of proxies", "127.0.0.1:1234", []string{"198.51.100.1, 10.1.2.3"}, "http", "198.51.100.1:0", "http"},
+		{"spoofed by client", "127.0.0.1:1234", []string{"203.0.113.9, 198.51.100.1"}, "https,http", "198.51.100.1:0", "https"},
+		{"several headers", "127.0.0.1:1234", []string{"203.0.113.9", "198.51.100.1"}, "", "198.51.100.1:0", ""},
+		{"only proxies", "127.0.0.1:1234", []string{"10.0.0.1"}, "", "10.0.0.1:0", ""},
+		{"malformed", "127.0.0.1:1234", []string{"unknown"}, "", "127.0.0.1:1234", ""},
+		{"IPv6", "[::ffff:127.0.0.1]:1234", []string{"2001:db8::1"}, "", "[2001:db8::1]:0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			h := ProxyHeaders(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+			}))
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, f := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+			if tt.proto != "" {
+				r.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got.RemoteAddr != tt.wantRemote || got.URL.Scheme != tt.wantScheme {
+				t.Errorf("RemoteAddr, Scheme = %q, %q; want %q, %q", got.RemoteAddr, got.URL.Scheme, tt.wantRemote, tt.wantScheme)
+			}
+		})
+	}
+
+	if _, err := ParseTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("ParseTrustedProxies() of invalid CIDR returned no error")
+	}
+}
+
+func TestRedirectHTTPS(t *testing.T) {
+	tests := []struct {
+		host, port, want string
+	}{
+		{"example.com", "443", "https://example.com/a?b=c"},
+		{"example.com:80", "443", "https://example.com/a?b=c"},
+		{"example.com:8080", "8443", "https://example.com:8443/a?b=c"},
+		{"[::1]:80", "443", "https://[::1]/a?b=c"},
+		{"[::1]", "8443", "https://[::1]:8443/a?b=c"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/a?b=c", nil)
+		r.Host = tt.host
+		w := httptest.NewRecorder()
+		RedirectHTTPS(tt.port).ServeHTTP(w, r)
+		if got := w.Header().Get("Location"); w.Code != http.StatusPermanentRedirect || got != tt.want {
+			t.Errorf("redirect of %v to port %v = %v %v, want 308 %v", tt.host, tt.port, w.Code, got, tt.want)
+		}
+	}
+}
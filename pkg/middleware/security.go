@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/twitchtv/twirp"
+)
+
+// DefaultContentSecurityPolicy allows the web interface to load its own
+// resources and Bootstrap and Vue from their CDNs. Vue compiles the
+// templates of the page at runtime, which requires 'unsafe-eval', and the
+// templates have inline styles.
+const DefaultContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-eval' https://cdn.jsdelivr.net; " +
+	"style-src 'self' 'unsafe-inline' https://maxcdn.bootstrapcdn.com; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"object-src 'none'; " +
+	"base-uri 'self'; " +
+	"form-action 'self'; " +
+	"frame-ancestors 'none'"
+
+// hstsMaxAge is the time browsers remember to use only HTTPS, one year.
+const hstsMaxAge = "max-age=31536000"
+
+// SecurityHeaders sets headers restricting what browsers do with the
+// responses: the Content-Security-Policy csp, if not empty, no MIME type
+// sniffing, no framing and no referrer to other sites. Responses to HTTPS
+// requests, also through a trusted reverse proxy, have
+// Strict-Transport-Security so that browsers don't use plain HTTP again.
+func SecurityHeaders(csp string) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			if csp != "" {
+				header.Set("Content-Security-Policy", csp)
+			}
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("X-Frame-Options", "DENY")
+			header.Set("Referrer-Policy", "same-origin")
+			header.Set("Cross-Origin-Opener-Policy", "same-origin")
+			if r.TLS != nil || r.URL.Scheme == "https" {
+				header.Set("Strict-Transport-Security", hstsMaxAge)
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MaxRequestBody rejects requests with a body larger than limit bytes with
+// a Twirp error response and status 413, before the body is read into
+// memory by the handler, e.g. a giant AddImport.
+func MaxRequestBody(limit int64) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tooLarge := twirp.NewError(twirp.ResourceExhausted, fmt.Sprintf("request body is larger than %d bytes", limit))
+			if r.ContentLength > limit {
+				writeTwirpError(w, http.StatusRequestEntityTooLarge, tooLarge)
+				return
+			}
+			// The body of unknown length is read here, where exceeding
+			// the limit can be told apart from other read errors.
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+			var maxBytesErr *http.MaxBytesError
+			switch {
+			case errors.As(err, &maxBytesErr):
+				writeTwirpError(w, http.StatusRequestEntityTooLarge, tooLarge)
+				return
+			case err != nil:
+				twerr := twirp.NewError(twirp.InvalidArgument, "failed to read request body: "+err.Error())
+				writeTwirpError(w, http.StatusBadRequest, twerr)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeTwirpError writes twerr as a Twirp error response with status.
+func writeTwirpError(w http.ResponseWriter, status int, twerr twirp.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"code": string(twerr.Code()),
+		"msg":  twerr.Msg(),
+	})
+}
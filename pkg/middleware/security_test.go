@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	h := SecurityHeaders(DefaultContentSecurityPolicy)(http.NotFoundHandler())
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://example.com/", nil))
+	for name, want := range map[string]string{
+		"Content-Security-Policy": DefaultContentSecurityPolicy,
+		"X-Content-Type-Options":  "nosniff",
+		"X-Frame-Options":         "DENY",
+	} {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("%v = %q, want %q", name, got, want)
+		}
+	}
+	if got := w.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("Strict-Transport-Security of HTTP = %q, want none", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "https://example.com/", nil))
+	if got := w.Header().Get("Strict-Transport-Security"); got != hstsMaxAge {
+		t.Errorf("Strict-Transport-Security of HTTPS = %q, want %q", got, hstsMaxAge)
+	}
+}
+
+func TestMaxRequestBody(t *testing.T) {
+	h := MaxRequestBody(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		w.Write(b)
+	}))
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{"small", "0123456789", 10, http.StatusOK},
+		{"large", "0123456789a", 11, http.StatusRequestEntityTooLarge},
+		{"large chunked", "0123456789a", -1, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/twirp/x", strings.NewReader(tt.body))
+		r.ContentLength = tt.contentLength
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%v: status = %v, want %v", tt.name, w.Code, tt.wantStatus)
+		}
+		if w.Code == http.StatusOK && w.Body.String() != tt.body {
+			t.Errorf("%v: body = %q, want %q", tt.name, w.Body.String(), tt.body)
+		}
+		if w.Code != http.StatusOK && !strings.Contains(w.Body.String(), `"code":"resource_exhausted"`) {
+			t.Errorf("%v: body = %q, want Twirp error", tt.name, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Package money implements exact decimal amounts of money.
+//
+// Amounts are stored as an integer number of units and a decimal scale so
+// that sums of amounts never accumulate floating point rounding errors.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxScale is the largest supported number of decimals.
+const maxScale = 9
+
+// ErrRange is returned if the result of an operation does not fit in an
+// Amount.
+var ErrRange = errors.New("money: amount out of range")
+
+// Amount is an exact decimal amount. The value of the amount is
+// units * 10^-scale, e.g. -12.50 is 1250 units with scale 2.
+// The zero value is a valid zero amount.
+type Amount struct {
+	units int64
+	scale int
+}
+
+// New returns the amount units * 10^-scale. For example New(1250, 2) is 12.50.
+func New(units int64, scale int) Amount {
+	if scale < 0 || scale > maxScale {
+		panic(fmt.Sprintf("money: scale %d out of range", scale))
+	}
+	if units == math.MinInt64 {
+		panic("money: units out of range")
+	}
+	return Amount{units: units, scale: scale}
+}
+
+// Parse parses a decimal amount such as "12", "-12.5" or "+0.05".
+// The decimal separator must be a period and no thousands separators
+// are allowed.
+func Parse(s string) (Amount, error) {
+	orig := s
+	if s == "" {
+		return Amount{}, errors.New("money: empty amount")
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !digits(intPart) || !digits(fracPart) {
+		return Amount{}, fmt.Errorf("money: invalid amount %q", orig)
+	}
+	if len(fracPart) > maxScale {
+		return Amount{}, fmt.Errorf("money: too many decimals in %q", orig)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Amount{}, fmt.Errorf("money: amount %q out of range", orig)
+	}
+	if neg {
+		units = -units
+	}
+	return Amount{units: units, scale: len(fracPart)}, nil
+}
+
+// MustParse is like Parse but panics if s is not a valid amount.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Units returns the integer number of 10^-Scale() units in a.
+func (a Amount) Units() int64 { return a.units }
+
+// Scale returns the number of decimals in a.
+func (a Amount) Scale() int { return a.scale }
+
+// String formats a with exactly Scale() decimals, e.g. "-12.50".
+func (a Amount) String() string {
+	units := a.units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+	s := strconv.FormatUint(abs(units), 10)
+	if a.scale == 0 {
+		return sign + s
+	}
+	if len(s) <= a.scale {
+		s = strings.Repeat("0", a.scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-a.scale] + "." + s[len(s)-a.scale:]
+}
+
+func abs(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+// WithMinScale returns a with at least scale decimals. Amounts with more
+// decimals, or too large to have scale decimals, are returned unchanged, so
+// no precision is ever lost.
+func (a Amount) WithMinScale(scale int) Amount {
+	if scale <= a.scale {
+		return a
+	}
+	if r, err := a.rescale(scale); err == nil {
+		return r
+	}
+	return a
+}
+
+// rescale returns a with a larger scale, or ErrRange if it does not fit.
+func (a Amount) rescale(scale int) (Amount, error) {
+	units := a.units
+	for i := a.scale; i < scale; i++ {
+		if units > math.MaxInt64/10 || units < -math.MaxInt64/10 {
+			return Amount{}, ErrRange
+		}
+		units *= 10
+	}
+	return Amount{units: units, scale: scale}, nil
+}
+
+// align returns a and b rescaled to the same scale.
+func align(a, b Amount) (Amount, Amount, error) {
+	var err error
+	switch {
+	case a.scale < b.scale:
+		a, err = a.rescale(b.scale)
+	case b.scale < a.scale:
+		b, err = b.rescale(a.scale)
+	}
+	return a, b, err
+}
+
+// Add returns the exact sum a+b, or ErrRange if it does not fit in an
+// Amount.
+func (a Amount) Add(b Amount) (Amount, error) {
+	a, b, err := align(a, b)
+	if err != nil {
+		return Amount{}, err
+	}
+	// The units of amounts are kept above math.MinInt64 so that Neg
+	// never overflows.
+	if b.units > 0 && a.units > math.MaxInt64-b.units ||
+		b.units < 0 && a.units < -math.MaxInt64-b.units {
+		return Amount{}, ErrRange
+	}
+	return Amount{units: a.units + b.units, scale: a.scale}, nil
+}
+
+// Sub returns the exact difference a-b, or ErrRange if it does not fit in
+// an Amount.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	return a.Add(b.Neg())
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{units: -a.units, scale: a.scale}
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of a.
+func (a Amount) Sign() int {
+	switch {
+	case a.units < 0:
+		return -1
+	case a.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether a is zero.
+func (a Amount) IsZero() bool { return a.units == 0 }
+
+// Cmp compares a and b numerically and returns -1, 0 or +1.
+// Amounts with different scales compare equal if their values are
+// equal, e.g. 1.5 and 1.50.
+func (a Amount) Cmp(b Amount) int {
+	return a.Rat().Cmp(b.Rat())
+}
+
+// Sum returns the exact sum of amounts, or ErrRange if it does not fit in
+// an Amount.
+func Sum(amounts ...Amount) (Amount, error) {
+	var total Amount
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Amount{}, err
+		}
+	}
+	return total, nil
+}
+
+// Rat returns a as an exact rational number.
+func (a Amount) Rat() *big.Rat {
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(a.units), den)
+}
+
+// FromRat returns r rounded to scale decimals. Halves are rounded away
+// from zero. FromRat returns an error if the result does not fit in an
+// Amount.
+func FromRat(r *big.Rat, scale int) (Amount, error) {
+	if scale < 0 || scale > maxScale {
+		return Amount{}, fmt.Errorf("money: scale %d out of range", scale)
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow))
+	num, den := scaled.Num(), scaled.Denom()
+
+	// Round half away from zero: (2*|num| + den) / (2*den)
+	q := new(big.Int).Abs(num)
+	q.Mul(q, big.NewInt(2)).Add(q, den)
+	q.Quo(q, new(big.Int).Mul(den, big.NewInt(2)))
+	if num.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() || q.Int64() == math.MinInt64 {
+		return Amount{}, ErrRange
+	}
+	return Amount{units: q.Int64(), scale: scale}, nil
+}
+
+// Scan implements sql.Scanner for PostgreSQL numeric values.
+func (a *Amount) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		*a, err = Parse(string(v))
+	case string:
+		*a, err = Parse(v)
+	case int64:
+		if v == math.MinInt64 {
+			return ErrRange
+		}
+		*a = Amount{units: v}
+	case nil:
+		*a = Amount{}
+	default:
+		err = fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+	return err
+}
+
+// Value implements driver.Valuer. Amounts are stored in the database as
+// decimal strings to avoid conversion through floating point.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
@@ -0,0 +1,153 @@
+package money
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: "10", want: Amount{units: 10}},
+		{in: "10.00", want: Amount{units: 1000, scale: 2}},
+		{in: "-30.05", want: Amount{units: -3005, scale: 2}},
+		{in: "+0.5", want: Amount{units: 5, scale: 1}},
+		{in: ".25", want: Amount{units: 25, scale: 2}},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "10,00", wantErr: true},
+		{in: "1 000.00", wantErr: true},
+		{in: "10.a", wantErr: true},
+		{in: "1.0000000001", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	tests := []struct {
+		a    Amount
+		want string
+	}{
+		{Amount{}, "0"},
+		{New(1250, 2), "12.50"},
+		{New(-5, 2), "-0.05"},
+		{New(5, 3), "0.005"},
+		{New(-1234, 0), "-1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.a.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	// 0.1 + 0.2 is the classic floating point counterexample.
+	got, err := Sum(MustParse("0.1"), MustParse("0.2"), MustParse("-0.30"))
+	if err != nil || !got.IsZero() {
+		t.Errorf("Sum() = %v, %v; want 0", got, err)
+	}
+	got, err = Sum(MustParse("13.37"), MustParse("14.29"), MustParse("-4.99"))
+	if want := MustParse("22.67"); err != nil || got.Cmp(want) != 0 {
+		t.Errorf("Sum() = %v, %v; want %v", got, err, want)
+	}
+}
+
+func TestAmount_Add_overflow(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"9223372036854775807", "1"},
+		{"-9223372036854775807", "-1"},
+		// Rescaling to 9 decimals overflows before adding.
+		{"10000000000", "0.000000001"},
+		{"-10000000000", "-0.000000001"},
+	}
+	for _, tt := range tests {
+		if got, err := MustParse(tt.a).Add(MustParse(tt.b)); err != ErrRange {
+			t.Errorf("%v + %v = %v, %v; want %v", tt.a, tt.b, got, err, ErrRange)
+		}
+	}
+	if got, err := MustParse("9223372036854775806").Add(MustParse("1")); err != nil || got.String() != "9223372036854775807" {
+		t.Errorf("Add() of largest amount = %v, %v", got, err)
+	}
+	if got := MustParse("10000000000").Cmp(MustParse("0.000000001")); got != 1 {
+		t.Errorf("Cmp() of amounts not fitting one scale = %v, want 1", got)
+	}
+	if got := MustParse("10000000000").WithMinScale(9).String(); got != "10000000000" {
+		t.Errorf("WithMinScale() of large amount = %v, want it unchanged", got)
+	}
+}
+
+func TestAmount_WithMinScale(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		want  string
+	}{
+		{"10", 2, "10.00"},
+		{"10.5", 2, "10.50"},
+		{"10.505", 2, "10.505"},
+		{"-3", 0, "-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := MustParse(tt.in).WithMinScale(tt.scale).String(); got != tt.want {
+				t.Errorf("WithMinScale(%d) = %v, want %v", tt.scale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Scan(t *testing.T) {
+	var a Amount
+	if err := a.Scan([]byte("-12.50")); err != nil {
+		t.Fatal(err)
+	}
+	if a != New(-1250, 2) {
+		t.Errorf("Scan() = %#v", a)
+	}
+	if err := a.Scan(1.5); err == nil {
+		t.Errorf("Scan(float64) returned no error")
+	}
+}
+
+func TestFromRat(t *testing.T) {
+	tests := []struct {
+		a, mul, div string
+		scale       int
+		want        string
+	}{
+		{"100.00", "1", "1.0921", 2, "91.57"},
+		{"-100.00", "1", "1.0921", 2, "-91.57"},
+		{"0.05", "1", "2", 2, "0.03"},
+		{"-0.05", "1", "2", 2, "-0.03"},
+		{"1000", "158.13", "1", 0, "158130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"*"+tt.mul+"/"+tt.div, func(t *testing.T) {
+			r := MustParse(tt.a).Rat()
+			r.Mul(r, MustParse(tt.mul).Rat())
+			r.Quo(r, MustParse(tt.div).Rat())
+			got, err := FromRat(r, tt.scale)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("FromRat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
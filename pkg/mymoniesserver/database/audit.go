@@ -0,0 +1,322 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrUndoConflict is returned when an operation can't be undone, because it
+// has already been undone or its records have been tagged again since.
+var ErrUndoConflict = errors.New("operation was already undone or its records were changed since")
+
+// ErrVersionConflict is returned when a record has been changed since the
+// version given to UpdateTag.
+var ErrVersionConflict = errors.New("record was changed since it was read")
+
+// Cause is the cause of a tag operation.
+type Cause string
+
+// Causes of tag operations.
+const (
+	// CauseManual is a tag set by a user, see UpdateTag.
+	CauseManual Cause = "manual"
+	// CauseBulk is a tag set by a user to many records, see BulkUpdateTag.
+	CauseBulk Cause = "bulk"
+	// CausePattern is a tag set by applying a new pattern, see AddPattern.
+	CausePattern Cause = "pattern"
+	// CauseImport is a tag of imported records, see AddImport.
+	CauseImport Cause = "import"
+	// CauseUndo is a tag restored by undoing an operation, see Undo.
+	CauseUndo Cause = "undo"
+)
+
+// TagChange is a change of the tag of a record in the audit log. The changes
+// of one UpdateTag, BulkUpdateTag, AddPattern, AddImport or Undo call belong
+// to the same operation.
+type TagChange struct {
+	OperationID string
+	RecordID    string
+	OldTagID    string // Empty if the record had no tag.
+	NewTagID    string // Empty if the tag was removed.
+	Username    string // Empty if authentication was disabled.
+	Cause       Cause
+	PatternID   string // Pattern of CausePattern operations.
+	ImportID    string // Import of CauseImport operations.
+	UndoOf      string // Undone operation of CauseUndo operations.
+	Created     time.Time
+	Undone      bool // Whether the operation has been undone.
+}
+
+// tagOperation is the cause of the tag changes of an operation.
+type tagOperation struct {
+	household string
+	userID    string
+	cause     Cause
+	patternID string
+	importID  string
+	undoOf    string
+}
+
+// nullID returns id as a nullable integer column value.
+func nullID(id string) sql.NullInt64 {
+	n, err := strconv.ParseInt(id, 10, 64)
+	return sql.NullInt64{Int64: n, Valid: err == nil}
+}
+
+// tagNumber returns a tag ID as a number for comparing with COALESCE(tag_id,
+// 0), which works the same in PostgreSQL and SQLite.
+func tagNumber(id string) int64 {
+	return nullID(id).Int64
+}
+
+// addOperation stores a tag operation and returns its ID.
+func addOperation(txn *loggedTx, op tagOperation) (string, error) {
+	var id string
+	err := txn.QueryRow(txn.Rebind(`INSERT INTO tag_operations
+		(household_id, user_id, cause, pattern_id, import_id, undo_of)
+		VALUES (?, ?, ?, ?, ?, ?) RETURNING CAST(id AS text)`),
+		op.household, nullID(op.userID), string(op.cause),
+		nullID(op.patternID), nullID(op.importID), nullID(op.undoOf)).Scan(&id)
+	return id, err
+}
+
+// anyVersion is the version of setTag for records changed regardless of
+// their version.
+const anyVersion = -1
+
+// setTag sets the tag of a record of the household from oldTagID to
+// newTagID, increments its version and records the change in operation
+// opID. It returns false if the record does not exist, its tag is not
+// oldTagID or its version is not version, unless version is anyVersion.
+func setTag(txn *loggedTx, household, opID, recordID, oldTagID, newTagID string, version int) (bool, error) {
+	query := `UPDATE records SET tag_id = ?, version = version + 1
+		WHERE id = ? AND COALESCE(tag_id, 0) = ?
+		AND import_id IN (SELECT id FROM imports WHERE household_id = ?)`
+	args := []interface{}{nullID(newTagID), recordID, tagNumber(oldTagID), household}
+	if version != anyVersion {
+		// The tag may have been changed and changed back since it was
+		// read, e.g. at read committed isolation of PostgreSQL.
+		query += ` AND version = ?`
+		args = append(args, version)
+	}
+	res, err := txn.Exec(txn.Rebind(query), args...)
+	if err != nil {
+		return false, err
+	}
+	if count, err := res.RowsAffected(); err != nil || count != 1 {
+		return false, err
+	}
+	_, err = txn.Exec(txn.Rebind(`INSERT INTO tag_changes
+		(operation_id, record_id, old_tag_id, new_tag_id) VALUES (?, ?, ?, ?)`),
+		opID, recordID, nullID(oldTagID), nullID(newTagID))
+	return err == nil, err
+}
+
+// recordTag returns the tag and version of a record of the household, or
+// ErrNotFound.
+func recordTag(txn *loggedTx, household, recordID string) (string, int, error) {
+	var tagID string
+	var version int
+	err := txn.QueryRow(txn.Rebind(`SELECT COALESCE(CAST(tag_id AS text), ''), version FROM records
+		WHERE id = ? AND import_id IN (SELECT id FROM imports WHERE household_id = ?)`),
+		recordID, household).Scan(&tagID, &version)
+	if err == sql.ErrNoRows {
+		return "", 0, ErrNotFound
+	}
+	return tagID, version, err
+}
+
+// updateTag implements UpdateTag for the SQL databases.
+func updateTag(db *loggedDB, household, userID, recordID, tagID string, version int) (string, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+	if err := checkTags(txn, household, tagID); err != nil {
+		return "", err
+	}
+	oldTagID, current, err := recordTag(txn, household, recordID)
+	if err != nil {
+		return "", err
+	}
+	if current != version {
+		return "", ErrVersionConflict
+	}
+	opID, err := addOperation(txn, tagOperation{household: household, userID: userID, cause: CauseManual})
+	if err != nil {
+		return "", err
+	}
+	// The record was changed concurrently if its version is no longer
+	// the one read.
+	if ok, err := setTag(txn, household, opID, recordID, oldTagID, tagID, version); err != nil {
+		return "", err
+	} else if !ok {
+		return "", ErrVersionConflict
+	}
+	return opID, txn.Commit()
+}
+
+// bulkUpdateTag implements BulkUpdateTag for the SQL databases.
+func bulkUpdateTag(db *loggedDB, household, userID string, recordIDs []string, f *TransactionFilter, tagID string) (string, []string, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return "", nil, err
+	}
+	defer txn.Rollback()
+	if err := checkTags(txn, household, tagID); err != nil {
+		return "", nil, err
+	}
+	if f != nil {
+		query, args := transactionQuery([]string{"CAST(records.id AS text)"}, household, *f)
+		recordIDs = nil
+		if err := txn.Select(&recordIDs, txn.Rebind(query), args...); err != nil {
+			return "", nil, err
+		}
+	}
+	opID, err := addOperation(txn, tagOperation{household: household, userID: userID, cause: CauseBulk})
+	if err != nil {
+		return "", nil, err
+	}
+	var changed []string
+	for _, id := range recordIDs {
+		oldTagID, version, err := recordTag(txn, household, id)
+		if err != nil {
+			return "", nil, err
+		}
+		if oldTagID == tagID {
+			continue
+		}
+		// A record changed concurrently keeps the tag it was changed to.
+		ok, err := setTag(txn, household, opID, id, oldTagID, tagID, version)
+		if err != nil {
+			return "", nil, err
+		}
+		if ok {
+			changed = append(changed, id)
+		}
+	}
+	return opID, changed, txn.Commit()
+}
+
+// addPatternTags tags the untagged records by id in a new pattern operation
+// for AddPattern of the SQL databases.
+func addPatternTags(txn *loggedTx, household, userID, patternID, tagID string, recordIDs []string) (string, []string, error) {
+	opID, err := addOperation(txn, tagOperation{household: household, userID: userID, cause: CausePattern, patternID: patternID})
+	if err != nil {
+		return "", nil, err
+	}
+	var changed []string
+	for _, id := range recordIDs {
+		ok, err := setTag(txn, household, opID, id, "", tagID, anyVersion)
+		if err != nil {
+			return "", nil, err
+		}
+		if ok {
+			changed = append(changed, id)
+		}
+	}
+	return opID, changed, nil
+}
+
+// addImportTags records the tags of the records of a new import in an import
+// operation for AddImport of the SQL databases, and returns the number of
+// tagged records.
+func addImportTags(txn *loggedTx, household, userID, importID string) (int, error) {
+	opID, err := addOperation(txn, tagOperation{household: household, userID: userID, cause: CauseImport, importID: importID})
+	if err != nil {
+		return 0, err
+	}
+	res, err := txn.Exec(txn.Rebind(`INSERT INTO tag_changes (operation_id, record_id, old_tag_id, new_tag_id)
+		SELECT CAST(? AS integer), id, NULL, tag_id FROM records WHERE import_id = ? AND tag_id IS NOT NULL`),
+		opID, importID)
+	if err != nil {
+		return 0, err
+	}
+	tagged, err := res.RowsAffected()
+	return int(tagged), err
+}
+
+// undo implements Undo for the SQL databases.
+func undo(db *loggedDB, household, userID, opID string) (string, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	var undone bool
+	err = txn.Get(&undone, txn.Rebind(`SELECT EXISTS (SELECT 1 FROM tag_operations WHERE undo_of = o.id)
+		FROM tag_operations o WHERE o.id = ? AND o.household_id = ?`), opID, household)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	} else if err != nil {
+		return "", err
+	}
+	if undone {
+		return "", ErrUndoConflict
+	}
+	var changes []struct {
+		RecordID string `json:"record_id"`
+		OldTagID string `json:"old_tag_id"`
+		NewTagID string `json:"new_tag_id"`
+	}
+	err = txn.Select(&changes, txn.Rebind(`SELECT CAST(record_id AS text) AS record_id,
+		COALESCE(CAST(old_tag_id AS text), '') AS old_tag_id,
+		COALESCE(CAST(new_tag_id AS text), '') AS new_tag_id
+		FROM tag_changes WHERE operation_id = ? ORDER BY id DESC`), opID)
+	if err != nil {
+		return "", err
+	}
+
+	undoID, err := addOperation(txn, tagOperation{household: household, userID: userID, cause: CauseUndo, undoOf: opID})
+	if err != nil {
+		return "", err
+	}
+	for _, c := range changes {
+		if ok, err := setTag(txn, household, undoID, c.RecordID, c.NewTagID, c.OldTagID, anyVersion); err != nil {
+			return "", err
+		} else if !ok {
+			return "", ErrUndoConflict
+		}
+	}
+	return undoID, txn.Commit()
+}
+
+// listTagHistory implements ListTagHistory for the SQL databases.
+func listTagHistory(db *loggedDB, household, recordID string) ([]*TagChange, error) {
+	rows, err := db.Query(db.Rebind(`SELECT
+			CAST(o.id AS text),
+			CAST(c.record_id AS text),
+			COALESCE(CAST(c.old_tag_id AS text), ''),
+			COALESCE(CAST(c.new_tag_id AS text), ''),
+			COALESCE(users.username, ''),
+			o.cause,
+			COALESCE(CAST(o.pattern_id AS text), ''),
+			COALESCE(CAST(o.import_id AS text), ''),
+			COALESCE(CAST(o.undo_of AS text), ''),
+			o.created_at,
+			EXISTS (SELECT 1 FROM tag_operations u WHERE u.undo_of = o.id)
+		FROM tag_changes c
+			JOIN tag_operations o ON c.operation_id = o.id
+			LEFT JOIN users ON o.user_id = users.id
+		WHERE c.record_id = ? AND o.household_id = ?
+		ORDER BY c.id DESC`), recordID, household)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := []*TagChange{}
+	for rows.Next() {
+		var c TagChange
+		err := rows.Scan(&c.OperationID, &c.RecordID, &c.OldTagID, &c.NewTagID, &c.Username,
+			&c.Cause, &c.PatternID, &c.ImportID, &c.UndoOf, &c.Created, &c.Undone)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+	return changes, rows.Err()
+}
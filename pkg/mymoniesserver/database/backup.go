@@ -0,0 +1,315 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+)
+
+// ErrNotEmpty is returned when restoring a backup to a database with data.
+var ErrNotEmpty = errors.New("database is not empty")
+
+// nullString returns s as a nullable text column value.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// selectRows runs a query and calls scan for each row.
+func selectRows(txn *loggedTx, query string, scan func(*sql.Rows) error) error {
+	rows, err := txn.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// sqlBackup reads all tables except sessions in one transaction. The
+// queries work the same in PostgreSQL and SQLite: IDs, dates and decimals
+// are cast to text.
+func sqlBackup(db *loggedDB, opts *sql.TxOptions) (*backup.Data, error) {
+	txn, err := db.BeginTxx(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Rollback()
+
+	d := &backup.Data{}
+	err = selectRows(txn, `SELECT CAST(id AS text), name, created_at FROM households ORDER BY id`,
+		func(rows *sql.Rows) error {
+			h := &backup.Household{}
+			d.Households = append(d.Households, h)
+			return rows.Scan(&h.ID, &h.Name, &h.Created)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), username, password_hash, created_at FROM users ORDER BY id`,
+		func(rows *sql.Rows) error {
+			u := &backup.User{}
+			d.Users = append(d.Users, u)
+			return rows.Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Created)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(household_id AS text), CAST(user_id AS text), role
+		FROM household_members ORDER BY household_id, user_id`,
+		func(rows *sql.Rows) error {
+			m := &backup.Member{}
+			d.Members = append(d.Members, m)
+			return rows.Scan(&m.HouseholdID, &m.UserID, &m.Role)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(user_id AS text), name, hash, scope, created_at
+		FROM api_tokens ORDER BY id`,
+		func(rows *sql.Rows) error {
+			t := &backup.Token{}
+			d.Tokens = append(d.Tokens, t)
+			return rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Hash, &t.Scope, &t.Created)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(household_id AS text), COALESCE(name, '')
+		FROM tags ORDER BY id`,
+		func(rows *sql.Rows) error {
+			t := &backup.Tag{}
+			d.Tags = append(d.Tags, t)
+			return rows.Scan(&t.ID, &t.HouseholdID, &t.Name)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(household_id AS text), COALESCE(filename, ''),
+		account, currency FROM imports ORDER BY id`,
+		func(rows *sql.Rows) error {
+			i := &backup.Import{}
+			d.Imports = append(d.Imports, i)
+			return rows.Scan(&i.ID, &i.HouseholdID, &i.FileName, &i.Account, &i.Currency)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), COALESCE(CAST(import_id AS text), ''),
+		COALESCE(CAST(transaction_date AS text), ''), COALESCE(CAST(value_date AS text), ''),
+		COALESCE(CAST(payment_date AS text), ''), COALESCE(CAST(amount AS text), ''), currency,
+		COALESCE(payee_payer, ''), COALESCE(account, ''), COALESCE(bic, ''),
+		COALESCE("transaction", ''), COALESCE(reference, ''), COALESCE(payer_reference, ''),
+		COALESCE(message, ''), COALESCE(card_number, ''), COALESCE(CAST(tag_id AS text), ''), version
+		FROM records ORDER BY id`,
+		func(rows *sql.Rows) error {
+			r := &backup.Record{}
+			d.Records = append(d.Records, r)
+			return rows.Scan(&r.ID, &r.ImportID, &r.TransactionDate, &r.ValueDate, &r.PaymentDate,
+				&r.Amount, &r.Currency, &r.PayeePayer, &r.Account, &r.Bic, &r.Transaction,
+				&r.Reference, &r.PayerReference, &r.Message, &r.CardNumber, &r.TagID, &r.Version)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(household_id AS text),
+		COALESCE(CAST(tag_id AS text), ''), account, query FROM patterns ORDER BY id`,
+		func(rows *sql.Rows) error {
+			p := &backup.Pattern{}
+			d.Patterns = append(d.Patterns, p)
+			return rows.Scan(&p.ID, &p.HouseholdID, &p.TagID, &p.Account, &p.Query)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(household_id AS text),
+		COALESCE(CAST(user_id AS text), ''), cause, COALESCE(CAST(pattern_id AS text), ''),
+		COALESCE(CAST(import_id AS text), ''), COALESCE(CAST(undo_of AS text), ''), created_at
+		FROM tag_operations ORDER BY id`,
+		func(rows *sql.Rows) error {
+			o := &backup.TagOperation{}
+			d.TagOperations = append(d.TagOperations, o)
+			return rows.Scan(&o.ID, &o.HouseholdID, &o.UserID, &o.Cause, &o.PatternID, &o.ImportID, &o.UndoOf, &o.Created)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(id AS text), CAST(operation_id AS text), CAST(record_id AS text),
+		COALESCE(CAST(old_tag_id AS text), ''), COALESCE(CAST(new_tag_id AS text), '')
+		FROM tag_changes ORDER BY id`,
+		func(rows *sql.Rows) error {
+			c := &backup.TagChange{}
+			d.TagChanges = append(d.TagChanges, c)
+			return rows.Scan(&c.ID, &c.OperationID, &c.RecordID, &c.OldTagID, &c.NewTagID)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = selectRows(txn, `SELECT CAST(date AS text), currency, CAST(rate AS text)
+		FROM exchange_rates ORDER BY currency, date`,
+		func(rows *sql.Rows) error {
+			r := &backup.ExchangeRate{}
+			d.ExchangeRates = append(d.ExchangeRates, r)
+			return rows.Scan(&r.Date, &r.Currency, &r.Rate)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// insertID runs an INSERT query with ? placeholders and returns the ID of
+// the new row.
+func insertID(txn *loggedTx, query string, args ...interface{}) (string, error) {
+	var id string
+	err := txn.QueryRow(txn.Rebind(query+" RETURNING CAST(id AS text)"), args...).Scan(&id)
+	return id, err
+}
+
+// sqlRestore stores checked backup data in an empty database in one
+// transaction, with new IDs assigned by the database. timestamp converts
+// times to column values of the database.
+func sqlRestore(db *loggedDB, d *backup.Data, timestamp func(time.Time) interface{}) error {
+	if err := d.Check(); err != nil {
+		return err
+	}
+	txn, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	var rows int
+	err = txn.Get(&rows, txn.Rebind(`SELECT
+		(SELECT COUNT(*) FROM households WHERE id <> ?) + (SELECT COUNT(*) FROM users) +
+		(SELECT COUNT(*) FROM tags) + (SELECT COUNT(*) FROM imports) +
+		(SELECT COUNT(*) FROM patterns) + (SELECT COUNT(*) FROM exchange_rates)`), DefaultHousehold)
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		return ErrNotEmpty
+	}
+	created := func(t time.Time) interface{} {
+		if t.IsZero() {
+			t = time.Now()
+		}
+		return timestamp(t)
+	}
+
+	households, users, tags := make(backup.IDs), make(backup.IDs), make(backup.IDs)
+	imports, records, patterns, operations := make(backup.IDs), make(backup.IDs), make(backup.IDs), make(backup.IDs)
+	for _, h := range d.Households {
+		// The default household exists in every database.
+		if h.ID == DefaultHousehold {
+			_, err = txn.Exec(txn.Rebind("UPDATE households SET name = ?, created_at = ? WHERE id = ?"),
+				h.Name, created(h.Created), DefaultHousehold)
+			households[h.ID] = DefaultHousehold
+		} else {
+			households[h.ID], err = insertID(txn, "INSERT INTO households (name, created_at) VALUES (?, ?)",
+				h.Name, created(h.Created))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, u := range d.Users {
+		users[u.ID], err = insertID(txn, "INSERT INTO users (username, password_hash, created_at) VALUES (?, ?, ?)",
+			u.Username, u.PasswordHash, created(u.Created))
+		if err != nil {
+			return err
+		}
+	}
+	for _, m := range d.Members {
+		_, err = txn.Exec(txn.Rebind("INSERT INTO household_members (household_id, user_id, role) VALUES (?, ?, ?)"),
+			households.Map(m.HouseholdID), users.Map(m.UserID), m.Role)
+		if err != nil {
+			return err
+		}
+	}
+	for _, t := range d.Tokens {
+		_, err = txn.Exec(txn.Rebind("INSERT INTO api_tokens (user_id, name, hash, scope, created_at) VALUES (?, ?, ?, ?, ?)"),
+			users.Map(t.UserID), t.Name, t.Hash, t.Scope, created(t.Created))
+		if err != nil {
+			return err
+		}
+	}
+	for _, t := range d.Tags {
+		tags[t.ID], err = insertID(txn, "INSERT INTO tags (household_id, name) VALUES (?, ?)",
+			households.Map(t.HouseholdID), t.Name)
+		if err != nil {
+			return err
+		}
+	}
+	for _, i := range d.Imports {
+		imports[i.ID], err = insertID(txn, "INSERT INTO imports (household_id, filename, account, currency) VALUES (?, ?, ?, ?)",
+			households.Map(i.HouseholdID), i.FileName, i.Account, i.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	stmt, err := txn.Preparex(txn.Rebind(`INSERT INTO records (import_id, transaction_date,
+		value_date, payment_date, amount, currency, payee_payer, account, bic,
+		"transaction", reference, payer_reference, message, card_number, tag_id, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING CAST(id AS text)`))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range d.Records {
+		var id string
+		err = stmt.QueryRow(nullID(imports.Map(r.ImportID)), nullString(r.TransactionDate),
+			nullString(r.ValueDate), nullString(r.PaymentDate), nullString(r.Amount), r.Currency,
+			r.PayeePayer, r.Account, r.Bic, r.Transaction, r.Reference, r.PayerReference,
+			r.Message, r.CardNumber, nullID(tags.Map(r.TagID)), r.Version).Scan(&id)
+		if err != nil {
+			return err
+		}
+		records[r.ID] = id
+	}
+	for _, p := range d.Patterns {
+		patterns[p.ID], err = insertID(txn, "INSERT INTO patterns (household_id, tag_id, account, query) VALUES (?, ?, ?, ?)",
+			households.Map(p.HouseholdID), nullID(tags.Map(p.TagID)), p.Account, p.Query)
+		if err != nil {
+			return err
+		}
+	}
+	for _, o := range d.TagOperations {
+		operations[o.ID], err = insertID(txn, `INSERT INTO tag_operations
+			(household_id, user_id, cause, pattern_id, import_id, undo_of, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			households.Map(o.HouseholdID), nullID(users.Map(o.UserID)), o.Cause,
+			nullID(patterns.Map(o.PatternID)), nullID(imports.Map(o.ImportID)),
+			nullID(operations.Map(o.UndoOf)), created(o.Created))
+		if err != nil {
+			return err
+		}
+	}
+	changeStmt, err := txn.Preparex(txn.Rebind(`INSERT INTO tag_changes
+		(operation_id, record_id, old_tag_id, new_tag_id) VALUES (?, ?, ?, ?)`))
+	if err != nil {
+		return err
+	}
+	defer changeStmt.Close()
+	for _, c := range d.TagChanges {
+		_, err = changeStmt.Exec(operations.Map(c.OperationID), records.Map(c.RecordID),
+			nullID(tags.Map(c.OldTagID)), nullID(tags.Map(c.NewTagID)))
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range d.ExchangeRates {
+		_, err = txn.Exec(txn.Rebind("INSERT INTO exchange_rates (date, currency, rate) VALUES (?, ?, ?)"),
+			r.Date, r.Currency, r.Rate)
+		if err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
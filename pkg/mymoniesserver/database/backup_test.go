@@ -0,0 +1,129 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func TestStorage_Backup(t *testing.T) {
+	testStorages(t, testBackup)
+}
+
+func testBackup(t *testing.T, db Storage) {
+	alice := &User{Username: "alice", PasswordHash: "hash"}
+	if err := db.AddUser(alice); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	h, err := db.AddHousehold("Family")
+	if err != nil {
+		t.Fatal("db.AddHousehold() returned error:", err)
+	}
+	if err := db.SetMember(h.ID, alice.ID, RoleOwner); err != nil {
+		t.Fatal("db.SetMember() returned error:", err)
+	}
+	// Deleted rows leave gaps in IDs, which are not restored.
+	for _, hash := range []string{"deleted", "hash"} {
+		if err := db.AddToken(&Token{UserID: alice.ID, Name: hash, Hash: hash, Scope: "import"}); err != nil {
+			t.Fatal("db.AddToken() returned error:", err)
+		}
+	}
+	if err := db.DeleteToken(alice.ID, "1"); err != nil {
+		t.Fatal("db.DeleteToken() returned error:", err)
+	}
+	tag, err := db.AddTag(h.ID, "groceries")
+	if err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	_, err = db.AddImport(h.ID, alice.ID, &pb.AddImportReq{
+		Account:  "FI1234",
+		FileName: "example.txt",
+		Currency: "EUR",
+		Transactions: []*pb.Transaction{
+			{TransactionDate: "2018-03-02T00:00:00Z", Amount: "-12.50", Currency: "EUR", PayeePayer: "Shop", TagId: tag.Id},
+			{Amount: "-3.00", Currency: "EUR"},
+		},
+	})
+	if err != nil {
+		t.Fatal("db.AddImport() returned error:", err)
+	}
+	if _, _, err := db.AddPattern(h.ID, alice.ID, &pb.Pattern{Account: "FI1234", Query: "Shop", TagId: tag.Id}, nil); err != nil {
+		t.Fatal("db.AddPattern() returned error:", err)
+	}
+	op, err := db.UpdateTag(h.ID, alice.ID, "2", tag.Id, 1)
+	if err != nil {
+		t.Fatal("db.UpdateTag() returned error:", err)
+	}
+	if _, err := db.Undo(h.ID, "", op); err != nil {
+		t.Fatal("db.Undo() returned error:", err)
+	}
+	if err := db.AddExchangeRates([]*pb.ExchangeRate{{Date: "2018-03-01T00:00:00Z", Currency: "USD", Rate: "1.2276"}}); err != nil {
+		t.Fatal("db.AddExchangeRates() returned error:", err)
+	}
+
+	want, err := db.Backup()
+	if err != nil {
+		t.Fatal("db.Backup() returned error:", err)
+	}
+	if len(want.Households) != 2 || len(want.Members) != 1 || len(want.Tokens) != 1 || len(want.Records) != 2 ||
+		len(want.TagOperations) != 4 || len(want.TagChanges) != 3 || len(want.ExchangeRates) != 1 {
+		t.Fatalf("db.Backup() = %+v, want all rows", want)
+	}
+	if err := db.Restore(want); err != ErrNotEmpty {
+		t.Errorf("db.Restore() to the same database error = %v, want %v", err, ErrNotEmpty)
+	}
+
+	expected, err := db.Backup()
+	if err != nil {
+		t.Fatal("db.Backup() returned error:", err)
+	}
+	clearTimes(expected)
+	targets := map[string]Storage{"memory": NewMemory(), "sqlite": newSQLite(t)}
+	for name, restored := range targets {
+		if err := restored.Restore(want); err != nil {
+			t.Fatalf("%v: db.Restore() returned error: %v", name, err)
+		}
+		got, err := restored.Backup()
+		if err != nil {
+			t.Fatalf("%v: db.Backup() of restored returned error: %v", name, err)
+		}
+		if got.Tokens[0].ID != "1" {
+			t.Errorf("%v: restored token ID = %v, want 1", name, got.Tokens[0].ID)
+		}
+		got.Tokens[0].ID = expected.Tokens[0].ID
+		clearTimes(got)
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%v: restored backup differs:\ngot  %+v\nwant %+v", name, got, expected)
+		}
+		records, err := restored.ListTransactions(h.ID, TransactionFilter{ID: "1"})
+		if err != nil || len(records) != 1 || records[0].TagId != tag.Id || records[0].TransactionDate != "2018-03-02T00:00:00Z" {
+			t.Errorf("%v: restored db.ListTransactions() = %v, %v", name, records, err)
+		}
+		if history, _ := restored.ListTagHistory(h.ID, "2"); len(history) != 2 || !history[1].Undone {
+			t.Errorf("%v: restored db.ListTagHistory() = %+v, want undone change", name, history)
+		}
+		if role, err := restored.GetRole(h.ID, alice.ID); role != RoleOwner || err != nil {
+			t.Errorf("%v: restored db.GetRole() = %v, %v; want %v", name, role, err, RoleOwner)
+		}
+	}
+}
+
+// clearTimes zeroes the creation times of d, which memory storage does not
+// keep for all rows.
+func clearTimes(d *backup.Data) {
+	for _, h := range d.Households {
+		h.Created = time.Time{}
+	}
+	for _, u := range d.Users {
+		u.Created = time.Time{}
+	}
+	for _, t := range d.Tokens {
+		t.Created = time.Time{}
+	}
+	for _, o := range d.TagOperations {
+		o.Created = time.Time{}
+	}
+}
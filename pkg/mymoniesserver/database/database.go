@@ -0,0 +1,250 @@
+// Package database implements mymonies data storage backed by PostgreSQL or
+// SQLite.
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// ErrNotFound is returned when a record to update does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalidTag is returned when a tag ID does not refer to a tag of the
+// household.
+var ErrInvalidTag = errors.New("tag does not exist")
+
+// Storage stores imported transaction records, tags, tagging patterns and
+// exchange rates. Arguments are expected to be validated by the caller.
+//
+// Records, tags and patterns belong to a household, and the data methods
+// only access the data of the household given as the first argument.
+// Exchange rates are shared by all households.
+//
+// Methods changing the tags of records record the changes in an audit log
+// as one operation, with the ID of the user making the change. The user ID
+// is empty if authentication is disabled.
+type Storage interface {
+	// AddImport stores an imported file and its transaction records. It
+	// returns the number of records imported with a tag.
+	AddImport(household, userID string, req *pb.AddImportReq) (int, error)
+	// AddPattern stores a pattern and tags the matching untagged records.
+	// It returns the ID of the tag operation and the IDs of the tagged
+	// records.
+	AddPattern(household, userID string, p *pb.Pattern, recordIDs []string) (string, []string, error)
+	// ListAccounts lists accounts once for each imported currency.
+	ListAccounts(household string) ([]*pb.Account, error)
+	// ListImports lists imported files ordered by ID.
+	ListImports(household string) ([]*Import, error)
+	// ListTags lists tags ordered by name.
+	ListTags(household string) ([]*pb.Tag, error)
+	// ListTransactions lists records matching the filter, newest first.
+	ListTransactions(household string, f TransactionFilter) ([]*pb.Transaction, error)
+	// EachTransaction calls fn with the records matching the filter,
+	// newest first, as they are read, e.g. to stream an export. It stops
+	// at the first error of fn and returns it.
+	EachTransaction(household string, f TransactionFilter, fn func(*pb.Transaction) error) error
+	// UpdateTag sets the tag of a record. Empty tagID removes the tag. It
+	// returns the ID of the tag operation, or ErrVersionConflict if the
+	// version of the record is no longer version. Every tag change
+	// increments the version of the record by one.
+	UpdateTag(household, userID, recordID, tagID string, version int) (string, error)
+	// BulkUpdateTag sets the tag of records by id, or of the records
+	// matching the filter if f is not nil, in one operation, and returns
+	// the operation ID and the IDs of the changed records. The filter is
+	// matched in the same transaction. Records that already have the tag,
+	// or are changed concurrently, are not changed. It returns ErrNotFound
+	// without changing any tags if a record does not exist.
+	BulkUpdateTag(household, userID string, recordIDs []string, f *TransactionFilter, tagID string) (string, []string, error)
+	// ListTagHistory lists the tag changes of a record, newest first.
+	ListTagHistory(household, recordID string) ([]*TagChange, error)
+	// Undo reverts the tag changes of an operation in a new operation, and
+	// returns its ID. It returns ErrNotFound if the operation does not
+	// exist, or ErrUndoConflict if the operation was already undone or any
+	// of its records have been tagged since.
+	Undo(household, userID, operationID string) (string, error)
+	// AddTag stores a new tag.
+	AddTag(household, name string) (*pb.Tag, error)
+	// CountUntagged returns the number of untagged records of each
+	// household with records, by household ID.
+	CountUntagged() (map[string]int, error)
+
+	// AddHousehold stores a new household without members.
+	AddHousehold(name string) (*Household, error)
+	// ListHouseholds lists the households of a user ordered by ID, with the
+	// role of the user. Empty userID lists all households, without roles.
+	ListHouseholds(userID string) ([]*Household, error)
+	// GetRole returns the role of a user in a household, or ErrNotFound if
+	// the user is not a member.
+	GetRole(household, userID string) (Role, error)
+	// ListMembers lists the members of a household ordered by username.
+	ListMembers(household string) ([]*Member, error)
+	// SetMember adds a user to a household or changes the role of a member.
+	SetMember(household, userID string, role Role) error
+	// RemoveMember removes a user from a household, or returns ErrNotFound.
+	RemoveMember(household, userID string) error
+
+	// AddUser stores a new user and sets its ID. Usernames are unique.
+	AddUser(u *User) error
+	// GetUser returns the user by username, or ErrNotFound.
+	GetUser(username string) (*User, error)
+	// SetPassword replaces the password hash of a user and deletes the
+	// sessions of the user.
+	SetPassword(userID, passwordHash string) error
+	// AddSession stores a new login session. Expired sessions are deleted.
+	AddSession(s *Session) error
+	// GetSession returns the session by ID, or ErrNotFound if it does not
+	// exist or has expired.
+	GetSession(id string) (*Session, error)
+	// DeleteSession deletes a session, if it exists.
+	DeleteSession(id string) error
+	// AddToken stores a new API token and sets its ID and creation time.
+	AddToken(t *Token) error
+	// ListTokens lists the API tokens of a user ordered by ID.
+	ListTokens(userID string) ([]*Token, error)
+	// GetToken returns the API token by hash, or ErrNotFound.
+	GetToken(hash string) (*Token, error)
+	// DeleteToken deletes an API token of a user, or returns ErrNotFound.
+	DeleteToken(userID, id string) error
+
+	// AddExchangeRates stores exchange rates, replacing any existing rate
+	// of the same currency and date.
+	AddExchangeRates(rates []*pb.ExchangeRate) error
+	exchangerate.Source
+
+	// Backup returns the data of all tables except login sessions.
+	Backup() (*backup.Data, error)
+	// Restore stores backup data in an empty database with new IDs. The
+	// default household of the backup replaces the default household of
+	// the database. It returns ErrNotEmpty if the database has any data
+	// besides the default household.
+	Restore(d *backup.Data) error
+
+	// WithContext returns the storage logging the SQL queries of its calls
+	// with ctx, e.g. with the ID of the request they are made for.
+	WithContext(ctx context.Context) Storage
+	// LogQueries logs SQL queries and their durations to logger at debug
+	// level, and queries taking at least slow at warning level. Zero slow
+	// disables the warnings. Queries are not logged by default.
+	LogQueries(logger *slog.Logger, slow time.Duration)
+
+	Migrate() error
+	MigrateTo(version int) error
+	Rollback(steps int) error
+	SchemaVersion() (int, error)
+	MigrationStatus() ([]MigrationStatus, error)
+	Close() error
+}
+
+// TransactionFilter limits the listed transactions. Zero value fields match
+// all transactions.
+type TransactionFilter struct {
+	ID      string
+	Account string
+	Month   time.Time // First day of month.
+	Query   string    // Exact match of any text field.
+}
+
+// Import is an imported file of transaction records.
+type Import struct {
+	ID       string
+	FileName string
+	Account  string
+	Currency string // ISO 4217 currency code of the account.
+}
+
+// User is a user who can log in to mymonies.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+}
+
+// DefaultHousehold is the ID of the household created by the schema
+// migrations. Data from before households were introduced belongs to it.
+const DefaultHousehold = "1"
+
+// Household is a group of users sharing transaction records, e.g. a family.
+type Household struct {
+	ID   string
+	Name string
+	Role Role // Role of the listing user, if any.
+}
+
+// Role is the role of a household member.
+type Role string
+
+// Roles of household members, from the most to the least privileged.
+const (
+	// RoleOwner can manage the members of the household.
+	RoleOwner Role = "owner"
+	// RoleEditor can import and tag records.
+	RoleEditor Role = "editor"
+	// RoleViewer can list records.
+	RoleViewer Role = "viewer"
+)
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+// Allows reports whether r has at least the privileges of role required.
+func (r Role) Allows(required Role) bool {
+	rank := map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+	return rank[r] > 0 && rank[r] >= rank[required]
+}
+
+// Member is a member of a household.
+type Member struct {
+	UserID   string
+	Username string
+	Role     Role
+}
+
+// Session is a login session of a user.
+type Session struct {
+	ID        string // Hash of the session token, never the token itself.
+	UserID    string
+	Username  string
+	CSRFToken string
+	Expires   time.Time
+}
+
+// Token is a personal API token of a user.
+type Token struct {
+	ID       string
+	UserID   string
+	Username string
+	Name     string    // Description of the token use, e.g. "nightly import".
+	Hash     string    // Hash of the token, never the token itself.
+	Scope    string    // Methods the token can call, e.g. "import".
+	Created  time.Time // Set by AddToken.
+}
+
+// Open connects to the database selected by the connection string scheme.
+// A sqlite: URL opens a SQLite database file, e.g. sqlite:///var/lib/mymonies.db
+// or sqlite:mymonies.db, and memory: returns an empty in-memory database.
+// Any other connection string is passed to PostgreSQL, e.g.
+// postgres://localhost/mymonies or database=mymonies.
+func Open(conn string) (Storage, error) {
+	if conn == "memory:" {
+		return NewMemory(), nil
+	}
+	if strings.HasPrefix(conn, "sqlite:") {
+		filename := strings.TrimPrefix(conn, "sqlite:")
+		filename = strings.TrimPrefix(filename, "//")
+		return ConnectSQLite(filename)
+	}
+	return Connect(conn)
+}
+
+// dateFormat is the format of dates in queries.
+const dateFormat = "2006-01-02"
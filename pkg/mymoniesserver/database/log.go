@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// queryLog logs SQL queries with the context they are made for, e.g. to
+// include the ID of the request. A zero queryLog logs nothing.
+type queryLog struct {
+	ctx    context.Context
+	logger *slog.Logger
+	// slow is the duration of slow queries logged as warnings; zero
+	// disables the warnings.
+	slow time.Duration
+}
+
+// logQuery logs query and its duration since start, at debug level or at
+// warning level if the query was slow.
+func (l queryLog) logQuery(query string, start time.Time) {
+	if l.logger == nil {
+		return
+	}
+	d := time.Since(start)
+	level, msg := slog.LevelDebug, "SQL query"
+	if l.slow > 0 && d >= l.slow {
+		level, msg = slog.LevelWarn, "slow SQL query"
+	}
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	l.logger.Log(ctx, level, msg, "query", strings.Join(strings.Fields(query), " "), "duration", d)
+}
+
+// withContext returns a copy of l logging with ctx.
+func (l queryLog) withContext(ctx context.Context) queryLog {
+	l.ctx = ctx
+	return l
+}
+
+// loggedDB is a connection pool logging the queries made with it and with
+// its transactions.
+type loggedDB struct {
+	*sqlx.DB
+	queryLog
+}
+
+// withContext returns a copy of db logging with ctx.
+func (db *loggedDB) withContext(ctx context.Context) *loggedDB {
+	return &loggedDB{db.DB, db.queryLog.withContext(ctx)}
+}
+
+func (db *loggedDB) Exec(query string, arg ...interface{}) (sql.Result, error) {
+	defer db.logQuery(query, time.Now())
+	return db.DB.Exec(query, arg...)
+}
+
+func (db *loggedDB) Query(query string, arg ...interface{}) (*sql.Rows, error) {
+	defer db.logQuery(query, time.Now())
+	return db.DB.Query(query, arg...)
+}
+
+func (db *loggedDB) QueryRow(query string, arg ...interface{}) *sql.Row {
+	defer db.logQuery(query, time.Now())
+	return db.DB.QueryRow(query, arg...)
+}
+
+func (db *loggedDB) Select(dest interface{}, query string, args ...interface{}) error {
+	defer db.logQuery(query, time.Now())
+	return db.DB.Select(dest, query, args...)
+}
+
+func (db *loggedDB) Get(dest interface{}, query string, args ...interface{}) error {
+	defer db.logQuery(query, time.Now())
+	return db.DB.Get(dest, query, args...)
+}
+
+func (db *loggedDB) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	defer db.logQuery(query, time.Now())
+	return db.DB.Queryx(query, args...)
+}
+
+func (db *loggedDB) QueryRowx(query string, arg ...interface{}) *sqlx.Row {
+	defer db.logQuery(query, time.Now())
+	return db.DB.QueryRowx(query, arg...)
+}
+
+func (db *loggedDB) NamedQuery(query string, arg interface{}) (*sqlx.Rows, error) {
+	defer db.logQuery(query, time.Now())
+	return db.DB.NamedQuery(query, arg)
+}
+
+func (db *loggedDB) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	defer db.logQuery(query, time.Now())
+	return db.DB.NamedExec(query, arg)
+}
+
+func (db *loggedDB) Beginx() (*loggedTx, error) {
+	txn, err := db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return &loggedTx{txn, db.queryLog}, nil
+}
+
+func (db *loggedDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*loggedTx, error) {
+	txn, err := db.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &loggedTx{txn, db.queryLog}, nil
+}
+
+// loggedTx is a transaction logging its queries. Statements prepared in the
+// transaction are logged when prepared, not each time they are executed.
+type loggedTx struct {
+	*sqlx.Tx
+	queryLog
+}
+
+func (txn *loggedTx) Exec(query string, arg ...interface{}) (sql.Result, error) {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Exec(query, arg...)
+}
+
+func (txn *loggedTx) Query(query string, arg ...interface{}) (*sql.Rows, error) {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Query(query, arg...)
+}
+
+func (txn *loggedTx) QueryRow(query string, arg ...interface{}) *sql.Row {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.QueryRow(query, arg...)
+}
+
+func (txn *loggedTx) Select(dest interface{}, query string, args ...interface{}) error {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Select(dest, query, args...)
+}
+
+func (txn *loggedTx) Get(dest interface{}, query string, args ...interface{}) error {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Get(dest, query, args...)
+}
+
+func (txn *loggedTx) Prepare(query string) (*sql.Stmt, error) {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Prepare(query)
+}
+
+func (txn *loggedTx) Preparex(query string) (*sqlx.Stmt, error) {
+	defer txn.logQuery(query, time.Now())
+	return txn.Tx.Preparex(query)
+}
@@ -0,0 +1,1094 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	"github.com/joneskoo/mymonies/pkg/money"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// Memory stores data in memory only. It behaves like the SQL databases,
+// including ordering and filtering of transactions and reference checks,
+// so it can be used in tests and demos without a database server.
+type Memory struct {
+	mu            sync.RWMutex
+	imports       []memoryImport
+	records       []*pb.Transaction
+	tags          []*pb.Tag
+	tagHouseholds map[string]string // tag id, household id
+	patterns      []memoryPattern
+	exchangeRates map[string]map[string]money.Amount // currency, date
+	users         []*User
+	sessions      map[string]*Session
+	households    []*Household
+	members       map[string]map[string]Role // household id, user id
+	tokens        []*Token
+	lastTokenID   int
+	operations    []*memoryOperation
+}
+
+type memoryImport struct {
+	id        int
+	household string
+	filename  string
+	account   string
+	currency  string
+}
+
+type memoryPattern struct {
+	household string
+	pattern   pb.Pattern
+}
+
+// memoryOperation is a tag operation with its changes, see TagChange.
+type memoryOperation struct {
+	tagOperation
+	id      string
+	created time.Time
+	changes []memoryTagChange
+	undone  bool
+}
+
+type memoryTagChange struct {
+	recordID string
+	oldTagID string
+	newTagID string
+}
+
+// NewMemory returns an empty in-memory database with the default household,
+// like a migrated SQL database.
+func NewMemory() *Memory {
+	return &Memory{
+		tagHouseholds: make(map[string]string),
+		exchangeRates: make(map[string]map[string]money.Amount),
+		sessions:      make(map[string]*Session),
+		households:    []*Household{{ID: DefaultHousehold, Name: "Default"}},
+		members:       map[string]map[string]Role{DefaultHousehold: {}},
+	}
+}
+
+// Migrate does nothing; in-memory data has no schema.
+func (db *Memory) Migrate() error { return nil }
+
+// MigrateTo(0) deletes all data, like migrating a SQL database to an empty
+// schema and back. Other versions do nothing; in-memory data has no schema.
+func (db *Memory) MigrateTo(version int) error {
+	if version != 0 {
+		return nil
+	}
+	empty := NewMemory()
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.imports, db.records, db.tags = empty.imports, empty.records, empty.tags
+	db.tagHouseholds, db.patterns, db.exchangeRates = empty.tagHouseholds, empty.patterns, empty.exchangeRates
+	db.users, db.sessions, db.households, db.members = empty.users, empty.sessions, empty.households, empty.members
+	db.tokens, db.lastTokenID, db.operations = empty.tokens, empty.lastTokenID, empty.operations
+	return nil
+}
+
+// Rollback does nothing; in-memory data has no schema.
+func (db *Memory) Rollback(steps int) error { return nil }
+
+// SchemaVersion returns 0; in-memory data has no schema.
+func (db *Memory) SchemaVersion() (int, error) { return 0, nil }
+
+// MigrationStatus returns no migrations; in-memory data has no schema.
+func (db *Memory) MigrationStatus() ([]MigrationStatus, error) { return nil, nil }
+
+// Close does nothing. Data is discarded when the Memory is garbage collected.
+func (db *Memory) Close() error { return nil }
+
+// WithContext returns db; the in-memory database has no queries to log.
+func (db *Memory) WithContext(ctx context.Context) Storage { return db }
+
+// LogQueries does nothing; the in-memory database has no queries to log.
+func (db *Memory) LogQueries(logger *slog.Logger, slow time.Duration) {}
+
+// memoryDate normalizes an RFC 3339 timestamp like a database date column.
+func memoryDate(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02T00:00:00Z")
+}
+
+// checkTag returns ErrInvalidTag unless tagID is empty or refers to a tag of
+// the household.
+func (db *Memory) checkTag(household, tagID string) error {
+	if tagID == "" || db.tagHouseholds[tagID] == household {
+		return nil
+	}
+	return ErrInvalidTag
+}
+
+// checkHousehold returns an error unless the household exists, like a
+// foreign key constraint.
+func (db *Memory) checkHousehold(household string) error {
+	if _, ok := db.members[household]; !ok {
+		return fmt.Errorf("household %q does not exist", household)
+	}
+	return nil
+}
+
+// importHouseholds returns the household of each import by import id.
+func (db *Memory) importHouseholds() map[string]string {
+	households := make(map[string]string)
+	for _, imp := range db.imports {
+		households[strconv.Itoa(imp.id)] = imp.household
+	}
+	return households
+}
+
+// AddTag stores a new tag.
+func (db *Memory) AddTag(household, name string) (*pb.Tag, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkHousehold(household); err != nil {
+		return nil, err
+	}
+	for _, t := range db.tags {
+		if t.Name == name && db.tagHouseholds[t.Id] == household {
+			return nil, fmt.Errorf("tag %q already exists", name)
+		}
+	}
+	t := &pb.Tag{Id: strconv.Itoa(len(db.tags) + 1), Name: name}
+	db.tags = append(db.tags, t)
+	db.tagHouseholds[t.Id] = household
+	c := *t
+	return &c, nil
+}
+
+// AddImport stores an imported file and its transaction records.
+func (db *Memory) AddImport(household, userID string, req *pb.AddImportReq) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkHousehold(household); err != nil {
+		return 0, err
+	}
+
+	records := make([]*pb.Transaction, 0, len(req.Transactions))
+	importID := len(db.imports) + 1
+	for i, r := range req.Transactions {
+		amount, err := money.Parse(r.Amount)
+		if err != nil {
+			return 0, err
+		}
+		if err := db.checkTag(household, r.TagId); err != nil {
+			return 0, err
+		}
+		records = append(records, &pb.Transaction{
+			Id:              strconv.Itoa(len(db.records) + i + 1),
+			ImportId:        strconv.Itoa(importID),
+			TransactionDate: memoryDate(r.TransactionDate),
+			ValueDate:       memoryDate(r.ValueDate),
+			PaymentDate:     memoryDate(r.PaymentDate),
+			Amount:          amount.String(),
+			PayeePayer:      r.PayeePayer,
+			Account:         r.Account,
+			Bic:             r.Bic,
+			Transaction:     r.Transaction,
+			Reference:       r.Reference,
+			PayerReference:  r.PayerReference,
+			Message:         r.Message,
+			CardNumber:      r.CardNumber,
+			TagId:           r.TagId,
+			Currency:        r.Currency,
+			Version:         1,
+		})
+	}
+	db.imports = append(db.imports, memoryImport{
+		id:        importID,
+		household: household,
+		filename:  req.FileName,
+		account:   req.Account,
+		currency:  req.Currency,
+	})
+	db.records = append(db.records, records...)
+	op := db.addOperation(tagOperation{household: household, userID: userID, cause: CauseImport, importID: strconv.Itoa(importID)})
+	for _, r := range records {
+		if r.TagId != "" {
+			op.changes = append(op.changes, memoryTagChange{recordID: r.Id, newTagID: r.TagId})
+		}
+	}
+	return len(op.changes), nil
+}
+
+// AddPattern stores a pattern and tags the untagged records by id.
+func (db *Memory) AddPattern(household, userID string, p *pb.Pattern, recordIDs []string) (string, []string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkTag(household, p.TagId); err != nil {
+		return "", nil, err
+	}
+	db.patterns = append(db.patterns, memoryPattern{household: household, pattern: *p})
+	op := db.addOperation(tagOperation{household: household, userID: userID, cause: CausePattern, patternID: strconv.Itoa(len(db.patterns))})
+	var changed []string
+	for _, id := range recordIDs {
+		if r := db.record(household, id); r != nil && r.TagId == "" {
+			db.setTag(op, r, p.TagId)
+			changed = append(changed, id)
+		}
+	}
+	return op.id, changed, nil
+}
+
+// ListAccounts lists accounts once for each imported currency.
+func (db *Memory) ListAccounts(household string) ([]*pb.Account, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	accounts := []*pb.Account{}
+	seen := make(map[pb.Account]bool)
+	for _, imp := range db.imports {
+		if imp.household != household {
+			continue
+		}
+		a := pb.Account{Number: imp.account, Currency: imp.currency}
+		if !seen[a] {
+			seen[a] = true
+			accounts = append(accounts, &a)
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Number != accounts[j].Number {
+			return accounts[i].Number < accounts[j].Number
+		}
+		return accounts[i].Currency < accounts[j].Currency
+	})
+	return accounts, nil
+}
+
+// ListImports lists imported files ordered by ID.
+func (db *Memory) ListImports(household string) ([]*Import, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	imports := []*Import{}
+	for _, imp := range db.imports {
+		if imp.household == household {
+			imports = append(imports, &Import{
+				ID:       strconv.Itoa(imp.id),
+				FileName: imp.filename,
+				Account:  imp.account,
+				Currency: imp.currency,
+			})
+		}
+	}
+	return imports, nil
+}
+
+// ListTags lists tags ordered by name.
+func (db *Memory) ListTags(household string) ([]*pb.Tag, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	tags := []*pb.Tag{}
+	for _, t := range db.tags {
+		if db.tagHouseholds[t.Id] == household {
+			c := *t
+			tags = append(tags, &c)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// ListTransactions lists records matching the filter, newest first.
+func (db *Memory) ListTransactions(household string, f TransactionFilter) ([]*pb.Transaction, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.transactions(household, f), nil
+}
+
+// transactions returns copies of the records matching the filter, newest
+// first. db.mu must be held.
+func (db *Memory) transactions(household string, f TransactionFilter) []*pb.Transaction {
+	accounts := make(map[string]string)
+	for _, imp := range db.imports {
+		accounts[strconv.Itoa(imp.id)] = imp.account
+	}
+	households := db.importHouseholds()
+	var start, end string
+	if !f.Month.IsZero() {
+		start = memoryDate(f.Month.Format(time.RFC3339))
+		end = memoryDate(f.Month.AddDate(0, 1, -1).Format(time.RFC3339))
+	}
+
+	transactions := make([]*pb.Transaction, 0)
+	for _, r := range db.records {
+		if households[r.ImportId] != household {
+			continue
+		}
+		if f.ID != "" && r.Id != f.ID {
+			continue
+		}
+		if f.Account != "" && accounts[r.ImportId] != f.Account {
+			continue
+		}
+		if start != "" && (r.TransactionDate == "" || r.TransactionDate < start || r.TransactionDate > end) {
+			continue
+		}
+		if f.Query != "" && !memoryMatch(r, f.Query) {
+			continue
+		}
+		c := *r
+		transactions = append(transactions, &c)
+	}
+
+	// Same order as "ORDER BY transaction_date IS NULL DESC,
+	// transaction_date DESC, id" in the SQL databases.
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if a.TransactionDate != b.TransactionDate {
+			if a.TransactionDate == "" || b.TransactionDate == "" {
+				return a.TransactionDate == ""
+			}
+			return a.TransactionDate > b.TransactionDate
+		}
+		ai, _ := strconv.Atoi(a.Id)
+		bi, _ := strconv.Atoi(b.Id)
+		return ai < bi
+	})
+	return transactions
+}
+
+// EachTransaction calls fn with the records matching the filter, newest
+// first. The records are copied first so that fn may use the storage.
+func (db *Memory) EachTransaction(household string, f TransactionFilter, fn func(*pb.Transaction) error) error {
+	transactions, err := db.ListTransactions(household, f)
+	if err != nil {
+		return err
+	}
+	for _, t := range transactions {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryMatch reports whether any text field of the record equals query.
+func memoryMatch(r *pb.Transaction, query string) bool {
+	for _, s := range []string{r.PayeePayer, r.Account, r.Transaction, r.Reference, r.PayerReference, r.Message} {
+		if s == query {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateTag sets the tag of a record at version. Empty tagID removes the tag.
+func (db *Memory) UpdateTag(household, userID, recordID, tagID string, version int) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkTag(household, tagID); err != nil {
+		return "", err
+	}
+	r := db.record(household, recordID)
+	if r == nil {
+		return "", ErrNotFound
+	}
+	if int(r.Version) != version {
+		return "", ErrVersionConflict
+	}
+	op := db.addOperation(tagOperation{household: household, userID: userID, cause: CauseManual})
+	db.setTag(op, r, tagID)
+	return op.id, nil
+}
+
+// BulkUpdateTag sets the tag of records by id in one operation.
+func (db *Memory) BulkUpdateTag(household, userID string, recordIDs []string, f *TransactionFilter, tagID string) (string, []string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkTag(household, tagID); err != nil {
+		return "", nil, err
+	}
+	if f != nil {
+		recordIDs = nil
+		for _, r := range db.transactions(household, *f) {
+			recordIDs = append(recordIDs, r.Id)
+		}
+	}
+	records := make([]*pb.Transaction, len(recordIDs))
+	for i, id := range recordIDs {
+		if records[i] = db.record(household, id); records[i] == nil {
+			return "", nil, ErrNotFound
+		}
+	}
+	op := db.addOperation(tagOperation{household: household, userID: userID, cause: CauseBulk})
+	var changed []string
+	for _, r := range records {
+		if r.TagId != tagID {
+			db.setTag(op, r, tagID)
+			changed = append(changed, r.Id)
+		}
+	}
+	return op.id, changed, nil
+}
+
+// record returns the record of the household by id, or nil.
+func (db *Memory) record(household, id string) *pb.Transaction {
+	households := db.importHouseholds()
+	for _, r := range db.records {
+		if r.Id == id && households[r.ImportId] == household {
+			return r
+		}
+	}
+	return nil
+}
+
+// addOperation adds a tag operation without changes.
+func (db *Memory) addOperation(op tagOperation) *memoryOperation {
+	o := &memoryOperation{
+		tagOperation: op,
+		id:           strconv.Itoa(len(db.operations) + 1),
+		created:      time.Now().UTC().Truncate(time.Second),
+	}
+	db.operations = append(db.operations, o)
+	return o
+}
+
+// setTag sets the tag of a record, increments its version and records the
+// change in op.
+func (db *Memory) setTag(op *memoryOperation, r *pb.Transaction, tagID string) {
+	op.changes = append(op.changes, memoryTagChange{recordID: r.Id, oldTagID: r.TagId, newTagID: tagID})
+	r.TagId = tagID
+	r.Version++
+}
+
+// ListTagHistory lists the tag changes of a record, newest first.
+func (db *Memory) ListTagHistory(household, recordID string) ([]*TagChange, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	changes := []*TagChange{}
+	for i := len(db.operations) - 1; i >= 0; i-- {
+		op := db.operations[i]
+		if op.household != household {
+			continue
+		}
+		username, _ := db.username(op.userID)
+		for j := len(op.changes) - 1; j >= 0; j-- {
+			c := op.changes[j]
+			if c.recordID != recordID {
+				continue
+			}
+			changes = append(changes, &TagChange{
+				OperationID: op.id,
+				RecordID:    c.recordID,
+				OldTagID:    c.oldTagID,
+				NewTagID:    c.newTagID,
+				Username:    username,
+				Cause:       op.cause,
+				PatternID:   op.patternID,
+				ImportID:    op.importID,
+				UndoOf:      op.undoOf,
+				Created:     op.created,
+				Undone:      op.undone,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// Undo reverts the tag changes of an operation.
+func (db *Memory) Undo(household, userID, operationID string) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var op *memoryOperation
+	for _, o := range db.operations {
+		if o.id == operationID && o.household == household {
+			op = o
+		}
+	}
+	if op == nil {
+		return "", ErrNotFound
+	}
+	if op.undone {
+		return "", ErrUndoConflict
+	}
+	// Check all records before changing any, so that undo is atomic.
+	records := make([]*pb.Transaction, len(op.changes))
+	for i, c := range op.changes {
+		records[i] = db.record(household, c.recordID)
+		if records[i] == nil || records[i].TagId != c.newTagID {
+			return "", ErrUndoConflict
+		}
+	}
+	undo := db.addOperation(tagOperation{household: household, userID: userID, cause: CauseUndo, undoOf: op.id})
+	for i := len(op.changes) - 1; i >= 0; i-- {
+		db.setTag(undo, records[i], op.changes[i].oldTagID)
+	}
+	op.undone = true
+	return undo.id, nil
+}
+
+// CountUntagged returns the number of untagged records of each household.
+func (db *Memory) CountUntagged() (map[string]int, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	households := db.importHouseholds()
+	counts := make(map[string]int)
+	for _, r := range db.records {
+		if r.TagId == "" {
+			counts[households[r.ImportId]]++
+		}
+	}
+	return counts, nil
+}
+
+// AddExchangeRates stores exchange rates, replacing any existing rate of the
+// same currency and date.
+func (db *Memory) AddExchangeRates(rates []*pb.ExchangeRate) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, r := range rates {
+		rate, err := money.Parse(r.Rate)
+		if err != nil {
+			return err
+		}
+		date, err := time.Parse(time.RFC3339, r.Date)
+		if err != nil {
+			return err
+		}
+		if db.exchangeRates[r.Currency] == nil {
+			db.exchangeRates[r.Currency] = make(map[string]money.Amount)
+		}
+		db.exchangeRates[r.Currency][date.Format(dateFormat)] = rate
+	}
+	return nil
+}
+
+// ExchangeRate implements exchangerate.Source with rates in memory.
+func (db *Memory) ExchangeRate(code string, date time.Time) (exchangerate.Rate, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for i := 0; i <= exchangerate.MaxFallback; i++ {
+		d := date.AddDate(0, 0, -i)
+		if rate, ok := db.exchangeRates[code][d.Format(dateFormat)]; ok {
+			day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+			return exchangerate.Rate{Date: day, Currency: code, Rate: rate}, nil
+		}
+	}
+	return exchangerate.Rate{Currency: code}, exchangerate.ErrNoRate
+}
+
+// AddUser stores a new user and sets its ID.
+func (db *Memory) AddUser(u *User) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, existing := range db.users {
+		if existing.Username == u.Username {
+			return fmt.Errorf("user %q already exists", u.Username)
+		}
+	}
+	u.ID = strconv.Itoa(len(db.users) + 1)
+	c := *u
+	db.users = append(db.users, &c)
+	return nil
+}
+
+// GetUser returns the user by username, or ErrNotFound.
+func (db *Memory) GetUser(username string) (*User, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, u := range db.users {
+		if u.Username == username {
+			c := *u
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// SetPassword replaces the password hash of a user and deletes the sessions
+// of the user.
+func (db *Memory) SetPassword(userID, passwordHash string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, u := range db.users {
+		if u.ID == userID {
+			u.PasswordHash = passwordHash
+			for id, s := range db.sessions {
+				if s.UserID == userID {
+					delete(db.sessions, id)
+				}
+			}
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// AddSession stores a new login session. Expired sessions are deleted.
+func (db *Memory) AddSession(s *Session) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	now := time.Now()
+	for id, existing := range db.sessions {
+		if existing.Expires.Before(now) {
+			delete(db.sessions, id)
+		}
+	}
+	var user *User
+	for _, u := range db.users {
+		if u.ID == s.UserID {
+			user = u
+		}
+	}
+	if user == nil {
+		return fmt.Errorf("user %q does not exist", s.UserID)
+	}
+	if _, ok := db.sessions[s.ID]; ok {
+		return fmt.Errorf("session already exists")
+	}
+	c := *s
+	c.Username = user.Username
+	db.sessions[s.ID] = &c
+	return nil
+}
+
+// GetSession returns the session by ID, or ErrNotFound if it does not exist
+// or has expired.
+func (db *Memory) GetSession(id string) (*Session, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	s, ok := db.sessions[id]
+	if !ok || !s.Expires.After(time.Now()) {
+		return nil, ErrNotFound
+	}
+	c := *s
+	return &c, nil
+}
+
+// DeleteSession deletes a session, if it exists.
+func (db *Memory) DeleteSession(id string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.sessions, id)
+	return nil
+}
+
+// AddHousehold stores a new household without members.
+func (db *Memory) AddHousehold(name string) (*Household, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	h := &Household{ID: strconv.Itoa(len(db.households) + 1), Name: name}
+	db.households = append(db.households, h)
+	db.members[h.ID] = make(map[string]Role)
+	c := *h
+	return &c, nil
+}
+
+// ListHouseholds lists the households of a user ordered by ID, with the role
+// of the user. Empty userID lists all households, without roles.
+func (db *Memory) ListHouseholds(userID string) ([]*Household, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	households := []*Household{}
+	for _, h := range db.households {
+		c := *h
+		if userID != "" {
+			role, ok := db.members[h.ID][userID]
+			if !ok {
+				continue
+			}
+			c.Role = role
+		}
+		households = append(households, &c)
+	}
+	return households, nil
+}
+
+// GetRole returns the role of a user in a household, or ErrNotFound if the
+// user is not a member.
+func (db *Memory) GetRole(household, userID string) (Role, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	role, ok := db.members[household][userID]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return role, nil
+}
+
+// ListMembers lists the members of a household ordered by username.
+func (db *Memory) ListMembers(household string) ([]*Member, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	members := []*Member{}
+	for _, u := range db.users {
+		if role, ok := db.members[household][u.ID]; ok {
+			members = append(members, &Member{UserID: u.ID, Username: u.Username, Role: role})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Username < members[j].Username })
+	return members, nil
+}
+
+// SetMember adds a user to a household or changes the role of a member.
+func (db *Memory) SetMember(household, userID string, role Role) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.checkHousehold(household); err != nil {
+		return err
+	}
+	if !role.Valid() {
+		return fmt.Errorf("invalid role %q", role)
+	}
+	for _, u := range db.users {
+		if u.ID == userID {
+			db.members[household][userID] = role
+			return nil
+		}
+	}
+	return fmt.Errorf("user %q does not exist", userID)
+}
+
+// RemoveMember removes a user from a household, or returns ErrNotFound.
+func (db *Memory) RemoveMember(household, userID string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.members[household][userID]; !ok {
+		return ErrNotFound
+	}
+	delete(db.members[household], userID)
+	return nil
+}
+
+// username returns the username of a user by ID.
+func (db *Memory) username(userID string) (string, bool) {
+	for _, u := range db.users {
+		if u.ID == userID {
+			return u.Username, true
+		}
+	}
+	return "", false
+}
+
+// AddToken stores a new API token and sets its ID and creation time.
+func (db *Memory) AddToken(t *Token) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	username, ok := db.username(t.UserID)
+	if !ok {
+		return fmt.Errorf("user %q does not exist", t.UserID)
+	}
+	for _, existing := range db.tokens {
+		if existing.Hash == t.Hash {
+			return fmt.Errorf("token already exists")
+		}
+	}
+	db.lastTokenID++
+	t.ID = strconv.Itoa(db.lastTokenID)
+	t.Created = time.Now().UTC().Truncate(time.Second)
+	c := *t
+	c.Username = username
+	db.tokens = append(db.tokens, &c)
+	return nil
+}
+
+// ListTokens lists the API tokens of a user ordered by ID.
+func (db *Memory) ListTokens(userID string) ([]*Token, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	tokens := []*Token{}
+	for _, t := range db.tokens {
+		if t.UserID == userID {
+			c := *t
+			tokens = append(tokens, &c)
+		}
+	}
+	return tokens, nil
+}
+
+// GetToken returns the API token by hash, or ErrNotFound.
+func (db *Memory) GetToken(hash string) (*Token, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	for _, t := range db.tokens {
+		if t.Hash == hash {
+			c := *t
+			return &c, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// DeleteToken deletes an API token of a user, or returns ErrNotFound.
+func (db *Memory) DeleteToken(userID, id string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for i, t := range db.tokens {
+		if t.UserID == userID && t.ID == id {
+			db.tokens = append(db.tokens[:i], db.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Backup returns the data of all tables except login sessions.
+func (db *Memory) Backup() (*backup.Data, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	d := &backup.Data{}
+	for _, h := range db.households {
+		d.Households = append(d.Households, &backup.Household{ID: h.ID, Name: h.Name})
+		for _, u := range db.users {
+			if role, ok := db.members[h.ID][u.ID]; ok {
+				d.Members = append(d.Members, &backup.Member{HouseholdID: h.ID, UserID: u.ID, Role: string(role)})
+			}
+		}
+	}
+	for _, u := range db.users {
+		d.Users = append(d.Users, &backup.User{ID: u.ID, Username: u.Username, PasswordHash: u.PasswordHash})
+	}
+	for _, t := range db.tokens {
+		d.Tokens = append(d.Tokens, &backup.Token{ID: t.ID, UserID: t.UserID, Name: t.Name, Hash: t.Hash, Scope: t.Scope, Created: t.Created})
+	}
+	for _, t := range db.tags {
+		d.Tags = append(d.Tags, &backup.Tag{ID: t.Id, HouseholdID: db.tagHouseholds[t.Id], Name: t.Name})
+	}
+	for _, imp := range db.imports {
+		d.Imports = append(d.Imports, &backup.Import{
+			ID:          strconv.Itoa(imp.id),
+			HouseholdID: imp.household,
+			FileName:    imp.filename,
+			Account:     imp.account,
+			Currency:    imp.currency,
+		})
+	}
+	// Dates are stored as RFC 3339 timestamps at midnight UTC.
+	date := func(timestamp string) string {
+		if len(timestamp) < len(dateFormat) {
+			return ""
+		}
+		return timestamp[:len(dateFormat)]
+	}
+	for _, r := range db.records {
+		d.Records = append(d.Records, &backup.Record{
+			ID:              r.Id,
+			ImportID:        r.ImportId,
+			TransactionDate: date(r.TransactionDate),
+			ValueDate:       date(r.ValueDate),
+			PaymentDate:     date(r.PaymentDate),
+			Amount:          r.Amount,
+			Currency:        r.Currency,
+			PayeePayer:      r.PayeePayer,
+			Account:         r.Account,
+			Bic:             r.Bic,
+			Transaction:     r.Transaction,
+			Reference:       r.Reference,
+			PayerReference:  r.PayerReference,
+			Message:         r.Message,
+			CardNumber:      r.CardNumber,
+			TagID:           r.TagId,
+			Version:         int(r.Version),
+		})
+	}
+	for i, p := range db.patterns {
+		d.Patterns = append(d.Patterns, &backup.Pattern{
+			ID:          strconv.Itoa(i + 1),
+			HouseholdID: p.household,
+			TagID:       p.pattern.TagId,
+			Account:     p.pattern.Account,
+			Query:       p.pattern.Query,
+		})
+	}
+	for _, op := range db.operations {
+		d.TagOperations = append(d.TagOperations, &backup.TagOperation{
+			ID:          op.id,
+			HouseholdID: op.household,
+			UserID:      op.userID,
+			Cause:       string(op.cause),
+			PatternID:   op.patternID,
+			ImportID:    op.importID,
+			UndoOf:      op.undoOf,
+			Created:     op.created,
+		})
+		for _, c := range op.changes {
+			d.TagChanges = append(d.TagChanges, &backup.TagChange{
+				ID:          strconv.Itoa(len(d.TagChanges) + 1),
+				OperationID: op.id,
+				RecordID:    c.recordID,
+				OldTagID:    c.oldTagID,
+				NewTagID:    c.newTagID,
+			})
+		}
+	}
+	// Same order as "ORDER BY currency, date" in SQL.
+	currencies := make([]string, 0, len(db.exchangeRates))
+	for c := range db.exchangeRates {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+	for _, c := range currencies {
+		dates := make([]string, 0, len(db.exchangeRates[c]))
+		for date := range db.exchangeRates[c] {
+			dates = append(dates, date)
+		}
+		sort.Strings(dates)
+		for _, date := range dates {
+			d.ExchangeRates = append(d.ExchangeRates, &backup.ExchangeRate{Date: date, Currency: c, Rate: db.exchangeRates[c][date].String()})
+		}
+	}
+	return d, nil
+}
+
+// Restore stores backup data in an empty database with new IDs.
+func (db *Memory) Restore(d *backup.Data) error {
+	if err := d.Check(); err != nil {
+		return err
+	}
+	// Parse amounts before changing anything, so that restore is atomic.
+	rates := make([]money.Amount, len(d.ExchangeRates))
+	for i, r := range d.ExchangeRates {
+		var err error
+		if rates[i], err = money.Parse(r.Rate); err != nil {
+			return fmt.Errorf("exchange rate %v %v: %v", r.Currency, r.Date, err)
+		}
+	}
+	for _, r := range d.Records {
+		if _, err := money.Parse(r.Amount); err != nil {
+			return fmt.Errorf("record %v: %v", r.ID, err)
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if len(db.households) > 1 || len(db.users) > 0 || len(db.tags) > 0 || len(db.imports) > 0 ||
+		len(db.patterns) > 0 || len(db.exchangeRates) > 0 {
+		return ErrNotEmpty
+	}
+
+	households, users, tags := make(backup.IDs), make(backup.IDs), make(backup.IDs)
+	imports, records, patterns := make(backup.IDs), make(backup.IDs), make(backup.IDs)
+	operations := make(map[string]*memoryOperation)
+	for _, h := range d.Households {
+		// The default household exists in every database.
+		if h.ID == DefaultHousehold {
+			db.households[0].Name = h.Name
+			households[h.ID] = DefaultHousehold
+			continue
+		}
+		id := strconv.Itoa(len(db.households) + 1)
+		db.households = append(db.households, &Household{ID: id, Name: h.Name})
+		db.members[id] = make(map[string]Role)
+		households[h.ID] = id
+	}
+	for _, u := range d.Users {
+		id := strconv.Itoa(len(db.users) + 1)
+		db.users = append(db.users, &User{ID: id, Username: u.Username, PasswordHash: u.PasswordHash})
+		users[u.ID] = id
+	}
+	for _, m := range d.Members {
+		db.members[households.Map(m.HouseholdID)][users.Map(m.UserID)] = Role(m.Role)
+	}
+	for _, t := range d.Tokens {
+		db.lastTokenID++
+		userID := users.Map(t.UserID)
+		username, _ := db.username(userID)
+		db.tokens = append(db.tokens, &Token{
+			ID:       strconv.Itoa(db.lastTokenID),
+			UserID:   userID,
+			Username: username,
+			Name:     t.Name,
+			Hash:     t.Hash,
+			Scope:    t.Scope,
+			Created:  t.Created,
+		})
+	}
+	for _, t := range d.Tags {
+		id := strconv.Itoa(len(db.tags) + 1)
+		db.tags = append(db.tags, &pb.Tag{Id: id, Name: t.Name})
+		db.tagHouseholds[id] = households.Map(t.HouseholdID)
+		tags[t.ID] = id
+	}
+	for _, imp := range d.Imports {
+		id := len(db.imports) + 1
+		db.imports = append(db.imports, memoryImport{
+			id:        id,
+			household: households.Map(imp.HouseholdID),
+			filename:  imp.FileName,
+			account:   imp.Account,
+			currency:  imp.Currency,
+		})
+		imports[imp.ID] = strconv.Itoa(id)
+	}
+	date := func(d string) string {
+		if d == "" {
+			return ""
+		}
+		return memoryDate(d + "T00:00:00Z")
+	}
+	for _, r := range d.Records {
+		id := strconv.Itoa(len(db.records) + 1)
+		db.records = append(db.records, &pb.Transaction{
+			Id:              id,
+			ImportId:        imports.Map(r.ImportID),
+			TransactionDate: date(r.TransactionDate),
+			ValueDate:       date(r.ValueDate),
+			PaymentDate:     date(r.PaymentDate),
+			Amount:          money.MustParse(r.Amount).String(),
+			Currency:        r.Currency,
+			PayeePayer:      r.PayeePayer,
+			Account:         r.Account,
+			Bic:             r.Bic,
+			Transaction:     r.Transaction,
+			Reference:       r.Reference,
+			PayerReference:  r.PayerReference,
+			Message:         r.Message,
+			CardNumber:      r.CardNumber,
+			TagId:           tags.Map(r.TagID),
+			Version:         int32(r.Version),
+		})
+		records[r.ID] = id
+	}
+	for _, p := range d.Patterns {
+		db.patterns = append(db.patterns, memoryPattern{
+			household: households.Map(p.HouseholdID),
+			pattern:   pb.Pattern{Account: p.Account, Query: p.Query, TagId: tags.Map(p.TagID)},
+		})
+		patterns[p.ID] = strconv.Itoa(len(db.patterns))
+	}
+	for _, o := range d.TagOperations {
+		op := &memoryOperation{
+			tagOperation: tagOperation{
+				household: households.Map(o.HouseholdID),
+				userID:    users.Map(o.UserID),
+				cause:     Cause(o.Cause),
+				patternID: patterns.Map(o.PatternID),
+				importID:  imports.Map(o.ImportID),
+			},
+			id:      strconv.Itoa(len(db.operations) + 1),
+			created: o.Created,
+		}
+		if undone := operations[o.UndoOf]; undone != nil {
+			op.undoOf = undone.id
+			undone.undone = true
+		}
+		db.operations = append(db.operations, op)
+		operations[o.ID] = op
+	}
+	for _, c := range d.TagChanges {
+		op := operations[c.OperationID]
+		op.changes = append(op.changes, memoryTagChange{
+			recordID: records.Map(c.RecordID),
+			oldTagID: tags.Map(c.OldTagID),
+			newTagID: tags.Map(c.NewTagID),
+		})
+	}
+	for i, r := range d.ExchangeRates {
+		if db.exchangeRates[r.Currency] == nil {
+			db.exchangeRates[r.Currency] = make(map[string]money.Amount)
+		}
+		db.exchangeRates[r.Currency][r.Date] = rates[i]
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// MigrationStatus describes whether a schema migration has been applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// migrator applies and reverts the schema migrations of a database.
+type migrator struct {
+	db         *sqlx.DB
+	migrations []migration
+	// createTable creates the schema_migrations table if it does not exist.
+	createTable string
+	// hasTable reports whether the schema_migrations table exists.
+	hasTable string
+	// lock is executed first in the migration transaction to prevent
+	// concurrent migrations, if the database needs an explicit lock.
+	lock string
+}
+
+// LatestVersion is the schema version after applying all migrations.
+func (m migrator) LatestVersion() int {
+	return m.migrations[len(m.migrations)-1].version
+}
+
+// Migrate applies any pending schema migrations.
+// This is safe to call multiple times.
+func (m migrator) Migrate() error {
+	return m.MigrateTo(m.LatestVersion())
+}
+
+// MigrateTo migrates the schema up or down to version. Version 0 is an
+// empty database. All migrations are run in a single transaction, so on
+// error the schema is left unchanged.
+func (m migrator) MigrateTo(version int) error {
+	if version < 0 || version > m.LatestVersion() {
+		return fmt.Errorf("unknown schema version %d", version)
+	}
+	txn, err := m.begin()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	applied, err := appliedMigrations(txn)
+	if err != nil {
+		return err
+	}
+	insert := m.db.Rebind("INSERT INTO schema_migrations (version, name) VALUES (?, ?)")
+	for _, mig := range m.migrations {
+		if mig.version > version || applied[mig.version] {
+			continue
+		}
+		if _, err := txn.Exec(mig.up); err != nil {
+			return fmt.Errorf("migration %d (%v) failed: %v", mig.version, mig.name, err)
+		}
+		if _, err := txn.Exec(insert, mig.version, mig.name); err != nil {
+			return err
+		}
+	}
+	remove := m.db.Rebind("DELETE FROM schema_migrations WHERE version = ?")
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.version <= version || !applied[mig.version] {
+			continue
+		}
+		if _, err := txn.Exec(mig.down); err != nil {
+			return fmt.Errorf("rollback of migration %d (%v) failed: %v", mig.version, mig.name, err)
+		}
+		if _, err := txn.Exec(remove, mig.version); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// Rollback reverts the latest steps applied migrations.
+func (m migrator) Rollback(steps int) error {
+	version, err := m.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < steps && version > 0; i++ {
+		version--
+	}
+	return m.MigrateTo(version)
+}
+
+// SchemaVersion returns the version of the latest applied migration, or
+// 0 if no migrations have been applied.
+func (m migrator) SchemaVersion() (int, error) {
+	status, err := m.MigrationStatus()
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for _, s := range status {
+		if s.Applied {
+			version = s.Version
+		}
+	}
+	return version, nil
+}
+
+// MigrationStatus lists all known migrations and whether they are applied.
+// It only reads the database, as it is called by readiness probes; without
+// the schema_migrations table no migrations are applied.
+func (m migrator) MigrationStatus() ([]MigrationStatus, error) {
+	appliedAt := make(map[int]time.Time)
+	var exists bool
+	if err := m.db.QueryRow(m.hasTable).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return m.status(appliedAt), nil
+	}
+	rows, err := m.db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		var t time.Time
+		if err := rows.Scan(&version, &t); err != nil {
+			return nil, err
+		}
+		appliedAt[version] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return m.status(appliedAt), nil
+}
+
+// status lists all known migrations with the times of the applied ones.
+func (m migrator) status(appliedAt map[int]time.Time) []MigrationStatus {
+	status := make([]MigrationStatus, len(m.migrations))
+	for i, mig := range m.migrations {
+		t, ok := appliedAt[mig.version]
+		status[i] = MigrationStatus{Version: mig.version, Name: mig.name, Applied: ok, AppliedAt: t}
+	}
+	return status
+}
+
+// begin begins a transaction holding the migration lock. The lock is
+// released when the transaction ends.
+func (m migrator) begin() (*sql.Tx, error) {
+	txn, err := m.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	if m.lock != "" {
+		if _, err := txn.Exec(m.lock); err != nil {
+			txn.Rollback()
+			return nil, err
+		}
+	}
+	if _, err := txn.Exec(m.createTable); err != nil {
+		txn.Rollback()
+		return nil, err
+	}
+	return txn, nil
+}
+
+func appliedMigrations(txn *sql.Tx) (map[int]bool, error) {
+	applied := make(map[int]bool)
+	rows, err := txn.Query("SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,492 @@
+package database
+
+// migration is a numbered, reversible database schema change.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// postgresMigrationLock is the PostgreSQL advisory lock held while migrating,
+// so that servers starting at the same time do not migrate concurrently.
+const postgresMigrationLock = "SELECT pg_advisory_xact_lock(7888456547677332851)" // "mymonies"
+
+const postgresSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version int PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`
+
+const postgresHasSchemaMigrations = "SELECT to_regclass('schema_migrations') IS NOT NULL"
+
+// postgresMigrations lists the PostgreSQL schema changes in order. Migrations
+// must never be edited or removed after they have been released; add a new
+// one instead.
+//
+// The first migrations use IF NOT EXISTS so that they can be applied to
+// databases created before schema migrations were introduced.
+var postgresMigrations = []migration{
+	{
+		version: 1,
+		name:    "create tables",
+		up: `
+			CREATE TABLE IF NOT EXISTS imports (
+				id serial UNIQUE,
+				filename text,
+				account text NOT NULL
+			);
+
+			CREATE TABLE IF NOT EXISTS tags (
+				id serial UNIQUE,
+				name text UNIQUE
+			);
+
+			CREATE TABLE IF NOT EXISTS records (
+				id serial UNIQUE,
+				import_id int REFERENCES imports(id) ON DELETE CASCADE,
+				transaction_date date ,
+				value_date date,
+				payment_date date,
+				amount double precision,
+				payee_payer text,
+				account text,
+				bic text,
+				transaction text,
+				reference text,
+				payer_reference text,
+				message text,
+				card_number text,
+				tag_id int REFERENCES tags(id)
+			);
+
+			CREATE TABLE IF NOT EXISTS patterns (
+				id serial		UNIQUE,
+				tag_id			int REFERENCES tags(id),
+				account			text NOT NULL,
+				query			text NOT NULL
+			);
+		`,
+		down: `
+			DROP TABLE patterns;
+			DROP TABLE records;
+			DROP TABLE tags;
+			DROP TABLE imports;
+		`,
+	},
+
+	{
+		version: 2,
+		name:    "add currency",
+		up: `
+			ALTER TABLE imports ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'EUR';
+			ALTER TABLE records ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'EUR';
+		`,
+		down: `
+			ALTER TABLE records DROP COLUMN currency;
+			ALTER TABLE imports DROP COLUMN currency;
+		`,
+	},
+
+	{
+		version: 3,
+		name:    "exact decimal amounts",
+		// Records from before currency support are in euros, so existing
+		// floating point amounts are rounded to cents.
+		up: `
+			DO $$
+			BEGIN
+				IF EXISTS (
+					SELECT 1 FROM information_schema.columns
+					WHERE table_name = 'records' AND column_name = 'amount'
+						AND data_type = 'double precision'
+				) THEN
+					ALTER TABLE records ALTER COLUMN amount TYPE numeric
+						USING round(amount::numeric, 2);
+				END IF;
+			END
+			$$;
+		`,
+		down: `
+			ALTER TABLE records ALTER COLUMN amount TYPE double precision;
+		`,
+	},
+
+	{
+		version: 4,
+		name:    "create exchange rates",
+		up: `
+			CREATE TABLE IF NOT EXISTS exchange_rates (
+				date			date NOT NULL,
+				currency		text NOT NULL,
+				rate			numeric NOT NULL,
+				PRIMARY KEY (currency, date)
+			);
+		`,
+		down: `
+			DROP TABLE exchange_rates;
+		`,
+	},
+
+	{
+		version: 5,
+		name:    "create users and sessions",
+		up: `
+			CREATE TABLE users (
+				id			serial PRIMARY KEY,
+				username		text NOT NULL UNIQUE,
+				password_hash		text NOT NULL,
+				created_at		timestamptz NOT NULL DEFAULT now()
+			);
+
+			CREATE TABLE sessions (
+				id			text PRIMARY KEY,
+				user_id			int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				csrf_token		text NOT NULL,
+				expires_at		timestamptz NOT NULL
+			);
+		`,
+		down: `
+			DROP TABLE sessions;
+			DROP TABLE users;
+		`,
+	},
+
+	{
+		version: 6,
+		name:    "add households",
+		// Existing data and users belong to the default household.
+		up: `
+			CREATE TABLE households (
+				id			serial PRIMARY KEY,
+				name			text NOT NULL,
+				created_at		timestamptz NOT NULL DEFAULT now()
+			);
+
+			CREATE TABLE household_members (
+				household_id		int NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				user_id			int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				role			text NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+				PRIMARY KEY (household_id, user_id)
+			);
+
+			INSERT INTO households (id, name) VALUES (1, 'Default');
+			SELECT setval('households_id_seq', 1);
+			INSERT INTO household_members (household_id, user_id, role)
+				SELECT 1, id, 'owner' FROM users;
+
+			ALTER TABLE imports ADD COLUMN household_id int NOT NULL DEFAULT 1
+				REFERENCES households(id) ON DELETE CASCADE;
+			ALTER TABLE imports ALTER COLUMN household_id DROP DEFAULT;
+			ALTER TABLE tags ADD COLUMN household_id int NOT NULL DEFAULT 1
+				REFERENCES households(id) ON DELETE CASCADE;
+			ALTER TABLE tags ALTER COLUMN household_id DROP DEFAULT;
+			ALTER TABLE patterns ADD COLUMN household_id int NOT NULL DEFAULT 1
+				REFERENCES households(id) ON DELETE CASCADE;
+			ALTER TABLE patterns ALTER COLUMN household_id DROP DEFAULT;
+
+			-- Tag names are unique within a household.
+			ALTER TABLE tags DROP CONSTRAINT tags_name_key;
+			ALTER TABLE tags ADD CONSTRAINT tags_household_id_name_key UNIQUE (household_id, name);
+		`,
+		// Rolling back fails if households have tags of the same name.
+		down: `
+			ALTER TABLE tags DROP CONSTRAINT tags_household_id_name_key;
+			ALTER TABLE tags ADD CONSTRAINT tags_name_key UNIQUE (name);
+			ALTER TABLE patterns DROP COLUMN household_id;
+			ALTER TABLE tags DROP COLUMN household_id;
+			ALTER TABLE imports DROP COLUMN household_id;
+			DROP TABLE household_members;
+			DROP TABLE households;
+		`,
+	},
+
+	{
+		version: 7,
+		name:    "create api tokens",
+		up: `
+			CREATE TABLE api_tokens (
+				id			serial PRIMARY KEY,
+				user_id			int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				name			text NOT NULL,
+				hash			text NOT NULL UNIQUE,
+				scope			text NOT NULL,
+				created_at		timestamptz NOT NULL DEFAULT now()
+			);
+		`,
+		down: `
+			DROP TABLE api_tokens;
+		`,
+	},
+
+	{
+		version: 8,
+		name:    "create tag audit log",
+		// An operation is one call changing tags, e.g. applying a pattern.
+		// Undoing an operation is an operation with undo_of set.
+		up: `
+			CREATE TABLE tag_operations (
+				id			serial PRIMARY KEY,
+				household_id		int NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				user_id			int REFERENCES users(id) ON DELETE SET NULL,
+				cause			text NOT NULL,
+				pattern_id		int REFERENCES patterns(id) ON DELETE SET NULL,
+				import_id		int REFERENCES imports(id) ON DELETE CASCADE,
+				undo_of			int REFERENCES tag_operations(id) ON DELETE CASCADE,
+				created_at		timestamptz NOT NULL DEFAULT now()
+			);
+			CREATE INDEX tag_operations_undo_of_idx ON tag_operations (undo_of);
+
+			CREATE TABLE tag_changes (
+				id			serial PRIMARY KEY,
+				operation_id		int NOT NULL REFERENCES tag_operations(id) ON DELETE CASCADE,
+				record_id		int NOT NULL REFERENCES records(id) ON DELETE CASCADE,
+				old_tag_id		int REFERENCES tags(id),
+				new_tag_id		int REFERENCES tags(id)
+			);
+			CREATE INDEX tag_changes_record_id_idx ON tag_changes (record_id);
+			CREATE INDEX tag_changes_operation_id_idx ON tag_changes (operation_id);
+		`,
+		down: `
+			DROP TABLE tag_changes;
+			DROP TABLE tag_operations;
+		`,
+	},
+
+	{
+		version: 9,
+		name:    "add record versions",
+		// The version is incremented on every tag change, so that
+		// concurrent edits can be detected.
+		up: `
+			ALTER TABLE records ADD COLUMN version int NOT NULL DEFAULT 1;
+		`,
+		down: `
+			ALTER TABLE records DROP COLUMN version;
+		`,
+	},
+}
+
+const sqliteSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version int PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+
+const sqliteHasSchemaMigrations = "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')"
+
+// sqliteMigrations lists the SQLite schema changes in order. The same rules
+// apply as for postgresMigrations, but the schemas are versioned separately.
+//
+// SQLite has no exact decimal type, so amounts and rates are stored as text
+// and dates as YYYY-MM-DD text.
+var sqliteMigrations = []migration{
+	{
+		version: 1,
+		name:    "create tables",
+		up: `
+			CREATE TABLE imports (
+				id integer PRIMARY KEY,
+				filename text,
+				account text NOT NULL,
+				currency text NOT NULL DEFAULT 'EUR'
+			);
+
+			CREATE TABLE tags (
+				id integer PRIMARY KEY,
+				name text UNIQUE
+			);
+
+			CREATE TABLE records (
+				id integer PRIMARY KEY,
+				import_id int REFERENCES imports(id) ON DELETE CASCADE,
+				transaction_date date,
+				value_date date,
+				payment_date date,
+				amount text NOT NULL,
+				payee_payer text,
+				account text,
+				bic text,
+				"transaction" text,
+				reference text,
+				payer_reference text,
+				message text,
+				card_number text,
+				tag_id int REFERENCES tags(id),
+				currency text NOT NULL DEFAULT 'EUR'
+			);
+
+			CREATE TABLE patterns (
+				id integer PRIMARY KEY,
+				tag_id int REFERENCES tags(id),
+				account text NOT NULL,
+				query text NOT NULL
+			);
+
+			CREATE TABLE exchange_rates (
+				date date NOT NULL,
+				currency text NOT NULL,
+				rate text NOT NULL,
+				PRIMARY KEY (currency, date)
+			);
+		`,
+		down: `
+			DROP TABLE exchange_rates;
+			DROP TABLE patterns;
+			DROP TABLE records;
+			DROP TABLE tags;
+			DROP TABLE imports;
+		`,
+	},
+
+	{
+		version: 2,
+		name:    "create users and sessions",
+		// Timestamps are stored as RFC 3339 text in UTC, so they sort
+		// correctly as long as they have no fractional seconds.
+		up: `
+			CREATE TABLE users (
+				id integer PRIMARY KEY,
+				username text NOT NULL UNIQUE,
+				password_hash text NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE sessions (
+				id text PRIMARY KEY,
+				user_id int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				csrf_token text NOT NULL,
+				expires_at timestamp NOT NULL
+			);
+		`,
+		down: `
+			DROP TABLE sessions;
+			DROP TABLE users;
+		`,
+	},
+
+	{
+		version: 3,
+		name:    "add households",
+		// Existing data and users belong to the default household.
+		//
+		// SQLite can't add a column with both a foreign key and a default,
+		// so household_id of imports and patterns is not a foreign key.
+		// Tags are recreated to make names unique within a household.
+		// Foreign keys referring to tags are checked on commit, after the
+		// tags have been copied back.
+		up: `
+			CREATE TABLE households (
+				id integer PRIMARY KEY,
+				name text NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE household_members (
+				household_id int NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				user_id int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				role text NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+				PRIMARY KEY (household_id, user_id)
+			);
+
+			INSERT INTO households (id, name) VALUES (1, 'Default');
+			INSERT INTO household_members (household_id, user_id, role)
+				SELECT 1, id, 'owner' FROM users;
+
+			ALTER TABLE imports ADD COLUMN household_id int NOT NULL DEFAULT 1;
+			ALTER TABLE patterns ADD COLUMN household_id int NOT NULL DEFAULT 1;
+
+			PRAGMA defer_foreign_keys = ON;
+			CREATE TABLE tags_copy AS SELECT id, name FROM tags;
+			DROP TABLE tags;
+			CREATE TABLE tags (
+				id integer PRIMARY KEY,
+				household_id int NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				name text,
+				UNIQUE (household_id, name)
+			);
+			INSERT INTO tags (id, household_id, name) SELECT id, 1, name FROM tags_copy;
+			DROP TABLE tags_copy;
+		`,
+		// Rolling back fails if households have tags of the same name.
+		down: `
+			PRAGMA defer_foreign_keys = ON;
+			CREATE TABLE tags_copy AS SELECT id, name FROM tags;
+			DROP TABLE tags;
+			CREATE TABLE tags (
+				id integer PRIMARY KEY,
+				name text UNIQUE
+			);
+			INSERT INTO tags (id, name) SELECT id, name FROM tags_copy;
+			DROP TABLE tags_copy;
+
+			ALTER TABLE patterns DROP COLUMN household_id;
+			ALTER TABLE imports DROP COLUMN household_id;
+			DROP TABLE household_members;
+			DROP TABLE households;
+		`,
+	},
+
+	{
+		version: 4,
+		name:    "create api tokens",
+		up: `
+			CREATE TABLE api_tokens (
+				id integer PRIMARY KEY,
+				user_id int NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				name text NOT NULL,
+				hash text NOT NULL UNIQUE,
+				scope text NOT NULL,
+				created_at timestamp NOT NULL
+			);
+		`,
+		down: `
+			DROP TABLE api_tokens;
+		`,
+	},
+
+	{
+		version: 5,
+		name:    "create tag audit log",
+		up: `
+			CREATE TABLE tag_operations (
+				id integer PRIMARY KEY,
+				household_id int NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				user_id int REFERENCES users(id) ON DELETE SET NULL,
+				cause text NOT NULL,
+				pattern_id int REFERENCES patterns(id) ON DELETE SET NULL,
+				import_id int REFERENCES imports(id) ON DELETE CASCADE,
+				undo_of int REFERENCES tag_operations(id) ON DELETE CASCADE,
+				created_at timestamp NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+			);
+			CREATE INDEX tag_operations_undo_of_idx ON tag_operations (undo_of);
+
+			CREATE TABLE tag_changes (
+				id integer PRIMARY KEY,
+				operation_id int NOT NULL REFERENCES tag_operations(id) ON DELETE CASCADE,
+				record_id int NOT NULL REFERENCES records(id) ON DELETE CASCADE,
+				old_tag_id int REFERENCES tags(id),
+				new_tag_id int REFERENCES tags(id)
+			);
+			CREATE INDEX tag_changes_record_id_idx ON tag_changes (record_id);
+			CREATE INDEX tag_changes_operation_id_idx ON tag_changes (operation_id);
+		`,
+		down: `
+			DROP TABLE tag_changes;
+			DROP TABLE tag_operations;
+		`,
+	},
+
+	{
+		version: 6,
+		name:    "add record versions",
+		up: `
+			ALTER TABLE records ADD COLUMN version int NOT NULL DEFAULT 1;
+		`,
+		down: `
+			ALTER TABLE records DROP COLUMN version;
+		`,
+	},
+}
@@ -0,0 +1,22 @@
+package database
+
+import "testing"
+
+func TestMigrations(t *testing.T) {
+	t.Run("postgres", func(t *testing.T) { testMigrations(t, postgresMigrations) })
+	t.Run("sqlite", func(t *testing.T) { testMigrations(t, sqliteMigrations) })
+}
+
+func testMigrations(t *testing.T, migrations []migration) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migrations[%d].version = %d, want %d", i, m.version, i+1)
+		}
+		if m.name == "" {
+			t.Errorf("migration %d has no name", m.version)
+		}
+		if m.up == "" || m.down == "" {
+			t.Errorf("migration %d (%v) is not reversible", m.version, m.name)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// AddImport stores an imported file and its transaction records.
+func (db *Postgres) AddImport(household, userID string, req *pb.AddImportReq) (int, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Rollback()
+
+	if err := checkTags(txn, household, importTagIDs(req)...); err != nil {
+		return 0, err
+	}
+	var importid string
+	const insertImport = "INSERT INTO imports (household_id, filename, account, currency) VALUES ($1, $2, $3, $4) RETURNING id"
+	if err := txn.QueryRow(insertImport, household, req.FileName, req.Account, req.Currency).Scan(&importid); err != nil {
+		return 0, err
+	}
+	stmt, err := txn.Prepare(pq.CopyIn("records", "import_id", "transaction_date",
+		"value_date", "payment_date", "amount", "payee_payer", "account", "bic",
+		"transaction", "reference", "payer_reference", "message", "card_number",
+		"tag_id", "currency"))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, r := range req.Transactions {
+		_, err = stmt.Exec(
+			importid,
+			sql.NullString{String: r.TransactionDate, Valid: r.TransactionDate != ""},
+			sql.NullString{String: r.ValueDate, Valid: r.ValueDate != ""},
+			sql.NullString{String: r.PaymentDate, Valid: r.PaymentDate != ""},
+			r.Amount,
+			r.PayeePayer,
+			r.Account,
+			r.Bic,
+			r.Transaction,
+			r.Reference,
+			r.PayerReference,
+			r.Message,
+			r.CardNumber,
+			sql.NullString{String: r.TagId, Valid: r.TagId != ""},
+			r.Currency)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		return 0, err
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, err
+	}
+	tagged, err := addImportTags(txn, household, userID, importid)
+	if err != nil {
+		return 0, err
+	}
+	return tagged, txn.Commit()
+}
+
+// AddPattern stores a pattern and tags the untagged records by id.
+func (db *Postgres) AddPattern(household, userID string, p *pb.Pattern, recordIDs []string) (string, []string, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return "", nil, err
+	}
+	defer txn.Rollback()
+	if err := checkTags(txn, household, p.TagId); err != nil {
+		return "", nil, err
+	}
+	var patternID string
+	err = txn.QueryRow("INSERT INTO patterns (household_id, account, query, tag_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		household, p.Account, p.Query, p.TagId).Scan(&patternID)
+	if err != nil {
+		return "", nil, err
+	}
+	opID, changed, err := addPatternTags(txn, household, userID, patternID, p.TagId, recordIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	return opID, changed, txn.Commit()
+}
+
+// ListAccounts lists accounts once for each imported currency.
+func (db *Postgres) ListAccounts(household string) ([]*pb.Account, error) {
+	accounts := []*pb.Account{}
+	err := db.Select(&accounts, `SELECT DISTINCT account AS number, currency from imports
+		WHERE household_id = $1 ORDER BY number, currency`, household)
+	return accounts, err
+}
+
+// ListImports lists imported files ordered by ID.
+func (db *Postgres) ListImports(household string) ([]*Import, error) {
+	rows, err := db.Query(`SELECT id, COALESCE(filename, ''), account, currency FROM imports
+		WHERE household_id = $1 ORDER BY id`, household)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	imports := []*Import{}
+	for rows.Next() {
+		imp := &Import{}
+		if err := rows.Scan(&imp.ID, &imp.FileName, &imp.Account, &imp.Currency); err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+	return imports, rows.Err()
+}
+
+// ListTags lists tags ordered by name.
+func (db *Postgres) ListTags(household string) ([]*pb.Tag, error) {
+	tags := []*pb.Tag{}
+	err := db.Select(&tags, "SELECT id, name from tags WHERE household_id = $1 ORDER BY name", household)
+	return tags, err
+}
+
+// postgresTransactionColumns selects records as pb.Transaction fields.
+// Nullable columns are coalesced to empty strings so that scanning never
+// fails, e.g. for untagged records or bills without payment dates.
+var postgresTransactionColumns = []string{
+	"records.id",
+	"records.import_id",
+	`COALESCE(to_char(records.transaction_date, 'YYYY-MM-DD"T00:00:00Z"'), '') AS transaction_date`,
+	`COALESCE(to_char(records.value_date, 'YYYY-MM-DD"T00:00:00Z"'), '') AS value_date`,
+	`COALESCE(to_char(records.payment_date, 'YYYY-MM-DD"T00:00:00Z"'), '') AS payment_date`,
+	"records.amount",
+	"COALESCE(records.payee_payer, '') AS payee_payer",
+	"COALESCE(records.account, '') AS account",
+	"COALESCE(records.bic, '') AS bic",
+	"COALESCE(records.transaction, '') AS transaction",
+	"COALESCE(records.reference, '') AS reference",
+	"COALESCE(records.payer_reference, '') AS payer_reference",
+	"COALESCE(records.message, '') AS message",
+	"COALESCE(records.card_number, '') AS card_number",
+	"COALESCE(records.tag_id::text, '') AS tag_id",
+	"records.version",
+	"records.currency",
+}
+
+// ListTransactions lists records matching the filter, newest first.
+func (db *Postgres) ListTransactions(household string, f TransactionFilter) ([]*pb.Transaction, error) {
+	transactions := make([]*pb.Transaction, 0)
+	err := db.EachTransaction(household, f, func(t *pb.Transaction) error {
+		transactions = append(transactions, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// EachTransaction calls fn with the records matching the filter, newest
+// first, as they are read.
+func (db *Postgres) EachTransaction(household string, f TransactionFilter, fn func(*pb.Transaction) error) error {
+	return eachTransaction(db.loggedDB, postgresTransactionColumns, household, f, fn)
+}
+
+// UpdateTag sets the tag of a record at version. Empty tagID removes the tag.
+func (db *Postgres) UpdateTag(household, userID, recordID, tagID string, version int) (string, error) {
+	return updateTag(db.loggedDB, household, userID, recordID, tagID, version)
+}
+
+// BulkUpdateTag sets the tag of records by id or filter in one operation.
+func (db *Postgres) BulkUpdateTag(household, userID string, recordIDs []string, f *TransactionFilter, tagID string) (string, []string, error) {
+	return bulkUpdateTag(db.loggedDB, household, userID, recordIDs, f, tagID)
+}
+
+// ListTagHistory lists the tag changes of a record, newest first.
+func (db *Postgres) ListTagHistory(household, recordID string) ([]*TagChange, error) {
+	return listTagHistory(db.loggedDB, household, recordID)
+}
+
+// Undo reverts the tag changes of an operation.
+func (db *Postgres) Undo(household, userID, operationID string) (string, error) {
+	return undo(db.loggedDB, household, userID, operationID)
+}
+
+// AddTag stores a new tag.
+func (db *Postgres) AddTag(household, name string) (*pb.Tag, error) {
+	t := &pb.Tag{Name: name}
+	err := db.QueryRow("INSERT INTO tags (household_id, name) VALUES ($1, $2) RETURNING id", household, name).Scan(&t.Id)
+	return t, err
+}
+
+// CountUntagged returns the number of untagged records of each household.
+func (db *Postgres) CountUntagged() (map[string]int, error) {
+	return countUntagged(db.loggedDB)
+}
+
+// AddExchangeRates stores exchange rates, replacing any existing rate of the
+// same currency and date.
+func (db *Postgres) AddExchangeRates(rates []*pb.ExchangeRate) error {
+	txn, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	// Rates are copied to a temporary table first so that existing rates
+	// can be replaced with a single upsert.
+	const createTemp = "CREATE TEMPORARY TABLE exchange_rates_import (LIKE exchange_rates) ON COMMIT DROP"
+	if _, err := txn.Exec(createTemp); err != nil {
+		return err
+	}
+	stmt, err := txn.Prepare(pq.CopyIn("exchange_rates_import", "date", "currency", "rate"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range rates {
+		if _, err := stmt.Exec(r.Date, r.Currency, r.Rate); err != nil {
+			return err
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		return err
+	}
+	if err := stmt.Close(); err != nil {
+		return err
+	}
+	const upsert = `INSERT INTO exchange_rates (date, currency, rate)
+		SELECT DISTINCT ON (currency, date) date, currency, rate FROM exchange_rates_import
+		ON CONFLICT (currency, date) DO UPDATE SET rate = EXCLUDED.rate`
+	if _, err := txn.Exec(upsert); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// ExchangeRate implements exchangerate.Source with rates in the database.
+func (db *Postgres) ExchangeRate(code string, date time.Time) (exchangerate.Rate, error) {
+	rate := exchangerate.Rate{Currency: code}
+	const query = `SELECT date, rate FROM exchange_rates
+		WHERE currency = $1 AND date <= $2::date AND date >= $2::date - $3::int
+		ORDER BY date DESC LIMIT 1`
+	err := db.QueryRow(query, code, date, exchangerate.MaxFallback).Scan(&rate.Date, &rate.Rate)
+	if err == sql.ErrNoRows {
+		return rate, exchangerate.ErrNoRate
+	}
+	return rate, err
+}
+
+// AddUser stores a new user and sets its ID.
+func (db *Postgres) AddUser(u *User) error {
+	const insert = "INSERT INTO users (username, password_hash) VALUES ($1, $2) RETURNING id"
+	return db.QueryRow(insert, u.Username, u.PasswordHash).Scan(&u.ID)
+}
+
+// GetUser returns the user by username, or ErrNotFound.
+func (db *Postgres) GetUser(username string) (*User, error) {
+	u := &User{}
+	err := db.QueryRow("SELECT id, username, password_hash FROM users WHERE username = $1", username).
+		Scan(&u.ID, &u.Username, &u.PasswordHash)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return u, err
+}
+
+// SetPassword replaces the password hash of a user and deletes the sessions
+// of the user.
+func (db *Postgres) SetPassword(userID, passwordHash string) error {
+	txn, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+	res, err := txn.Exec("UPDATE users SET password_hash = $1 WHERE id = $2", passwordHash, userID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	if _, err := txn.Exec("DELETE FROM sessions WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// AddSession stores a new login session. Expired sessions are deleted.
+func (db *Postgres) AddSession(s *Session) error {
+	if _, err := db.Exec("DELETE FROM sessions WHERE expires_at < now()"); err != nil {
+		return err
+	}
+	_, err := db.Exec("INSERT INTO sessions (id, user_id, csrf_token, expires_at) VALUES ($1, $2, $3, $4)",
+		s.ID, s.UserID, s.CSRFToken, s.Expires)
+	return err
+}
+
+// GetSession returns the session by ID, or ErrNotFound if it does not exist
+// or has expired.
+func (db *Postgres) GetSession(id string) (*Session, error) {
+	s := &Session{}
+	const query = `SELECT sessions.id, user_id, username, csrf_token, expires_at
+		FROM sessions JOIN users ON sessions.user_id = users.id
+		WHERE sessions.id = $1 AND expires_at > now()`
+	err := db.QueryRow(query, id).Scan(&s.ID, &s.UserID, &s.Username, &s.CSRFToken, &s.Expires)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return s, err
+}
+
+// DeleteSession deletes a session, if it exists.
+func (db *Postgres) DeleteSession(id string) error {
+	_, err := db.Exec("DELETE FROM sessions WHERE id = $1", id)
+	return err
+}
+
+// AddHousehold stores a new household without members.
+func (db *Postgres) AddHousehold(name string) (*Household, error) {
+	h := &Household{Name: name}
+	err := db.QueryRow("INSERT INTO households (name) VALUES ($1) RETURNING id", name).Scan(&h.ID)
+	return h, err
+}
+
+// ListHouseholds lists the households of a user ordered by ID, with the role
+// of the user. Empty userID lists all households, without roles.
+func (db *Postgres) ListHouseholds(userID string) ([]*Household, error) {
+	query := "SELECT id, name, '' FROM households ORDER BY id"
+	var args []interface{}
+	if userID != "" {
+		query = `SELECT id, name, role FROM households
+			JOIN household_members ON households.id = household_members.household_id
+			WHERE user_id = $1 ORDER BY id`
+		args = append(args, userID)
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	households := []*Household{}
+	for rows.Next() {
+		h := &Household{}
+		if err := rows.Scan(&h.ID, &h.Name, &h.Role); err != nil {
+			return nil, err
+		}
+		households = append(households, h)
+	}
+	return households, rows.Err()
+}
+
+// GetRole returns the role of a user in a household, or ErrNotFound if the
+// user is not a member.
+func (db *Postgres) GetRole(household, userID string) (Role, error) {
+	var role Role
+	err := db.QueryRow("SELECT role FROM household_members WHERE household_id = $1 AND user_id = $2",
+		household, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return role, err
+}
+
+// ListMembers lists the members of a household ordered by username.
+func (db *Postgres) ListMembers(household string) ([]*Member, error) {
+	rows, err := db.Query(`SELECT user_id, username, role FROM household_members
+		JOIN users ON household_members.user_id = users.id
+		WHERE household_id = $1 ORDER BY username`, household)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := []*Member{}
+	for rows.Next() {
+		m := &Member{}
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Role); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// SetMember adds a user to a household or changes the role of a member.
+func (db *Postgres) SetMember(household, userID string, role Role) error {
+	_, err := db.Exec(`INSERT INTO household_members (household_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = EXCLUDED.role`,
+		household, userID, role)
+	return err
+}
+
+// RemoveMember removes a user from a household, or returns ErrNotFound.
+func (db *Postgres) RemoveMember(household, userID string) error {
+	res, err := db.Exec("DELETE FROM household_members WHERE household_id = $1 AND user_id = $2", household, userID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddToken stores a new API token and sets its ID and creation time.
+func (db *Postgres) AddToken(t *Token) error {
+	const insert = `INSERT INTO api_tokens (user_id, name, hash, scope) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+	return db.QueryRow(insert, t.UserID, t.Name, t.Hash, t.Scope).Scan(&t.ID, &t.Created)
+}
+
+// ListTokens lists the API tokens of a user ordered by ID.
+func (db *Postgres) ListTokens(userID string) ([]*Token, error) {
+	rows, err := db.Query(`SELECT api_tokens.id, user_id, username, name, hash, scope, api_tokens.created_at
+		FROM api_tokens JOIN users ON api_tokens.user_id = users.id
+		WHERE user_id = $1 ORDER BY api_tokens.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tokens := []*Token{}
+	for rows.Next() {
+		t := &Token{}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Username, &t.Name, &t.Hash, &t.Scope, &t.Created); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// GetToken returns the API token by hash, or ErrNotFound.
+func (db *Postgres) GetToken(hash string) (*Token, error) {
+	t := &Token{}
+	err := db.QueryRow(`SELECT api_tokens.id, user_id, username, name, hash, scope, api_tokens.created_at
+		FROM api_tokens JOIN users ON api_tokens.user_id = users.id
+		WHERE hash = $1`, hash).Scan(&t.ID, &t.UserID, &t.Username, &t.Name, &t.Hash, &t.Scope, &t.Created)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return t, err
+}
+
+// DeleteToken deletes an API token of a user, or returns ErrNotFound.
+func (db *Postgres) DeleteToken(userID, id string) error {
+	res, err := db.Exec("DELETE FROM api_tokens WHERE user_id = $1 AND id = $2", userID, id)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Backup returns the data of all tables except login sessions, read in one
+// snapshot of the database.
+func (db *Postgres) Backup() (*backup.Data, error) {
+	return sqlBackup(db.loggedDB, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// Restore stores backup data in an empty database with new IDs.
+func (db *Postgres) Restore(d *backup.Data) error {
+	return sqlRestore(db.loggedDB, d, func(t time.Time) interface{} { return t })
+}
@@ -1,6 +1,10 @@
 package database
 
-import "strings"
+import (
+	"strings"
+
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
 
 type SelectQuery struct {
 	Columns []string
@@ -31,3 +35,114 @@ func (q SelectQuery) SQL() string {
 func (q *SelectQuery) AndWhere(cond string) {
 	q.Where = append(q.Where, cond)
 }
+
+// transactionQuery returns a query selecting columns of the records of the
+// household matching the filter. The query uses ? placeholders, see
+// sqlx.DB.Rebind.
+func transactionQuery(columns []string, household string, f TransactionFilter) (string, []interface{}) {
+	query := &SelectQuery{
+		Columns: columns,
+		From: `records
+			JOIN imports ON records.import_id = imports.id`,
+		Where: []string{"imports.household_id = ?"},
+		// NULL dates sort first, like in PostgreSQL, in all databases.
+		OrderBy: "records.transaction_date IS NULL DESC, records.transaction_date DESC, records.id",
+	}
+	args := []interface{}{household}
+
+	if f.ID != "" {
+		query.AndWhere("records.id = ?")
+		args = append(args, f.ID)
+	}
+
+	if f.Account != "" {
+		query.AndWhere("imports.account = ?")
+		args = append(args, f.Account)
+	}
+
+	if !f.Month.IsZero() {
+		query.AndWhere("records.transaction_date BETWEEN ? AND ?")
+		args = append(args, f.Month.Format(dateFormat), f.Month.AddDate(0, 1, -1).Format(dateFormat))
+	}
+
+	if f.Query != "" {
+		query.AndWhere(`? IN (payee_payer, records.account, "transaction", reference, payer_reference, message)`)
+		args = append(args, f.Query)
+	}
+
+	return query.SQL(), args
+}
+
+// eachTransaction calls fn with the records of the household matching the
+// filter, selecting columns.
+func eachTransaction(db *loggedDB, columns []string, household string, f TransactionFilter, fn func(*pb.Transaction) error) error {
+	query, args := transactionQuery(columns, household, f)
+	rows, err := db.Queryx(db.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t pb.Transaction
+		if err := rows.StructScan(&t); err != nil {
+			return err
+		}
+		if err := fn(&t); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// importTagIDs returns the tag IDs of the records of an import.
+func importTagIDs(req *pb.AddImportReq) []string {
+	ids := make([]string, 0, len(req.Transactions))
+	for _, r := range req.Transactions {
+		ids = append(ids, r.TagId)
+	}
+	return ids
+}
+
+// checkTags returns ErrInvalidTag unless every tag ID is empty or refers to a
+// tag of the household.
+func checkTags(txn *loggedTx, household string, tagIDs ...string) error {
+	checked := make(map[string]bool)
+	for _, id := range tagIDs {
+		if id == "" || checked[id] {
+			continue
+		}
+		var count int
+		query := txn.Rebind("SELECT count(*) FROM tags WHERE id = ? AND household_id = ?")
+		if err := txn.Get(&count, query, id, household); err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrInvalidTag
+		}
+		checked[id] = true
+	}
+	return nil
+}
+
+// countUntagged returns the number of untagged records of each household,
+// see Storage.CountUntagged.
+func countUntagged(db *loggedDB) (map[string]int, error) {
+	rows, err := db.Query(`SELECT CAST(imports.household_id AS text), count(*)
+		FROM records JOIN imports ON imports.id = records.import_id
+		WHERE records.tag_id IS NULL GROUP BY imports.household_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var household string
+		var count int
+		if err := rows.Scan(&household, &count); err != nil {
+			return nil, err
+		}
+		counts[household] = count
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,556 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	// Load pure Go SQLite driver and embedded SQLite build
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+
+	"github.com/joneskoo/mymonies/pkg/backup"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// ConnectSQLite opens a SQLite database file, creating it if it does not
+// exist.
+func ConnectSQLite(filename string) (*SQLite, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("failed to connect to database: missing SQLite file name")
+	}
+	// Transactions take the write lock immediately so that concurrent
+	// writers wait for the busy timeout instead of failing on upgrade.
+	dsn := "file:" + filename + "?_pragma=busy_timeout(10000)&_pragma=foreign_keys(1)&_txlock=immediate"
+	db, err := sqlx.Connect("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %v", err)
+	}
+	// use JSON field name as database field name
+	db.Mapper = reflectx.NewMapperFunc("json", strings.ToLower)
+	m := migrator{
+		db:          db,
+		migrations:  sqliteMigrations,
+		createTable: sqliteSchemaMigrations,
+		hasTable:    sqliteHasSchemaMigrations,
+	}
+	return &SQLite{&loggedDB{DB: db}, m}, nil
+}
+
+// SQLite represents a SQLite database and implements data models.
+type SQLite struct {
+	*loggedDB
+	migrator
+}
+
+// Close closes the database.
+func (db *SQLite) Close() error { return db.DB.Close() }
+
+// WithContext returns the database logging queries with ctx.
+func (db *SQLite) WithContext(ctx context.Context) Storage {
+	return &SQLite{db.loggedDB.withContext(ctx), db.migrator}
+}
+
+// LogQueries logs queries to logger, at warning level if they take at
+// least slow.
+func (db *SQLite) LogQueries(logger *slog.Logger, slow time.Duration) {
+	db.logger, db.slow = logger, slow
+}
+
+// sqliteDate converts an RFC 3339 timestamp to a SQLite date.
+func sqliteDate(timestamp string) sql.NullString {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.Format(dateFormat), Valid: true}
+}
+
+// AddImport stores an imported file and its transaction records.
+func (db *SQLite) AddImport(household, userID string, req *pb.AddImportReq) (int, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Rollback()
+
+	if err := checkTags(txn, household, importTagIDs(req)...); err != nil {
+		return 0, err
+	}
+	res, err := txn.Exec("INSERT INTO imports (household_id, filename, account, currency) VALUES (?, ?, ?, ?)",
+		household, req.FileName, req.Account, req.Currency)
+	if err != nil {
+		return 0, err
+	}
+	importid, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	stmt, err := txn.Prepare(`INSERT INTO records (import_id, transaction_date,
+		value_date, payment_date, amount, payee_payer, account, bic,
+		"transaction", reference, payer_reference, message, card_number,
+		tag_id, currency) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+	for _, r := range req.Transactions {
+		_, err = stmt.Exec(
+			importid,
+			sqliteDate(r.TransactionDate),
+			sqliteDate(r.ValueDate),
+			sqliteDate(r.PaymentDate),
+			r.Amount,
+			r.PayeePayer,
+			r.Account,
+			r.Bic,
+			r.Transaction,
+			r.Reference,
+			r.PayerReference,
+			r.Message,
+			r.CardNumber,
+			sql.NullString{String: r.TagId, Valid: r.TagId != ""},
+			r.Currency)
+		if err != nil {
+			return 0, err
+		}
+	}
+	tagged, err := addImportTags(txn, household, userID, strconv.FormatInt(importid, 10))
+	if err != nil {
+		return 0, err
+	}
+	return tagged, txn.Commit()
+}
+
+// AddPattern stores a pattern and tags the untagged records by id.
+func (db *SQLite) AddPattern(household, userID string, p *pb.Pattern, recordIDs []string) (string, []string, error) {
+	txn, err := db.Beginx()
+	if err != nil {
+		return "", nil, err
+	}
+	defer txn.Rollback()
+	if err := checkTags(txn, household, p.TagId); err != nil {
+		return "", nil, err
+	}
+	res, err := txn.Exec("INSERT INTO patterns (household_id, account, query, tag_id) VALUES (?, ?, ?, ?)",
+		household, p.Account, p.Query, p.TagId)
+	if err != nil {
+		return "", nil, err
+	}
+	patternID, err := res.LastInsertId()
+	if err != nil {
+		return "", nil, err
+	}
+	opID, changed, err := addPatternTags(txn, household, userID, strconv.FormatInt(patternID, 10), p.TagId, recordIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	return opID, changed, txn.Commit()
+}
+
+// ListAccounts lists accounts once for each imported currency.
+func (db *SQLite) ListAccounts(household string) ([]*pb.Account, error) {
+	accounts := []*pb.Account{}
+	err := db.Select(&accounts, `SELECT DISTINCT account AS number, currency from imports
+		WHERE household_id = ? ORDER BY number, currency`, household)
+	return accounts, err
+}
+
+// ListImports lists imported files ordered by ID.
+func (db *SQLite) ListImports(household string) ([]*Import, error) {
+	rows, err := db.Query(`SELECT id, COALESCE(filename, ''), account, currency FROM imports
+		WHERE household_id = ? ORDER BY id`, household)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	imports := []*Import{}
+	for rows.Next() {
+		imp := &Import{}
+		if err := rows.Scan(&imp.ID, &imp.FileName, &imp.Account, &imp.Currency); err != nil {
+			return nil, err
+		}
+		imports = append(imports, imp)
+	}
+	return imports, rows.Err()
+}
+
+// ListTags lists tags ordered by name.
+func (db *SQLite) ListTags(household string) ([]*pb.Tag, error) {
+	tags := []*pb.Tag{}
+	err := db.Select(&tags, "SELECT id, name from tags WHERE household_id = ? ORDER BY name", household)
+	return tags, err
+}
+
+// sqliteTransactionColumns selects records as pb.Transaction fields, like
+// postgresTransactionColumns.
+var sqliteTransactionColumns = []string{
+	"records.id",
+	"records.import_id",
+	"COALESCE(records.transaction_date || 'T00:00:00Z', '') AS transaction_date",
+	"COALESCE(records.value_date || 'T00:00:00Z', '') AS value_date",
+	"COALESCE(records.payment_date || 'T00:00:00Z', '') AS payment_date",
+	"records.amount",
+	"COALESCE(records.payee_payer, '') AS payee_payer",
+	"COALESCE(records.account, '') AS account",
+	"COALESCE(records.bic, '') AS bic",
+	`COALESCE(records."transaction", '') AS "transaction"`,
+	"COALESCE(records.reference, '') AS reference",
+	"COALESCE(records.payer_reference, '') AS payer_reference",
+	"COALESCE(records.message, '') AS message",
+	"COALESCE(records.card_number, '') AS card_number",
+	"COALESCE(CAST(records.tag_id AS text), '') AS tag_id",
+	"records.version",
+	"records.currency",
+}
+
+// ListTransactions lists records matching the filter, newest first.
+func (db *SQLite) ListTransactions(household string, f TransactionFilter) ([]*pb.Transaction, error) {
+	transactions := make([]*pb.Transaction, 0)
+	err := db.EachTransaction(household, f, func(t *pb.Transaction) error {
+		transactions = append(transactions, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// EachTransaction calls fn with the records matching the filter, newest
+// first, as they are read.
+func (db *SQLite) EachTransaction(household string, f TransactionFilter, fn func(*pb.Transaction) error) error {
+	return eachTransaction(db.loggedDB, sqliteTransactionColumns, household, f, fn)
+}
+
+// UpdateTag sets the tag of a record at version. Empty tagID removes the tag.
+func (db *SQLite) UpdateTag(household, userID, recordID, tagID string, version int) (string, error) {
+	return updateTag(db.loggedDB, household, userID, recordID, tagID, version)
+}
+
+// BulkUpdateTag sets the tag of records by id or filter in one operation.
+func (db *SQLite) BulkUpdateTag(household, userID string, recordIDs []string, f *TransactionFilter, tagID string) (string, []string, error) {
+	return bulkUpdateTag(db.loggedDB, household, userID, recordIDs, f, tagID)
+}
+
+// ListTagHistory lists the tag changes of a record, newest first.
+func (db *SQLite) ListTagHistory(household, recordID string) ([]*TagChange, error) {
+	return listTagHistory(db.loggedDB, household, recordID)
+}
+
+// Undo reverts the tag changes of an operation.
+func (db *SQLite) Undo(household, userID, operationID string) (string, error) {
+	return undo(db.loggedDB, household, userID, operationID)
+}
+
+// AddTag stores a new tag.
+func (db *SQLite) AddTag(household, name string) (*pb.Tag, error) {
+	res, err := db.Exec("INSERT INTO tags (household_id, name) VALUES (?, ?)", household, name)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Tag{Id: strconv.FormatInt(id, 10), Name: name}, nil
+}
+
+// CountUntagged returns the number of untagged records of each household.
+func (db *SQLite) CountUntagged() (map[string]int, error) {
+	return countUntagged(db.loggedDB)
+}
+
+// AddExchangeRates stores exchange rates, replacing any existing rate of the
+// same currency and date.
+func (db *SQLite) AddExchangeRates(rates []*pb.ExchangeRate) error {
+	txn, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	stmt, err := txn.Prepare(`INSERT INTO exchange_rates (date, currency, rate) VALUES (?, ?, ?)
+		ON CONFLICT (currency, date) DO UPDATE SET rate = excluded.rate`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range rates {
+		if _, err := stmt.Exec(sqliteDate(r.Date), r.Currency, r.Rate); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// ExchangeRate implements exchangerate.Source with rates in the database.
+func (db *SQLite) ExchangeRate(code string, date time.Time) (exchangerate.Rate, error) {
+	rate := exchangerate.Rate{Currency: code}
+	const query = `SELECT date, rate FROM exchange_rates
+		WHERE currency = ? AND date <= ? AND date >= ?
+		ORDER BY date DESC LIMIT 1`
+	oldest := date.AddDate(0, 0, -exchangerate.MaxFallback)
+	err := db.QueryRow(query, code, date.Format(dateFormat), oldest.Format(dateFormat)).Scan(&rate.Date, &rate.Rate)
+	if err == sql.ErrNoRows {
+		return rate, exchangerate.ErrNoRate
+	}
+	return rate, err
+}
+
+// sqliteTime converts t to the format of SQLite timestamps.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// AddUser stores a new user and sets its ID.
+func (db *SQLite) AddUser(u *User) error {
+	res, err := db.Exec("INSERT INTO users (username, password_hash) VALUES (?, ?)", u.Username, u.PasswordHash)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	u.ID = strconv.FormatInt(id, 10)
+	return nil
+}
+
+// GetUser returns the user by username, or ErrNotFound.
+func (db *SQLite) GetUser(username string) (*User, error) {
+	u := &User{}
+	err := db.QueryRow("SELECT id, username, password_hash FROM users WHERE username = ?", username).
+		Scan(&u.ID, &u.Username, &u.PasswordHash)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return u, err
+}
+
+// SetPassword replaces the password hash of a user and deletes the sessions
+// of the user.
+func (db *SQLite) SetPassword(userID, passwordHash string) error {
+	txn, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+	res, err := txn.Exec("UPDATE users SET password_hash = ? WHERE id = ?", passwordHash, userID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	if _, err := txn.Exec("DELETE FROM sessions WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// AddSession stores a new login session. Expired sessions are deleted.
+func (db *SQLite) AddSession(s *Session) error {
+	if _, err := db.Exec("DELETE FROM sessions WHERE expires_at < ?", sqliteTime(time.Now())); err != nil {
+		return err
+	}
+	_, err := db.Exec("INSERT INTO sessions (id, user_id, csrf_token, expires_at) VALUES (?, ?, ?, ?)",
+		s.ID, s.UserID, s.CSRFToken, sqliteTime(s.Expires))
+	return err
+}
+
+// GetSession returns the session by ID, or ErrNotFound if it does not exist
+// or has expired.
+func (db *SQLite) GetSession(id string) (*Session, error) {
+	s := &Session{}
+	const query = `SELECT sessions.id, user_id, username, csrf_token, expires_at
+		FROM sessions JOIN users ON sessions.user_id = users.id
+		WHERE sessions.id = ? AND expires_at > ?`
+	err := db.QueryRow(query, id, sqliteTime(time.Now())).Scan(&s.ID, &s.UserID, &s.Username, &s.CSRFToken, &s.Expires)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return s, err
+}
+
+// DeleteSession deletes a session, if it exists.
+func (db *SQLite) DeleteSession(id string) error {
+	_, err := db.Exec("DELETE FROM sessions WHERE id = ?", id)
+	return err
+}
+
+// AddHousehold stores a new household without members.
+func (db *SQLite) AddHousehold(name string) (*Household, error) {
+	res, err := db.Exec("INSERT INTO households (name) VALUES (?)", name)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &Household{ID: strconv.FormatInt(id, 10), Name: name}, nil
+}
+
+// ListHouseholds lists the households of a user ordered by ID, with the role
+// of the user. Empty userID lists all households, without roles.
+func (db *SQLite) ListHouseholds(userID string) ([]*Household, error) {
+	query := "SELECT id, name, '' FROM households ORDER BY id"
+	var args []interface{}
+	if userID != "" {
+		query = `SELECT id, name, role FROM households
+			JOIN household_members ON households.id = household_members.household_id
+			WHERE user_id = ? ORDER BY id`
+		args = append(args, userID)
+	}
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	households := []*Household{}
+	for rows.Next() {
+		h := &Household{}
+		if err := rows.Scan(&h.ID, &h.Name, &h.Role); err != nil {
+			return nil, err
+		}
+		households = append(households, h)
+	}
+	return households, rows.Err()
+}
+
+// GetRole returns the role of a user in a household, or ErrNotFound if the
+// user is not a member.
+func (db *SQLite) GetRole(household, userID string) (Role, error) {
+	var role Role
+	err := db.QueryRow("SELECT role FROM household_members WHERE household_id = ? AND user_id = ?",
+		household, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return role, err
+}
+
+// ListMembers lists the members of a household ordered by username.
+func (db *SQLite) ListMembers(household string) ([]*Member, error) {
+	rows, err := db.Query(`SELECT user_id, username, role FROM household_members
+		JOIN users ON household_members.user_id = users.id
+		WHERE household_id = ? ORDER BY username`, household)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := []*Member{}
+	for rows.Next() {
+		m := &Member{}
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Role); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// SetMember adds a user to a household or changes the role of a member.
+func (db *SQLite) SetMember(household, userID string, role Role) error {
+	_, err := db.Exec(`INSERT INTO household_members (household_id, user_id, role) VALUES (?, ?, ?)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = excluded.role`,
+		household, userID, role)
+	return err
+}
+
+// RemoveMember removes a user from a household, or returns ErrNotFound.
+func (db *SQLite) RemoveMember(household, userID string) error {
+	res, err := db.Exec("DELETE FROM household_members WHERE household_id = ? AND user_id = ?", household, userID)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddToken stores a new API token and sets its ID and creation time.
+func (db *SQLite) AddToken(t *Token) error {
+	created := time.Now().UTC().Truncate(time.Second)
+	res, err := db.Exec("INSERT INTO api_tokens (user_id, name, hash, scope, created_at) VALUES (?, ?, ?, ?, ?)",
+		t.UserID, t.Name, t.Hash, t.Scope, sqliteTime(created))
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.ID = strconv.FormatInt(id, 10)
+	t.Created = created
+	return nil
+}
+
+// ListTokens lists the API tokens of a user ordered by ID.
+func (db *SQLite) ListTokens(userID string) ([]*Token, error) {
+	rows, err := db.Query(`SELECT api_tokens.id, user_id, username, name, hash, scope, api_tokens.created_at
+		FROM api_tokens JOIN users ON api_tokens.user_id = users.id
+		WHERE user_id = ? ORDER BY api_tokens.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tokens := []*Token{}
+	for rows.Next() {
+		t := &Token{}
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Username, &t.Name, &t.Hash, &t.Scope, &t.Created); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// GetToken returns the API token by hash, or ErrNotFound.
+func (db *SQLite) GetToken(hash string) (*Token, error) {
+	t := &Token{}
+	err := db.QueryRow(`SELECT api_tokens.id, user_id, username, name, hash, scope, api_tokens.created_at
+		FROM api_tokens JOIN users ON api_tokens.user_id = users.id
+		WHERE hash = ?`, hash).Scan(&t.ID, &t.UserID, &t.Username, &t.Name, &t.Hash, &t.Scope, &t.Created)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return t, err
+}
+
+// DeleteToken deletes an API token of a user, or returns ErrNotFound.
+func (db *SQLite) DeleteToken(userID, id string) error {
+	res, err := db.Exec("DELETE FROM api_tokens WHERE user_id = ? AND id = ?", userID, id)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count != 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Backup returns the data of all tables except login sessions.
+func (db *SQLite) Backup() (*backup.Data, error) {
+	return sqlBackup(db.loggedDB, nil)
+}
+
+// Restore stores backup data in an empty database with new IDs.
+func (db *SQLite) Restore(d *backup.Data) error {
+	return sqlRestore(db.loggedDB, d, func(t time.Time) interface{} { return sqliteTime(t) })
+}
@@ -0,0 +1,628 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	"github.com/joneskoo/mymonies/pkg/logging"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func newSQLite(t *testing.T) *SQLite {
+	dir, err := ioutil.TempDir("", "mymonies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	db, err := ConnectSQLite(filepath.Join(dir, "mymonies.db"))
+	if err != nil {
+		t.Fatal("ConnectSQLite() returned error:", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatal("db.Migrate() returned error:", err)
+	}
+	return db
+}
+
+// testStorages runs test for each storage implementation.
+func testStorages(t *testing.T, test func(t *testing.T, db Storage)) {
+	t.Run("memory", func(t *testing.T) { test(t, NewMemory()) })
+	t.Run("sqlite", func(t *testing.T) { test(t, newSQLite(t)) })
+}
+
+func TestStorage_transactions(t *testing.T) {
+	testStorages(t, testTransactions)
+}
+
+func testTransactions(t *testing.T, db Storage) {
+	const h = DefaultHousehold
+	_, err := db.AddImport(h, "", &pb.AddImportReq{
+		Account:  "FI1234",
+		FileName: "example.txt",
+		Currency: "EUR",
+		Transactions: []*pb.Transaction{
+			{TransactionDate: "2018-03-02T00:00:00Z", Amount: "-110.57", Currency: "SEK", PayeePayer: "Shop"},
+			{TransactionDate: "2018-04-01T00:00:00Z", Amount: "10.00", Currency: "EUR", Message: "Salary"},
+		},
+	})
+	if err != nil {
+		t.Fatal("db.AddImport() returned error:", err)
+	}
+	if _, err := db.AddTag(h, "groceries"); err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+
+	accounts, err := db.ListAccounts(h)
+	if err != nil {
+		t.Fatal("db.ListAccounts() returned error:", err)
+	}
+	if want := []*pb.Account{{Number: "FI1234", Currency: "EUR"}}; !reflect.DeepEqual(accounts, want) {
+		t.Errorf("db.ListAccounts() = %v, want %v", accounts, want)
+	}
+	imports, err := db.ListImports(h)
+	if err != nil {
+		t.Fatal("db.ListImports() returned error:", err)
+	}
+	if want := []*Import{{ID: "1", FileName: "example.txt", Account: "FI1234", Currency: "EUR"}}; !reflect.DeepEqual(imports, want) {
+		t.Errorf("db.ListImports() = %v, want %v", imports, want)
+	}
+
+	if _, err := db.UpdateTag(h, "", "1", "1", 1); err != nil {
+		t.Fatal("db.UpdateTag() returned error:", err)
+	}
+	if _, err := db.UpdateTag(h, "", "1", "", 1); err != ErrVersionConflict {
+		t.Errorf("db.UpdateTag() of changed record error = %v, want %v", err, ErrVersionConflict)
+	}
+	// Setting the same tag again changes the version only.
+	if _, err := db.UpdateTag(h, "", "1", "1", 2); err != nil {
+		t.Fatal("db.UpdateTag() to the same tag returned error:", err)
+	}
+	if _, err := db.UpdateTag(h, "", "1", "1", 2); err != ErrVersionConflict {
+		t.Errorf("db.UpdateTag() of record with new version error = %v, want %v", err, ErrVersionConflict)
+	}
+	if _, err := db.UpdateTag(h, "", "3", "1", 1); err != ErrNotFound {
+		t.Errorf("db.UpdateTag() of missing record error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := db.UpdateTag(h, "", "2", "2", 1); err != ErrInvalidTag {
+		t.Errorf("db.UpdateTag() to missing tag error = %v, want %v", err, ErrInvalidTag)
+	}
+
+	march := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := db.ListTransactions(h, TransactionFilter{Account: "FI1234", Month: march})
+	if err != nil {
+		t.Fatal("db.ListTransactions() returned error:", err)
+	}
+	want := []*pb.Transaction{{
+		Id:              "1",
+		ImportId:        "1",
+		TransactionDate: "2018-03-02T00:00:00Z",
+		Amount:          "-110.57",
+		Currency:        "SEK",
+		PayeePayer:      "Shop",
+		TagId:           "1",
+		Version:         3,
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("db.ListTransactions() = %v, want %v", got, want)
+	}
+
+	got, err = db.ListTransactions(h, TransactionFilter{Query: "Salary"})
+	if err != nil {
+		t.Fatal("db.ListTransactions() returned error:", err)
+	}
+	if len(got) != 1 || got[0].Id != "2" {
+		t.Errorf("db.ListTransactions() by query = %v, want transaction 2", got)
+	}
+
+	got, err = db.ListTransactions(h, TransactionFilter{})
+	if err != nil {
+		t.Fatal("db.ListTransactions() returned error:", err)
+	}
+	if len(got) != 2 || got[0].Id != "2" || got[1].Id != "1" {
+		t.Errorf("db.ListTransactions() = %v, want transactions 2, 1", got)
+	}
+
+	var ids []string
+	errStop := errors.New("stop")
+	err = db.EachTransaction(h, TransactionFilter{}, func(t *pb.Transaction) error {
+		ids = append(ids, t.Id)
+		return errStop
+	})
+	if err != errStop || !reflect.DeepEqual(ids, []string{"2"}) {
+		t.Errorf("db.EachTransaction() stopped with %v after %v, want stop after [2]", err, ids)
+	}
+
+	untagged, err := db.CountUntagged()
+	if err != nil {
+		t.Fatal("db.CountUntagged() returned error:", err)
+	}
+	if want := map[string]int{h: 1}; !reflect.DeepEqual(untagged, want) {
+		t.Errorf("db.CountUntagged() = %v, want %v", untagged, want)
+	}
+}
+
+func TestStorage_transactionOrder(t *testing.T) {
+	testStorages(t, func(t *testing.T, db Storage) {
+		_, err := db.AddImport(DefaultHousehold, "", &pb.AddImportReq{
+			Account:  "FI1234",
+			FileName: "example.txt",
+			Currency: "EUR",
+			Transactions: []*pb.Transaction{
+				{TransactionDate: "2018-03-01T00:00:00Z", Amount: "-1.00", Currency: "EUR"},
+				{Amount: "-2.00", Currency: "EUR"},
+				{TransactionDate: "2018-03-02T00:00:00Z", Amount: "-3.00", Currency: "EUR"},
+				{TransactionDate: "2018-03-01T00:00:00Z", Amount: "-4.00", Currency: "EUR"},
+			},
+		})
+		if err != nil {
+			t.Fatal("db.AddImport() returned error:", err)
+		}
+		got, err := db.ListTransactions(DefaultHousehold, TransactionFilter{})
+		if err != nil {
+			t.Fatal("db.ListTransactions() returned error:", err)
+		}
+		var ids []string
+		for _, tx := range got {
+			ids = append(ids, tx.Id)
+		}
+		// Records without a date first, then newest first and by ID.
+		if want := []string{"2", "3", "1", "4"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("db.ListTransactions() IDs = %v, want %v", ids, want)
+		}
+	})
+}
+
+func TestStorage_tagHistory(t *testing.T) {
+	testStorages(t, testTagHistory)
+}
+
+func testTagHistory(t *testing.T, db Storage) {
+	const h = DefaultHousehold
+	alice := &User{Username: "alice", PasswordHash: "x"}
+	if err := db.AddUser(alice); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	tag, err := db.AddTag(h, "groceries")
+	if err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	tagged, err := db.AddImport(h, alice.ID, &pb.AddImportReq{
+		Account:  "FI1234",
+		FileName: "example.txt",
+		Currency: "EUR",
+		Transactions: []*pb.Transaction{
+			{Amount: "-1.00", Currency: "EUR", TagId: tag.Id},
+			{Amount: "-2.00", Currency: "EUR"},
+			{Amount: "-3.00", Currency: "EUR"},
+		},
+	})
+	if err != nil || tagged != 1 {
+		t.Fatalf("db.AddImport() = %v, %v; want 1 tagged", tagged, err)
+	}
+	pattern, changed, err := db.AddPattern(h, alice.ID, &pb.Pattern{Account: "FI1234", TagId: tag.Id}, []string{"1", "2", "3"})
+	if err != nil {
+		t.Fatal("db.AddPattern() returned error:", err)
+	}
+	if want := []string{"2", "3"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("db.AddPattern() tagged %v, want %v", changed, want)
+	}
+	manual, err := db.UpdateTag(h, "", "3", "", 2)
+	if err != nil {
+		t.Fatal("db.UpdateTag() returned error:", err)
+	}
+
+	history, err := db.ListTagHistory(h, "3")
+	if err != nil {
+		t.Fatal("db.ListTagHistory() returned error:", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("db.ListTagHistory() returned %d changes, want 2", len(history))
+	}
+	if c := history[0]; c.OperationID != manual || c.Cause != CauseManual || c.OldTagID != tag.Id || c.NewTagID != "" || c.Username != "" {
+		t.Errorf("db.ListTagHistory()[0] = %+v, want manual removal of tag", c)
+	}
+	if c := history[1]; c.OperationID != pattern || c.Cause != CausePattern || c.PatternID != "1" || c.NewTagID != tag.Id || c.Username != "alice" || c.Created.IsZero() {
+		t.Errorf("db.ListTagHistory()[1] = %+v, want pattern by alice", c)
+	}
+	if history, _ := db.ListTagHistory(h, "1"); len(history) != 1 || history[0].Cause != CauseImport {
+		t.Errorf("db.ListTagHistory() of imported tag = %+v, want import", history)
+	}
+
+	// The pattern can't be undone while one of its records has changed.
+	if _, err := db.Undo(h, alice.ID, pattern); err != ErrUndoConflict {
+		t.Errorf("db.Undo() of changed operation error = %v, want %v", err, ErrUndoConflict)
+	}
+	if got, _ := db.ListTransactions(h, TransactionFilter{ID: "2"}); len(got) != 1 || got[0].TagId != tag.Id {
+		t.Errorf("failed db.Undo() changed record = %v", got)
+	}
+	if _, err := db.Undo(h, alice.ID, manual); err != nil {
+		t.Fatal("db.Undo() returned error:", err)
+	}
+	if _, err := db.Undo(h, alice.ID, manual); err != ErrUndoConflict {
+		t.Errorf("db.Undo() twice error = %v, want %v", err, ErrUndoConflict)
+	}
+	if _, err := db.Undo(h, alice.ID, pattern); err != nil {
+		t.Fatal("db.Undo() returned error:", err)
+	}
+	got, err := db.ListTransactions(h, TransactionFilter{})
+	if err != nil {
+		t.Fatal("db.ListTransactions() returned error:", err)
+	}
+	for _, r := range got {
+		if want := map[string]string{"1": tag.Id}[r.Id]; r.TagId != want {
+			t.Errorf("record %v tag after undo = %q, want %q", r.Id, r.TagId, want)
+		}
+	}
+	if history, _ := db.ListTagHistory(h, "2"); len(history) != 2 || history[0].Cause != CauseUndo || history[0].UndoOf != pattern || !history[1].Undone {
+		t.Errorf("db.ListTagHistory() after undo = %+v", history)
+	}
+	if _, err := db.Undo(h, alice.ID, "100"); err != ErrNotFound {
+		t.Errorf("db.Undo() of missing operation error = %v, want %v", err, ErrNotFound)
+	}
+
+	// Record 1 has the tag already.
+	if _, changed, err := db.BulkUpdateTag(h, alice.ID, []string{"1", "2"}, nil, tag.Id); err != nil || !reflect.DeepEqual(changed, []string{"2"}) {
+		t.Errorf("db.BulkUpdateTag() = %v, %v; want changed [2]", changed, err)
+	}
+	if _, changed, err := db.BulkUpdateTag(h, alice.ID, nil, &TransactionFilter{ID: "1"}, ""); err != nil || !reflect.DeepEqual(changed, []string{"1"}) {
+		t.Errorf("db.BulkUpdateTag() by filter = %v, %v; want changed [1]", changed, err)
+	}
+}
+
+func TestStorage_tokens(t *testing.T) {
+	testStorages(t, testTokens)
+}
+
+func testTokens(t *testing.T, db Storage) {
+	u := &User{Username: "alice", PasswordHash: "hash"}
+	if err := db.AddUser(u); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	tok := &Token{UserID: u.ID, Name: "nightly import", Hash: "hash", Scope: "import"}
+	if err := db.AddToken(tok); err != nil {
+		t.Fatal("db.AddToken() returned error:", err)
+	}
+	if tok.ID == "" || tok.Created.IsZero() {
+		t.Errorf("db.AddToken() did not set ID and creation time: %+v", tok)
+	}
+	got, err := db.GetToken("hash")
+	if err != nil {
+		t.Fatal("db.GetToken() returned error:", err)
+	}
+	want := *tok
+	want.Username = "alice"
+	if !got.Created.Equal(want.Created) {
+		t.Errorf("db.GetToken() created = %v, want %v", got.Created, want.Created)
+	}
+	got.Created = want.Created
+	if *got != want {
+		t.Errorf("db.GetToken() = %+v, want %+v", got, want)
+	}
+	if tokens, err := db.ListTokens(u.ID); err != nil || len(tokens) != 1 || tokens[0].ID != tok.ID {
+		t.Errorf("db.ListTokens() = %v, %v; want token %v", tokens, err, tok.ID)
+	}
+
+	if err := db.DeleteToken("2", tok.ID); err != ErrNotFound {
+		t.Errorf("db.DeleteToken() of other user error = %v, want %v", err, ErrNotFound)
+	}
+	if err := db.DeleteToken(u.ID, tok.ID); err != nil {
+		t.Fatal("db.DeleteToken() returned error:", err)
+	}
+	if _, err := db.GetToken("hash"); err != ErrNotFound {
+		t.Errorf("db.GetToken() after delete error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStorage_households(t *testing.T) {
+	testStorages(t, testHouseholds)
+}
+
+func testHouseholds(t *testing.T, db Storage) {
+	alice := &User{Username: "alice", PasswordHash: "hash"}
+	if err := db.AddUser(alice); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	other, err := db.AddHousehold("Other")
+	if err != nil {
+		t.Fatal("db.AddHousehold() returned error:", err)
+	}
+	if err := db.SetMember(other.ID, alice.ID, RoleViewer); err != nil {
+		t.Fatal("db.SetMember() returned error:", err)
+	}
+	if err := db.SetMember(other.ID, alice.ID, RoleEditor); err != nil {
+		t.Fatal("db.SetMember() returned error:", err)
+	}
+	households, err := db.ListHouseholds(alice.ID)
+	if err != nil {
+		t.Fatal("db.ListHouseholds() returned error:", err)
+	}
+	if want := []*Household{{ID: other.ID, Name: "Other", Role: RoleEditor}}; !reflect.DeepEqual(households, want) {
+		t.Errorf("db.ListHouseholds() = %v, want %v", households, want)
+	}
+	if households, err := db.ListHouseholds(""); err != nil || len(households) != 2 {
+		t.Errorf("db.ListHouseholds() of all = %v, %v; want 2 households", households, err)
+	}
+	if role, err := db.GetRole(DefaultHousehold, alice.ID); err != ErrNotFound {
+		t.Errorf("db.GetRole() of non-member = %v, %v; want %v", role, err, ErrNotFound)
+	}
+	members, err := db.ListMembers(other.ID)
+	if err != nil {
+		t.Fatal("db.ListMembers() returned error:", err)
+	}
+	if want := []*Member{{UserID: alice.ID, Username: "alice", Role: RoleEditor}}; !reflect.DeepEqual(members, want) {
+		t.Errorf("db.ListMembers() = %v, want %v", members, want)
+	}
+
+	// Tags and records of one household are not visible in another.
+	tag, err := db.AddTag(DefaultHousehold, "groceries")
+	if err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	if _, err := db.AddTag(other.ID, "groceries"); err != nil {
+		t.Fatal("db.AddTag() of same name in other household returned error:", err)
+	}
+	imp := &pb.AddImportReq{
+		Account:      "FI1234",
+		FileName:     "example.txt",
+		Currency:     "EUR",
+		Transactions: []*pb.Transaction{{Amount: "1.00", Currency: "EUR", TagId: tag.Id}},
+	}
+	if _, err := db.AddImport(other.ID, "", imp); err != ErrInvalidTag {
+		t.Errorf("db.AddImport() with tag of other household error = %v, want %v", err, ErrInvalidTag)
+	}
+	if _, err := db.AddImport(DefaultHousehold, "", imp); err != nil {
+		t.Fatal("db.AddImport() returned error:", err)
+	}
+	if got, err := db.ListTransactions(other.ID, TransactionFilter{}); err != nil || len(got) != 0 {
+		t.Errorf("db.ListTransactions() of other household = %v, %v; want none", got, err)
+	}
+	if got, err := db.ListAccounts(other.ID); err != nil || len(got) != 0 {
+		t.Errorf("db.ListAccounts() of other household = %v, %v; want none", got, err)
+	}
+	if got, err := db.ListImports(other.ID); err != nil || len(got) != 0 {
+		t.Errorf("db.ListImports() of other household = %v, %v; want none", got, err)
+	}
+	if got, err := db.ListTags(other.ID); err != nil || len(got) != 1 || got[0].Id == tag.Id {
+		t.Errorf("db.ListTags() of other household = %v, %v; want own tag", got, err)
+	}
+	if _, err := db.UpdateTag(other.ID, "", "1", "", 1); err != ErrNotFound {
+		t.Errorf("db.UpdateTag() of other household error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := db.RemoveMember(other.ID, alice.ID); err != nil {
+		t.Fatal("db.RemoveMember() returned error:", err)
+	}
+	if err := db.RemoveMember(other.ID, alice.ID); err != ErrNotFound {
+		t.Errorf("db.RemoveMember() of non-member error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStorage_ExchangeRate(t *testing.T) {
+	testStorages(t, testExchangeRate)
+}
+
+func testExchangeRate(t *testing.T, db Storage) {
+	rates := []*pb.ExchangeRate{
+		{Date: "2018-03-02T00:00:00Z", Currency: "SEK", Rate: "10.1"},
+		{Date: "2018-03-02T00:00:00Z", Currency: "SEK", Rate: "10.1565"},
+	}
+	if err := db.AddExchangeRates(rates); err != nil {
+		t.Fatal("db.AddExchangeRates() returned error:", err)
+	}
+
+	monday := time.Date(2018, 3, 5, 0, 0, 0, 0, time.UTC)
+	got, err := db.ExchangeRate("SEK", monday)
+	if err != nil {
+		t.Fatal("db.ExchangeRate() returned error:", err)
+	}
+	if got.Date.Format(dateFormat) != "2018-03-02" || got.Rate.String() != "10.1565" {
+		t.Errorf("db.ExchangeRate() = %v %v, want 2018-03-02 10.1565", got.Date, got.Rate)
+	}
+
+	if _, err := db.ExchangeRate("SEK", monday.AddDate(0, 1, 0)); err != exchangerate.ErrNoRate {
+		t.Errorf("db.ExchangeRate() without recent rate error = %v, want %v", err, exchangerate.ErrNoRate)
+	}
+}
+
+func TestStorage_sessions(t *testing.T) {
+	testStorages(t, testSessions)
+}
+
+func testSessions(t *testing.T, db Storage) {
+	u := &User{Username: "alice", PasswordHash: "hash"}
+	if err := db.AddUser(u); err != nil {
+		t.Fatal("db.AddUser() returned error:", err)
+	}
+	if err := db.AddUser(&User{Username: "alice", PasswordHash: "other"}); err == nil {
+		t.Error("db.AddUser() with existing username returned no error")
+	}
+	got, err := db.GetUser("alice")
+	if err != nil {
+		t.Fatal("db.GetUser() returned error:", err)
+	}
+	if *got != *u {
+		t.Errorf("db.GetUser() = %+v, want %+v", got, u)
+	}
+	if _, err := db.GetUser("bob"); err != ErrNotFound {
+		t.Errorf("db.GetUser() for missing user error = %v, want %v", err, ErrNotFound)
+	}
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	if err := db.AddSession(&Session{ID: "s1", UserID: u.ID, CSRFToken: "csrf", Expires: expires}); err != nil {
+		t.Fatal("db.AddSession() returned error:", err)
+	}
+	expired := &Session{ID: "s2", UserID: u.ID, CSRFToken: "csrf", Expires: time.Now().Add(-time.Hour)}
+	if err := db.AddSession(expired); err != nil {
+		t.Fatal("db.AddSession() returned error:", err)
+	}
+	s, err := db.GetSession("s1")
+	if err != nil {
+		t.Fatal("db.GetSession() returned error:", err)
+	}
+	want := Session{ID: "s1", UserID: u.ID, Username: "alice", CSRFToken: "csrf", Expires: expires}
+	if !s.Expires.Equal(want.Expires) {
+		t.Errorf("db.GetSession() expires = %v, want %v", s.Expires, want.Expires)
+	}
+	s.Expires = want.Expires
+	if *s != want {
+		t.Errorf("db.GetSession() = %+v, want %+v", s, want)
+	}
+	if _, err := db.GetSession("s2"); err != ErrNotFound {
+		t.Errorf("db.GetSession() for expired session error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := db.DeleteSession("s1"); err != nil {
+		t.Fatal("db.DeleteSession() returned error:", err)
+	}
+	if _, err := db.GetSession("s1"); err != ErrNotFound {
+		t.Errorf("db.GetSession() after delete error = %v, want %v", err, ErrNotFound)
+	}
+
+	if err := db.AddSession(&Session{ID: "s3", UserID: u.ID, CSRFToken: "csrf", Expires: expires}); err != nil {
+		t.Fatal("db.AddSession() returned error:", err)
+	}
+	if err := db.SetPassword(u.ID, "new hash"); err != nil {
+		t.Fatal("db.SetPassword() returned error:", err)
+	}
+	if _, err := db.GetSession("s3"); err != ErrNotFound {
+		t.Errorf("db.GetSession() after password change error = %v, want %v", err, ErrNotFound)
+	}
+	if got, err := db.GetUser("alice"); err != nil || got.PasswordHash != "new hash" {
+		t.Errorf("db.GetUser() after password change = %+v, %v", got, err)
+	}
+}
+
+// TestSQLite_setTagVersion tests that a tag is not set if the record was
+// changed after reading it, even if its tag is the same again.
+func TestMemory_MigrateTo(t *testing.T) {
+	db := NewMemory()
+	if _, err := db.AddTag(DefaultHousehold, "groceries"); err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	if err := db.MigrateTo(0); err != nil {
+		t.Fatal("db.MigrateTo(0) returned error:", err)
+	}
+	if tags, err := db.ListTags(DefaultHousehold); err != nil || len(tags) != 0 {
+		t.Errorf("db.ListTags() after db.MigrateTo(0) = %v, %v; want no tags", tags, err)
+	}
+	if _, err := db.AddTag(DefaultHousehold, "groceries"); err != nil {
+		t.Errorf("db.AddTag() after db.MigrateTo(0) returned error: %v", err)
+	}
+}
+
+func TestSQLite_setTagVersion(t *testing.T) {
+	db := newSQLite(t)
+	const h = DefaultHousehold
+	_, err := db.AddImport(h, "", &pb.AddImportReq{
+		Account:      "FI1234",
+		Currency:     "EUR",
+		Transactions: []*pb.Transaction{{TransactionDate: "2018-03-02T00:00:00Z", Amount: "1.00", Currency: "EUR"}},
+	})
+	if err != nil {
+		t.Fatal("db.AddImport() returned error:", err)
+	}
+	if _, err := db.AddTag(h, "groceries"); err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	txn, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txn.Rollback()
+	tagID, version, err := recordTag(txn, h, "1")
+	if err != nil {
+		t.Fatal("recordTag() returned error:", err)
+	}
+	// A concurrent change and change back of the tag.
+	if _, err := txn.Exec(`UPDATE records SET version = version + 2 WHERE id = 1`); err != nil {
+		t.Fatal(err)
+	}
+	opID, err := addOperation(txn, tagOperation{household: h, cause: CauseManual})
+	if err != nil {
+		t.Fatal("addOperation() returned error:", err)
+	}
+	if ok, err := setTag(txn, h, opID, "1", tagID, "1", version); err != nil || ok {
+		t.Errorf("setTag() of changed record = %v, %v; want false", ok, err)
+	}
+	if ok, err := setTag(txn, h, opID, "1", tagID, "1", version+2); err != nil || !ok {
+		t.Errorf("setTag() of current version = %v, %v; want true", ok, err)
+	}
+}
+
+func TestSQLite_Rollback(t *testing.T) {
+	db := newSQLite(t)
+	if err := db.Rollback(len(sqliteMigrations)); err != nil {
+		t.Fatal("db.Rollback() returned error:", err)
+	}
+	if version, err := db.SchemaVersion(); err != nil || version != 0 {
+		t.Errorf("db.SchemaVersion() = %v, %v; want 0", version, err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatal("db.Migrate() after rollback returned error:", err)
+	}
+}
+
+func TestSQLite_MigrationStatus(t *testing.T) {
+	db, err := ConnectSQLite(filepath.Join(t.TempDir(), "mymonies.db"))
+	if err != nil {
+		t.Fatal("ConnectSQLite() returned error:", err)
+	}
+	defer db.Close()
+	status, err := db.MigrationStatus()
+	if err != nil {
+		t.Fatal("db.MigrationStatus() returned error:", err)
+	}
+	if len(status) != len(sqliteMigrations) || status[0].Applied {
+		t.Errorf("db.MigrationStatus() = %v, want no migrations applied", status)
+	}
+	var tables int
+	if err := db.Get(&tables, "SELECT count(*) FROM sqlite_master WHERE name = 'schema_migrations'"); err != nil || tables != 0 {
+		t.Errorf("schema_migrations tables after db.MigrationStatus() = %v, %v; want 0", tables, err)
+	}
+}
+
+func TestSQLite_LogQueries(t *testing.T) {
+	db := newSQLite(t)
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "text", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.LogQueries(logger, 0)
+	ctx := logging.NewContext(context.Background(), "req1")
+	if _, err := db.WithContext(ctx).ListTags(DefaultHousehold); err != nil {
+		t.Fatal("db.ListTags() returned error:", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `level=DEBUG msg="SQL query" query="SELECT id, name from tags`) || !strings.Contains(got, "request_id=req1") {
+		t.Errorf("log = %q, want debug SQL query with request ID", got)
+	}
+
+	buf.Reset()
+	db.LogQueries(logger, time.Nanosecond)
+	if _, err := db.AddTag(DefaultHousehold, "groceries"); err != nil {
+		t.Fatal("db.AddTag() returned error:", err)
+	}
+	if got := buf.String(); !strings.Contains(got, `level=WARN msg="slow SQL query"`) || strings.Contains(got, "request_id") {
+		t.Errorf("log = %q, want slow SQL query warning without request ID", got)
+	}
+
+	buf.Reset()
+	rates := []*pb.ExchangeRate{{Date: "2018-01-02T00:00:00Z", Currency: "USD", Rate: "1.2065"}}
+	if err := db.WithContext(ctx).AddExchangeRates(rates); err != nil {
+		t.Fatal("db.AddExchangeRates() returned error:", err)
+	}
+	if got := buf.String(); !strings.Contains(got, `query="INSERT INTO exchange_rates`) || !strings.Contains(got, "request_id=req1") {
+		t.Errorf("log = %q, want prepared statement logged with request ID", got)
+	}
+}
@@ -0,0 +1,103 @@
+package mymoniesserver
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// OpenDemo returns an in-memory database with example data. Any changes are
+// lost when the server exits.
+func OpenDemo(logger *slog.Logger) (database.Storage, error) {
+	db := database.NewMemory()
+	if err := addDemoData(db, time.Now()); err != nil {
+		return nil, err
+	}
+	logger.Info("using in-memory demo database")
+	return db, nil
+}
+
+// addDemoData stores three months of example transactions until now.
+// The data is the same for every run on the same day.
+func addDemoData(db database.Storage, now time.Time) error {
+	tags := make(map[string]string)
+	for _, name := range []string{"groceries", "restaurants", "rent", "salary", "travel"} {
+		t, err := db.AddTag(database.DefaultHousehold, name)
+		if err != nil {
+			return err
+		}
+		tags[name] = t.Id
+	}
+
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -3, 0)
+	rnd := rand.New(rand.NewSource(start.Unix()))
+	amount := func(min, max int) string {
+		cents := min*100 + rnd.Intn((max-min)*100)
+		return fmt.Sprintf("-%d.%02d", cents/100, cents%100)
+	}
+
+	account := &mymonies.AddImportReq{
+		Account:  "FI21 1234 5600 0007 85",
+		FileName: "demo-account.txt",
+		Currency: "EUR",
+	}
+	card := &mymonies.AddImportReq{
+		Account:  "4920 0000 0000 0000",
+		FileName: "demo-card.pdf",
+		Currency: "EUR",
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.RFC3339)
+		tx := func(imp *mymonies.AddImportReq, payee, amount, currency, tag string) {
+			imp.Transactions = append(imp.Transactions, &mymonies.Transaction{
+				TransactionDate: date,
+				ValueDate:       date,
+				PaymentDate:     date,
+				PayeePayer:      payee,
+				Amount:          amount,
+				Currency:        currency,
+				TagId:           tags[tag],
+			})
+		}
+		switch {
+		case d.Day() == 1:
+			tx(account, "Kiinteistö Oy Esimerkki", "-950.00", "EUR", "rent")
+		case d.Day() == 15:
+			tx(account, "Example Employer Oy", "3200.00", "EUR", "salary")
+		}
+		if d.Weekday() == time.Saturday {
+			tx(card, "K-Market", amount(40, 120), "EUR", "groceries")
+		}
+		if rnd.Intn(5) == 0 {
+			tx(card, "Ravintola Demo", amount(10, 40), "EUR", "restaurants")
+		}
+		// A trip to Stockholm each month, paid in kronor.
+		if d.Day() >= 8 && d.Day() <= 9 && d.Weekday() != time.Sunday {
+			tx(card, "Hotell Exempel", amount(900, 1500), "SEK", "travel")
+			tx(card, "Pressbyrån", amount(30, 120), "SEK", "")
+		}
+	}
+
+	// Exchange rates are published on weekdays, so the first weekend
+	// needs rates from before the first transaction.
+	var rates []*mymonies.ExchangeRate
+	for d := start.AddDate(0, 0, -exchangerate.MaxFallback); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			rate := fmt.Sprintf("11.%04d", 1000+rnd.Intn(3000))
+			rates = append(rates, &mymonies.ExchangeRate{Date: d.Format(time.RFC3339), Currency: "SEK", Rate: rate})
+		}
+	}
+
+	for _, imp := range []*mymonies.AddImportReq{account, card} {
+		if _, err := db.AddImport(database.DefaultHousehold, "", imp); err != nil {
+			return err
+		}
+	}
+	return db.AddExchangeRates(rates)
+}
@@ -0,0 +1,31 @@
+package mymoniesserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func Test_addDemoData(t *testing.T) {
+	// Every day of a year, so that each weekday and month length is covered.
+	start := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	for now := start; now.Before(start.AddDate(1, 0, 0)); now = now.AddDate(0, 0, 1) {
+		db := database.NewMemory()
+		if err := addDemoData(db, now); err != nil {
+			t.Fatalf("addDemoData(%v) returned error: %v", now, err)
+		}
+		s := &server{DB: db, logger: logging.Discard()}
+		req := &pb.ListTotalsReq{Filter: &pb.TransactionFilter{}, BaseCurrency: "EUR"}
+		resp, err := s.ListTotals(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListTotals() of demo data on %v returned error: %v", now, err)
+		}
+		if len(resp.Totals) == 0 {
+			t.Fatalf("ListTotals() of demo data on %v returned no totals", now)
+		}
+	}
+}
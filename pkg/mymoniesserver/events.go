@@ -0,0 +1,82 @@
+// This file contains the server-sent events endpoint pushing live updates
+// of data changes to the browser.
+
+package mymoniesserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/events"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// EventsPath is the path of the server-sent events endpoint.
+const EventsPath = "/events"
+
+// keepaliveInterval is the interval of comments sent to idle event streams,
+// so that proxies don't close them.
+const keepaliveInterval = 30 * time.Second
+
+// Events returns a handler streaming the change events published to hub as
+// server-sent events. The household is selected like for RPC requests, or
+// with the household query parameter, since browsers can't set headers of
+// EventSource requests. Viewers can subscribe to the events.
+func Events(db database.Storage, hub *events.Hub) http.Handler {
+	s := &server{DB: db, Events: hub}
+	return http.HandlerFunc(s.serveEvents)
+}
+
+func (s *server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if id := r.URL.Query().Get("household"); id != "" {
+		ctx = context.WithValue(ctx, householdKey{}, id)
+	}
+	household, err := s.household(ctx, database.RoleViewer)
+	if err != nil {
+		writeError(w, err.(twirp.Error))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, cancel := s.Events.Subscribe(household)
+	defer cancel()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disable response buffering of nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-ch:
+			if !ok {
+				// Fell behind or the server is shutting down; the
+				// client reconnects and reloads.
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		}
+		flusher.Flush()
+	}
+}
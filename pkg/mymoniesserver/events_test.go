@@ -0,0 +1,53 @@
+package mymoniesserver
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/events"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func Test_server_serveEvents(t *testing.T) {
+	s := newServer(t, "testdata/update-tag/data.json")
+	s.Events = events.NewHub()
+	srv := httptest.NewServer(http.HandlerFunc(s.serveEvents))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + EventsPath)
+	if err != nil {
+		t.Fatal("GET events returned error:", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	body := bufio.NewReader(resp.Body)
+	// The stream starts with the reconnection delay, after subscribing.
+	if line, err := body.ReadString('\n'); err != nil || !strings.HasPrefix(line, "retry:") {
+		t.Fatalf("first line = %q, %v; want retry", line, err)
+	}
+
+	_, err = s.UpdateTag(context.Background(), &pb.UpdateTagReq{TransactionId: "1", TagId: "2", Version: 1})
+	if err != nil {
+		t.Fatal("UpdateTag() returned error:", err)
+	}
+	var lines []string
+	for len(lines) < 2 || lines[len(lines)-1] != "" {
+		line, err := body.ReadString('\n')
+		if err != nil {
+			t.Fatal("reading event returned error:", err)
+		}
+		if line = strings.TrimSuffix(line, "\n"); line != "" || len(lines) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	want := []string{"event: tag", `data: {"type":"tag","transaction_ids":["1"],"operation_id":"2"}`, ""}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("event = %q, want %q", lines, want)
+	}
+}
@@ -0,0 +1,130 @@
+// This file contains the export endpoint streaming transactions in file
+// formats for other tools.
+
+package mymoniesserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/export"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// ExportPath is the path of the export endpoint.
+const ExportPath = "/export"
+
+// Export returns a handler exporting the transactions of the household in
+// the format of the format query parameter, csv by default, see
+// export.Formats. The transactions are selected with the id, account, month
+// and query parameters like TransactionFilter. CSV exports take the
+// delimiter and decimal_comma parameters, see export.Options. Errors after
+// the response has started are logged to logger and abort the response, so
+// that the client does not take a truncated export for a complete one.
+func Export(db database.Storage, logger *slog.Logger) http.Handler {
+	s := &server{DB: db, logger: logger}
+	return http.HandlerFunc(s.serveExport)
+}
+
+func (s *server) serveExport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	name := q.Get("format")
+	if name == "" {
+		name = "csv"
+	}
+	format, ok := export.Formats[name]
+	if !ok {
+		writeError(w, twirp.InvalidArgumentError("format", "unknown export format"))
+		return
+	}
+	var opts export.Options
+	if d := q.Get("delimiter"); d != "" {
+		if utf8.RuneCountInString(d) != 1 {
+			writeError(w, twirp.InvalidArgumentError("delimiter", "must be one character"))
+			return
+		}
+		opts.Delimiter, _ = utf8.DecodeRuneInString(d)
+	}
+	if dc := q.Get("decimal_comma"); dc != "" {
+		var err error
+		if opts.DecimalComma, err = strconv.ParseBool(dc); err != nil {
+			writeError(w, twirp.InvalidArgumentError("decimal_comma", err.Error()))
+			return
+		}
+	}
+	filter, err := transactionFilter(&pb.TransactionFilter{
+		Id:      q.Get("id"),
+		Account: q.Get("account"),
+		Month:   q.Get("month"),
+		Query:   q.Get("query"),
+	})
+	if err != nil {
+		writeError(w, err.(twirp.Error))
+		return
+	}
+	household, err := s.household(r.Context(), database.RoleViewer)
+	if err != nil {
+		writeError(w, err.(twirp.Error))
+		return
+	}
+	// The records are written as they are read from storage; only the
+	// journal formats keep them to sort them. Errors can be reported
+	// until the first record is written.
+	var ew export.Writer
+	start := func() {
+		w.Header().Set("Content-Type", format.ContentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="mymonies`+format.Extension+`"`)
+		ew = format.New(w, opts)
+	}
+	err = s.exportRecords(r.Context(), household, filter, func(rec *export.Record) error {
+		if ew == nil {
+			start()
+		}
+		return ew.Write(rec)
+	})
+	if err != nil && ew == nil {
+		writeError(w, twirp.InternalErrorWith(err))
+		return
+	}
+	if ew == nil {
+		start()
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		s.logger.ErrorContext(r.Context(), "export failed after the response started", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// exportRecords calls fn with the transactions of the household matching
+// filter with their tag names and imports, newest first, as they are read.
+func (s *server) exportRecords(ctx context.Context, household string, filter database.TransactionFilter, fn func(*export.Record) error) error {
+	tags, err := s.db(ctx).ListTags(household)
+	if err != nil {
+		return err
+	}
+	imports, err := s.db(ctx).ListImports(household)
+	if err != nil {
+		return err
+	}
+	tagNames := make(map[string]string)
+	for _, t := range tags {
+		tagNames[t.Id] = t.Name
+	}
+	importsByID := make(map[string]*database.Import)
+	for _, imp := range imports {
+		importsByID[imp.ID] = imp
+	}
+	return s.db(ctx).EachTransaction(household, filter, func(t *pb.Transaction) error {
+		t.Amount = formatAmount(t.Amount, t.Currency)
+		return fn(&export.Record{Transaction: t, Tag: tagNames[t.TagId], Import: importsByID[t.ImportId]})
+	})
+}
@@ -0,0 +1,88 @@
+package mymoniesserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func Test_server_serveExport(t *testing.T) {
+	s := newServer(t, "testdata/list-totals/data.json")
+	tests := []struct {
+		query      string
+		wantStatus int
+		want       string
+	}{
+		{
+			query:      "?format=jsonl&month=2018-03",
+			wantStatus: http.StatusOK,
+			want: `{"id":"2","transaction_date":"2018-03-05","amount":"110.57","currency":"SEK","payee_payer":"krona payee","tag_id":"1","tag":"example","import":{"id":"1","file_name":"asdf","account":"foo","currency":"EUR"}}` + "\n" +
+				`{"id":"1","transaction_date":"2018-03-01","amount":"10.00","currency":"EUR","payee_payer":"euro payee","tag_id":"1","tag":"example","import":{"id":"1","file_name":"asdf","account":"foo","currency":"EUR"}}` + "\n",
+		},
+		{
+			query:      "?query=untagged+payee&decimal_comma=true",
+			wantStatus: http.StatusOK,
+			want: "id;transaction_date;value_date;payment_date;amount;currency;payee_payer;account;bic;transaction;reference;payer_reference;message;card_number;tag_id;tag;import_id;import_file_name;import_account\n" +
+				"3;2018-04-02;;;-5,50;EUR;untagged payee;;;;;;;;;;1;asdf;foo\n",
+		},
+		{query: "?format=xls", wantStatus: http.StatusBadRequest},
+		{query: "?delimiter=ab", wantStatus: http.StatusBadRequest},
+		{query: "?month=march", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.serveExport(rec, httptest.NewRequest("GET", ExportPath+tt.query, nil))
+		if rec.Code != tt.wantStatus {
+			t.Errorf("GET %v status = %v, want %v", tt.query, rec.Code, tt.wantStatus)
+			continue
+		}
+		body, _ := ioutil.ReadAll(rec.Body)
+		if tt.wantStatus == http.StatusOK && string(body) != tt.want {
+			t.Errorf("GET %v =\n%s\nwant\n%s", tt.query, body, tt.want)
+		}
+	}
+}
+
+// failingStorage fails listing transactions after the first one.
+type failingStorage struct {
+	database.Storage
+}
+
+func (db failingStorage) WithContext(ctx context.Context) database.Storage { return db }
+
+func (db failingStorage) EachTransaction(household string, f database.TransactionFilter, fn func(*pb.Transaction) error) error {
+	if err := fn(&pb.Transaction{Id: "1", Amount: "1.00", Currency: "EUR"}); err != nil {
+		return err
+	}
+	return errors.New("connection lost")
+}
+
+func Test_server_serveExport_error(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "text", "info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server{DB: failingStorage{database.NewMemory()}, logger: logger}
+	rec := httptest.NewRecorder()
+	func() {
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("serveExport() panicked with %v, want %v", r, http.ErrAbortHandler)
+			}
+		}()
+		s.serveExport(rec, httptest.NewRequest("GET", ExportPath+"?format=jsonl", nil))
+	}()
+	if got := buf.String(); !strings.Contains(got, "export failed") || !strings.Contains(got, "connection lost") {
+		t.Errorf("log = %q, want export error", got)
+	}
+}
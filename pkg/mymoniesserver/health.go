@@ -0,0 +1,108 @@
+// This file contains the liveness and readiness probes of the server, e.g.
+// for Kubernetes or a load balancer.
+
+package mymoniesserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// Paths of the probes.
+const (
+	HealthPath = "/healthz"
+	ReadyPath  = "/readyz"
+)
+
+// pingTimeout limits the database ping of readiness probes.
+const pingTimeout = 2 * time.Second
+
+// Health returns a handler of liveness probes. The server is alive as long
+// as it serves requests.
+func Health() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, nil)
+	})
+}
+
+// Ready returns a handler of readiness probes. The server is ready to serve
+// requests when the database answers a ping and all schema migrations are
+// applied, until shutdown is done, i.e. the server is shutting down.
+func Ready(shutdown context.Context, db database.Storage) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checks := map[string]string{
+			"database":   "ok",
+			"migrations": "ok",
+		}
+		ready := true
+		fail := func(check string, err error) {
+			checks[check] = err.Error()
+			ready = false
+		}
+		if shutdown.Err() != nil {
+			fail("server", fmt.Errorf("shutting down"))
+		}
+		if p, ok := db.(pinger); ok {
+			ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+			defer cancel()
+			if err := p.PingContext(ctx); err != nil {
+				fail("database", err)
+			}
+		}
+		if checks["database"] == "ok" {
+			if err := migrated(db); err != nil {
+				fail("migrations", err)
+			}
+		}
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+		writeStatus(w, status, checks)
+	})
+}
+
+// pinger is implemented by storages with a database server connection,
+// i.e. *sqlx.DB.
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// migrated returns an error if any schema migration is not applied.
+func migrated(db database.Storage) error {
+	status, err := db.MigrationStatus()
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, m := range status {
+		if !m.Applied {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending", pending)
+	}
+	return nil
+}
+
+// writeStatus writes the JSON response of a probe, with the results of the
+// checks, if any.
+func writeStatus(w http.ResponseWriter, status int, checks map[string]string) {
+	resp := struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks,omitempty"`
+	}{"ok", checks}
+	if status != http.StatusOK {
+		resp.Status = "unavailable"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
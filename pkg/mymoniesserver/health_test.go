@@ -0,0 +1,67 @@
+package mymoniesserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+)
+
+// pendingStorage is a storage with a schema migration pending.
+type pendingStorage struct {
+	database.Storage
+}
+
+func (pendingStorage) MigrationStatus() ([]database.MigrationStatus, error) {
+	return []database.MigrationStatus{{Version: 1, Applied: true}, {Version: 2}}, nil
+}
+
+// downStorage is a storage of a database that does not answer.
+type downStorage struct {
+	database.Storage
+}
+
+func (downStorage) PingContext(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func TestReady(t *testing.T) {
+	shuttingDown, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name       string
+		shutdown   context.Context
+		db         database.Storage
+		wantStatus int
+		wantBody   string
+	}{
+		{"ready", context.Background(), database.NewMemory(), http.StatusOK, `"status":"ok"`},
+		{"pending migrations", context.Background(), pendingStorage{database.NewMemory()}, http.StatusServiceUnavailable, `"migrations":"1 migrations pending"`},
+		{"database down", context.Background(), downStorage{database.NewMemory()}, http.StatusServiceUnavailable, `"database":"connection refused"`},
+		{"shutting down", shuttingDown, database.NewMemory(), http.StatusServiceUnavailable, `"server":"shutting down"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Ready(tt.shutdown, tt.db).ServeHTTP(w, httptest.NewRequest("GET", ReadyPath, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if body := w.Body.String(); !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	w := httptest.NewRecorder()
+	Health().ServeHTTP(w, httptest.NewRequest("GET", HealthPath, nil))
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"status":"ok"}` {
+		t.Errorf("response = %v %q, want 200 ok", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,79 @@
+// This file contains the tag history rpc methods.
+
+package mymoniesserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/events"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// userID returns the ID of the authenticated user for the tag audit log, or
+// an empty string if authentication is disabled.
+func userID(ctx context.Context) string {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		return user.ID
+	}
+	return ""
+}
+
+// ListTagHistory lists the tag changes of a transaction, newest first.
+func (s *server) ListTagHistory(ctx context.Context, req *pb.ListTagHistoryReq) (*pb.ListTagHistoryResp, error) {
+	if req.TransactionId == "" {
+		return nil, twirp.RequiredArgumentError("transaction_id")
+	}
+	household, err := s.household(ctx, database.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.db(ctx).ListTagHistory(household, req.TransactionId)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	resp := &pb.ListTagHistoryResp{Changes: []*pb.TagChange{}}
+	for _, c := range changes {
+		resp.Changes = append(resp.Changes, &pb.TagChange{
+			OperationId:   c.OperationID,
+			TransactionId: c.RecordID,
+			OldTagId:      c.OldTagID,
+			NewTagId:      c.NewTagID,
+			Username:      c.Username,
+			Time:          c.Created.UTC().Format(time.RFC3339),
+			Cause:         string(c.Cause),
+			PatternId:     c.PatternID,
+			ImportId:      c.ImportID,
+			UndoOf:        c.UndoOf,
+			Undone:        c.Undone,
+		})
+	}
+	return resp, nil
+}
+
+// Undo reverts all tag changes of an operation, e.g. one application of a
+// pattern. Either all changes are reverted or none, if any of the
+// transactions have been tagged again since.
+func (s *server) Undo(ctx context.Context, req *pb.UndoReq) (*pb.UndoResp, error) {
+	if req.OperationId == "" {
+		return nil, twirp.RequiredArgumentError("operation_id")
+	}
+	household, err := s.household(ctx, database.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.db(ctx).Undo(household, userID(ctx), req.OperationId)
+	if err == database.ErrNotFound {
+		return nil, twirp.InvalidArgumentError("operation_id", "not found in database")
+	} else if err == database.ErrUndoConflict {
+		return nil, twirp.NewError(twirp.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	s.Events.Publish(events.Event{Type: events.Tag, Household: household, OperationID: id})
+	return &pb.UndoResp{OperationId: id}, nil
+}
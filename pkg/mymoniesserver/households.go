@@ -0,0 +1,158 @@
+// This file contains household scoping of rpc methods and the household
+// rpc methods.
+
+package mymoniesserver
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+// HouseholdHeader is the HTTP request header selecting the household of an
+// RPC request. Without it, requests use the first household of the user.
+const HouseholdHeader = "X-Mymonies-Household"
+
+type householdKey struct{}
+
+// HouseholdFromHeader returns h with the household selected by
+// HouseholdHeader in the request context.
+func HouseholdFromHeader(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(HouseholdHeader); id != "" {
+			r = r.WithContext(context.WithValue(r.Context(), householdKey{}, id))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// household returns the household of the request, if the authenticated user
+// has at least the required role in it. Without authentication, the request
+// has full access to the selected or the default household.
+func (s *server) household(ctx context.Context, required database.Role) (string, error) {
+	selected, _ := ctx.Value(householdKey{}).(string)
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		if selected == "" {
+			selected = database.DefaultHousehold
+		}
+		return selected, nil
+	}
+
+	if selected == "" {
+		households, err := s.db(ctx).ListHouseholds(user.ID)
+		if err != nil {
+			return "", twirp.InternalErrorWith(err)
+		}
+		if len(households) == 0 {
+			return "", twirp.NewError(twirp.PermissionDenied, "not a member of any household")
+		}
+		selected = households[0].ID
+	}
+	role, err := s.db(ctx).GetRole(selected, user.ID)
+	if err == database.ErrNotFound {
+		return "", twirp.NewError(twirp.PermissionDenied, "not a member of household")
+	} else if err != nil {
+		return "", twirp.InternalErrorWith(err)
+	}
+	if !role.Allows(required) {
+		return "", twirp.NewError(twirp.PermissionDenied, "requires "+string(required)+" role in household")
+	}
+	return selected, nil
+}
+
+// ListHouseholds lists the households of the user.
+func (s *server) ListHouseholds(ctx context.Context, _ *pb.ListHouseholdsReq) (*pb.ListHouseholdsResp, error) {
+	var userID string
+	if user, ok := auth.UserFromContext(ctx); ok {
+		userID = user.ID
+	}
+	households, err := s.db(ctx).ListHouseholds(userID)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	resp := &pb.ListHouseholdsResp{Households: []*pb.Household{}}
+	for _, h := range households {
+		role := h.Role
+		if userID == "" {
+			role = database.RoleOwner
+		}
+		resp.Households = append(resp.Households, &pb.Household{Id: h.ID, Name: h.Name, Role: string(role)})
+	}
+	return resp, nil
+}
+
+// ListHouseholdMembers lists the members of the household.
+func (s *server) ListHouseholdMembers(ctx context.Context, _ *pb.ListHouseholdMembersReq) (*pb.ListHouseholdMembersResp, error) {
+	household, err := s.household(ctx, database.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.db(ctx).ListMembers(household)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	resp := &pb.ListHouseholdMembersResp{Members: []*pb.HouseholdMember{}}
+	for _, m := range members {
+		resp.Members = append(resp.Members, &pb.HouseholdMember{Username: m.Username, Role: string(m.Role)})
+	}
+	return resp, nil
+}
+
+// SetHouseholdMember adds a user to the household, changes the role of a
+// member or removes a member. Only owners can manage members, and the last
+// owner can't be removed.
+func (s *server) SetHouseholdMember(ctx context.Context, req *pb.SetHouseholdMemberReq) (*pb.SetHouseholdMemberResp, error) {
+	if req.Username == "" {
+		return nil, twirp.RequiredArgumentError("username")
+	}
+	role := database.Role(req.Role)
+	if role != "" && !role.Valid() {
+		return nil, twirp.InvalidArgumentError("role", "must be owner, editor, viewer or empty")
+	}
+	household, err := s.household(ctx, database.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.db(ctx).GetUser(req.Username)
+	if err == database.ErrNotFound {
+		return nil, twirp.InvalidArgumentError("username", "not found in database")
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+
+	if role != database.RoleOwner {
+		members, err := s.db(ctx).ListMembers(household)
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		owners, isOwner := 0, false
+		for _, m := range members {
+			if m.Role == database.RoleOwner {
+				owners++
+				isOwner = isOwner || m.UserID == user.ID
+			}
+		}
+		if isOwner && owners == 1 {
+			return nil, twirp.NewError(twirp.FailedPrecondition, "household must have an owner")
+		}
+	}
+
+	if role == "" {
+		err = s.db(ctx).RemoveMember(household, user.ID)
+		if err == database.ErrNotFound {
+			return nil, twirp.InvalidArgumentError("username", "not a member of household")
+		}
+	} else {
+		err = s.db(ctx).SetMember(household, user.ID, role)
+	}
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return &pb.SetHouseholdMemberResp{}, nil
+}
@@ -0,0 +1,138 @@
+package mymoniesserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/auth"
+	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
+	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+)
+
+func Test_server_household(t *testing.T) {
+	s := newServer(t, "testdata/update-tag/data.json")
+	users := make(map[string]context.Context)
+	userIDs := make(map[string]string)
+	for _, name := range []string{"owner", "viewer", "outsider"} {
+		u := &database.User{Username: name, PasswordHash: "hash"}
+		if err := s.DB.AddUser(u); err != nil {
+			t.Fatal("AddUser() returned error:", err)
+		}
+		users[name] = auth.NewContext(context.Background(), &auth.User{ID: u.ID, Username: name})
+		userIDs[name] = u.ID
+	}
+	ctx := users["owner"]
+	if _, err := s.SetHouseholdMember(ctx, &pb.SetHouseholdMemberReq{Username: "owner", Role: "owner"}); err == nil {
+		t.Fatal("SetHouseholdMember() by non-member returned no error")
+	}
+	// Without authentication, the default household is accessible.
+	for _, name := range []string{"owner", "viewer"} {
+		req := &pb.SetHouseholdMemberReq{Username: name, Role: name}
+		if _, err := s.SetHouseholdMember(context.Background(), req); err != nil {
+			t.Fatal("SetHouseholdMember() returned error:", err)
+		}
+	}
+	other, err := s.DB.AddHousehold("Other")
+	if err != nil {
+		t.Fatal("AddHousehold() returned error:", err)
+	}
+	if err := s.DB.SetMember(other.ID, userIDs["outsider"], database.RoleOwner); err != nil {
+		t.Fatal("SetMember() returned error:", err)
+	}
+
+	all := &pb.ListTransactionsReq{Filter: &pb.TransactionFilter{}}
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		call     func(ctx context.Context) error
+		wantCode twirp.ErrorCode
+	}{
+		{
+			name: "viewer lists transactions",
+			ctx:  users["viewer"],
+			call: func(ctx context.Context) error { _, err := s.ListTransactions(ctx, all); return err },
+		},
+		{
+			name: "viewer can't update tag",
+			ctx:  users["viewer"],
+			call: func(ctx context.Context) error {
+				_, err := s.UpdateTag(ctx, &pb.UpdateTagReq{TransactionId: "1", Version: 1})
+				return err
+			},
+			wantCode: twirp.PermissionDenied,
+		},
+		{
+			name: "owner updates tag",
+			ctx:  users["owner"],
+			call: func(ctx context.Context) error {
+				_, err := s.UpdateTag(ctx, &pb.UpdateTagReq{TransactionId: "1", Version: 1})
+				return err
+			},
+		},
+		{
+			name: "outsider can't update tag of other household",
+			ctx:  users["outsider"],
+			call: func(ctx context.Context) error {
+				_, err := s.UpdateTag(ctx, &pb.UpdateTagReq{TransactionId: "1", Version: 1})
+				return err
+			},
+			wantCode: twirp.InvalidArgument,
+		},
+		{
+			name:     "outsider can't select other household",
+			ctx:      context.WithValue(users["outsider"], householdKey{}, database.DefaultHousehold),
+			call:     func(ctx context.Context) error { _, err := s.ListTransactions(ctx, all); return err },
+			wantCode: twirp.PermissionDenied,
+		},
+		{
+			name: "viewer can't manage members",
+			ctx:  users["viewer"],
+			call: func(ctx context.Context) error {
+				_, err := s.SetHouseholdMember(ctx, &pb.SetHouseholdMemberReq{Username: "outsider", Role: "viewer"})
+				return err
+			},
+			wantCode: twirp.PermissionDenied,
+		},
+		{
+			name: "last owner can't be removed",
+			ctx:  users["owner"],
+			call: func(ctx context.Context) error {
+				_, err := s.SetHouseholdMember(ctx, &pb.SetHouseholdMemberReq{Username: "owner"})
+				return err
+			},
+			wantCode: twirp.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(tt.ctx)
+			var code twirp.ErrorCode
+			if twerr, ok := err.(twirp.Error); ok {
+				code = twerr.Code()
+			} else if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("error = %v, want code %q", err, tt.wantCode)
+			}
+		})
+	}
+
+	resp, err := s.ListTransactions(users["outsider"], all)
+	if err != nil {
+		t.Fatal("ListTransactions() returned error:", err)
+	}
+	if len(resp.Transactions) != 0 {
+		t.Errorf("ListTransactions() of other household = %v, want none", resp.Transactions)
+	}
+	households, err := s.ListHouseholds(users["viewer"], &pb.ListHouseholdsReq{})
+	if err != nil {
+		t.Fatal("ListHouseholds() returned error:", err)
+	}
+	want := []*pb.Household{{Id: database.DefaultHousehold, Name: "Default", Role: "viewer"}}
+	if len(households.Households) != 1 || *households.Households[0] != *want[0] {
+		t.Errorf("ListHouseholds() = %v, want %v", households.Households, want)
+	}
+}
@@ -4,70 +4,87 @@ package mymoniesserver
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"sort"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/lib/pq"
 	"github.com/twitchtv/twirp"
 
+	"github.com/joneskoo/mymonies/pkg/currency"
+	"github.com/joneskoo/mymonies/pkg/events"
+	"github.com/joneskoo/mymonies/pkg/exchangerate"
+	"github.com/joneskoo/mymonies/pkg/money"
 	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
 	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 )
 
-// AddImport stores new transaction records.
-func (s *server) AddImport(_ context.Context, req *pb.AddImportReq) (*pb.AddImportResp, error) {
-	if err := validateAddImportReq(req); err != nil {
+// AddExchangeRates stores currency exchange rates. A rate replaces any
+// existing rate of the same currency and date. Exchange rates are shared by
+// all households, but only editors can add them.
+func (s *server) AddExchangeRates(ctx context.Context, req *pb.AddExchangeRatesReq) (*pb.AddExchangeRatesResp, error) {
+	if err := validateAddExchangeRatesReq(req); err != nil {
 		return nil, err
 	}
-	txn, err := s.DB.Begin()
-	if err != nil {
+	if _, err := s.household(ctx, database.RoleEditor); err != nil {
+		return nil, err
+	}
+	if err := s.db(ctx).AddExchangeRates(req.Rates); err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	defer txn.Rollback()
+	s.logger.InfoContext(ctx, "stored exchange rates", "count", len(req.Rates))
+	return &pb.AddExchangeRatesResp{}, nil
+}
 
-	var importid int
-	const insertImport = "INSERT INTO imports (filename, account) VALUES ($1, $2) RETURNING id"
-	if err := txn.QueryRow(insertImport, req.FileName, req.Account).Scan(&importid); err != nil {
-		return nil, twirp.InternalErrorWith(err)
+func validateAddExchangeRatesReq(req *pb.AddExchangeRatesReq) error {
+	if len(req.Rates) == 0 {
+		return twirp.RequiredArgumentError("rates")
+	}
+	for _, r := range req.Rates {
+		if r.Date == "" {
+			return twirp.RequiredArgumentError("date")
+		}
+		if _, err := parseDate("date", r.Date); err != nil {
+			return err
+		}
+		if !currency.Valid(r.Currency) {
+			return twirp.InvalidArgumentError("currency", "must be ISO 4217 currency code")
+		}
+		if r.Currency == exchangerate.Base {
+			return twirp.InvalidArgumentError("currency", "rates are quoted against "+exchangerate.Base)
+		}
+		if rate, err := money.Parse(r.Rate); err != nil || rate.Sign() <= 0 {
+			return twirp.InvalidArgumentError("rate", "must be positive decimal number")
+		}
 	}
-	stmt, err := txn.Prepare(pq.CopyIn("records", "import_id", "transaction_date",
-		"value_date", "payment_date", "amount", "payee_payer", "account", "bic",
-		"transaction", "reference", "payer_reference", "message", "card_number"))
+	return nil
+}
+
+// AddImport stores new transaction records.
+func (s *server) AddImport(ctx context.Context, req *pb.AddImportReq) (*pb.AddImportResp, error) {
+	if err := validateAddImportReq(req); err != nil {
+		return nil, err
+	}
+	household, err := s.household(ctx, database.RoleEditor)
 	if err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		return nil, err
 	}
-	defer stmt.Close()
-	s.logger.Println("importing", len(req.Transactions), "transactions")
+	s.logger.InfoContext(ctx, "importing transactions", "household", household, "count", len(req.Transactions))
 	for _, r := range req.Transactions {
-		_, err = stmt.Exec(
-			importid,
-			sql.NullString{String: r.TransactionDate, Valid: r.TransactionDate != ""},
-			sql.NullString{String: r.ValueDate, Valid: r.ValueDate != ""},
-			sql.NullString{String: r.PaymentDate, Valid: r.PaymentDate != ""},
-			r.Amount,
-			r.PayeePayer,
-			r.Account,
-			r.Bic,
-			r.Transaction,
-			r.Reference,
-			r.PayerReference,
-			r.Message,
-			r.CardNumber)
-		if err != nil {
-			return nil, twirp.InternalErrorWith(err)
+		// Transactions are in the account currency unless specified otherwise.
+		if r.Currency == "" {
+			r.Currency = req.Currency
 		}
 	}
-	if _, err := stmt.Exec(); err != nil {
-		return nil, twirp.InternalErrorWith(err)
-	}
-	if err := stmt.Close(); err != nil {
+	tagged, err := s.db(ctx).AddImport(household, userID(ctx), req)
+	if err == database.ErrInvalidTag {
+		return nil, twirp.InvalidArgumentError("tag_id", "not found in database")
+	} else if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	err = txn.Commit()
-	return &pb.AddImportResp{}, err
+	s.Metrics.Imported(len(req.Transactions), tagged)
+	s.Events.Publish(events.Event{Type: events.Import, Household: household})
+	return &pb.AddImportResp{}, nil
 }
 
 func validateAddImportReq(req *pb.AddImportReq) error {
@@ -77,40 +94,66 @@ func validateAddImportReq(req *pb.AddImportReq) error {
 	if req.FileName == "" {
 		return twirp.RequiredArgumentError("file_name")
 	}
+	if req.Currency == "" {
+		return twirp.RequiredArgumentError("currency")
+	}
+	if !currency.Valid(req.Currency) {
+		return twirp.InvalidArgumentError("currency", "must be ISO 4217 currency code")
+	}
 	if len(req.Transactions) == 0 {
 		return twirp.RequiredArgumentError("transactions")
 	}
 
 	var importErr error
-	// must be RFC 3339 format date, with zero time UTC
 	mustRFC3339Date := func(argument, timestr string) {
 		if timestr == "" {
 			return
 		}
-		t, err := time.Parse(time.RFC3339, timestr)
-		if err != nil {
-			importErr = twirp.InvalidArgumentError(argument, "must be RFC 3339 timestamp")
-		}
-		if hour, min, sec := t.Clock(); hour != 0 || min != 0 || sec != 0 {
-			e := fmt.Sprintf("time must be zero UTC, was %02d:%02d:%02d", hour, min, sec)
-			importErr = twirp.InvalidArgumentError(argument, e)
+		if _, err := parseDate(argument, timestr); err != nil {
+			importErr = err
 		}
-		return
 	}
 	for _, t := range req.Transactions {
 		mustRFC3339Date("transaction_date", t.TransactionDate)
 		mustRFC3339Date("value_date", t.ValueDate)
 		mustRFC3339Date("payment_date", t.PaymentDate)
+		if _, err := money.Parse(t.Amount); err != nil {
+			importErr = twirp.InvalidArgumentError("amount", "must be decimal number, e.g. -12.50")
+		}
+		if t.Currency != "" && !currency.Valid(t.Currency) {
+			importErr = twirp.InvalidArgumentError("currency", "must be ISO 4217 currency code")
+		}
+		if _, err := strconv.ParseInt(t.TagId, 10, 64); t.TagId != "" && err != nil {
+			importErr = twirp.InvalidArgumentError("tag_id", err.Error())
+		}
 	}
 	return importErr
 }
 
+// parseDate parses a date argument. It must be RFC 3339 format date, with
+// zero time UTC.
+func parseDate(argument, timestr string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, timestr)
+	if err != nil {
+		return t, twirp.InvalidArgumentError(argument, "must be RFC 3339 timestamp")
+	}
+	if hour, min, sec := t.Clock(); hour != 0 || min != 0 || sec != 0 {
+		e := fmt.Sprintf("time must be zero UTC, was %02d:%02d:%02d", hour, min, sec)
+		return t, twirp.InvalidArgumentError(argument, e)
+	}
+	return t, nil
+}
+
 // AddPattern stores a new pattern to tag transactions on import.
 func (s *server) AddPattern(ctx context.Context, req *pb.AddPatternReq) (*pb.AddPatternResp, error) {
 	if req.Pattern == nil {
 		return nil, twirp.RequiredArgumentError("pattern")
 	}
 	p := req.Pattern
+	household, err := s.household(ctx, database.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
 
 	// List affected transaction ids
 	resp, err := s.ListTransactions(ctx, &pb.ListTransactionsReq{Filter: &pb.TransactionFilter{
@@ -120,153 +163,289 @@ func (s *server) AddPattern(ctx context.Context, req *pb.AddPatternReq) (*pb.Add
 	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	ids := make([]string, len(resp.Transactions))
+	ids := make([]string, 0, len(resp.Transactions))
 	for _, tx := range resp.Transactions {
 		ids = append(ids, tx.Id)
 	}
 
-	txn, err := s.DB.Begin()
+	opID, tagged, err := s.db(ctx).AddPattern(household, userID(ctx), p, ids)
+	if err == database.ErrInvalidTag {
+		return nil, twirp.InvalidArgumentError("tag_id", "not found in database")
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	s.Metrics.AutoTagged(database.CausePattern, len(tagged))
+	s.Events.Publish(events.Event{Type: events.Pattern, Household: household, OperationID: opID})
+	return &pb.AddPatternResp{OperationId: opID}, nil
+}
+
+// AddTag stores a new tag. Tag names are unique within a household.
+func (s *server) AddTag(ctx context.Context, req *pb.AddTagReq) (*pb.AddTagResp, error) {
+	if req.Name == "" {
+		return nil, twirp.RequiredArgumentError("name")
+	}
+	household, err := s.household(ctx, database.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
-	defer txn.Rollback()
-	_, err = txn.Exec("INSERT INTO patterns (account, query, tag_id) VALUES ($1, $2, $3)",
-		p.Account, p.Query, p.TagId)
+	tags, err := s.db(ctx).ListTags(household)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	for _, t := range tags {
+		if t.Name == req.Name {
+			return nil, twirp.NewError(twirp.AlreadyExists, "tag already exists")
+		}
+	}
+	tag, err := s.db(ctx).AddTag(household, req.Name)
+	if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	return &pb.AddTagResp{Tag: tag}, nil
+}
+
+// BulkUpdateTag sets the tag of many transactions, selected either by id or
+// by filter, in one undoable operation.
+func (s *server) BulkUpdateTag(ctx context.Context, req *pb.BulkUpdateTagReq) (*pb.BulkUpdateTagResp, error) {
+	if len(req.TransactionIds) == 0 && req.Filter == nil {
+		return nil, twirp.RequiredArgumentError("transaction_ids")
+	}
+	if len(req.TransactionIds) > 0 && req.Filter != nil {
+		return nil, twirp.InvalidArgumentError("filter", "must be empty when transaction_ids is given")
+	}
+	if _, err := strconv.ParseInt(req.TagId, 10, 64); req.TagId != "" && err != nil {
+		return nil, twirp.InvalidArgumentError("tag_id", err.Error())
+	}
+	household, err := s.household(ctx, database.RoleEditor)
 	if err != nil {
 		return nil, err
 	}
-	if len(ids) > 0 {
-		_, err = txn.Exec("UPDATE records SET tag_id = $1 WHERE id IN ("+strings.Join(ids, ",")+") AND tag_id IS NULL", p.TagId)
+
+	var filter *database.TransactionFilter
+	if req.Filter != nil {
+		f, err := transactionFilter(req.Filter)
 		if err != nil {
 			return nil, err
 		}
+		filter = &f
+	}
+
+	opID, changed, err := s.db(ctx).BulkUpdateTag(household, userID(ctx), req.TransactionIds, filter, req.TagId)
+	if err == database.ErrNotFound {
+		return nil, twirp.InvalidArgumentError("transaction_ids", "not found in database")
+	} else if err == database.ErrInvalidTag {
+		return nil, twirp.InvalidArgumentError("tag_id", "not found in database")
+	} else if err != nil {
+		return nil, twirp.InternalErrorWith(err)
+	}
+	if len(changed) > 0 {
+		s.Events.Publish(events.Event{Type: events.Tag, Household: household, TransactionIDs: changed, OperationID: opID})
 	}
-	err = txn.Commit()
-	return &pb.AddPatternResp{}, err
+	return &pb.BulkUpdateTagResp{Count: int32(len(changed)), OperationId: opID}, nil
 }
 
-// ListAccounts lists accounts in the database.
-func (s *server) ListAccounts(context.Context, *pb.ListAccountsReq) (*pb.ListAccountsResp, error) {
-	resp := &pb.ListAccountsResp{Accounts: []*pb.Account{}}
-	err := s.DB.Select(&resp.Accounts, "SELECT DISTINCT account AS number from imports")
+// ListAccounts lists accounts in the database. An account that has been
+// imported in more than one currency is listed once for each currency.
+func (s *server) ListAccounts(ctx context.Context, _ *pb.ListAccountsReq) (*pb.ListAccountsResp, error) {
+	household, err := s.household(ctx, database.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := s.db(ctx).ListAccounts(household)
 	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	return resp, nil
+	return &pb.ListAccountsResp{Accounts: accounts}, nil
 }
 
 // ListTags lists the transaction tags in the database.
-func (s *server) ListTags(context.Context, *pb.ListTagsReq) (*pb.ListTagsResp, error) {
-	tags := make([]*pb.Tag, 0)
-	rows, err := s.DB.Queryx("SELECT * from tags ORDER BY name")
+func (s *server) ListTags(ctx context.Context, _ *pb.ListTagsReq) (*pb.ListTagsResp, error) {
+	household, err := s.household(ctx, database.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.db(ctx).ListTags(household)
 	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var t pb.Tag
-		err := rows.StructScan(&t)
+	return &pb.ListTagsResp{
+		Tags: tags,
+	}, nil
+}
+
+// ListTotals sums transaction amounts by month and tag. If a base currency
+// is requested, amounts are converted to it using the exchange rate of each
+// transaction date. Otherwise each currency is summed separately.
+func (s *server) ListTotals(ctx context.Context, req *pb.ListTotalsReq) (*pb.ListTotalsResp, error) {
+	base := req.BaseCurrency
+	if base != "" && !currency.Valid(base) {
+		return nil, twirp.InvalidArgumentError("base_currency", "must be ISO 4217 currency code")
+	}
+	resp, err := s.ListTransactions(ctx, &pb.ListTransactionsReq{Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ month, tagID, currency string }
+	sums := make(map[key]money.Amount)
+	totals := make(map[key]*pb.Total)
+	converter := exchangerate.NewConverter(s.db(ctx))
+	for _, tx := range resp.Transactions {
+		date, err := bookingDate(tx)
 		if err != nil {
 			return nil, twirp.InternalErrorWith(err)
 		}
-		tags = append(tags, &t)
+		amount, err := money.Parse(tx.Amount)
+		if err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
+		code := tx.Currency
+		if base != "" {
+			amount, err = converter.Convert(amount, code, base, date)
+			if err != nil {
+				return nil, twirp.NewError(twirp.FailedPrecondition, err.Error())
+			}
+			code = base
+		}
+		k := key{date.Format("2006-01"), tx.TagId, code}
+		if totals[k] == nil {
+			totals[k] = &pb.Total{Month: k.month, TagId: k.tagID, Currency: k.currency}
+		}
+		totals[k].Count++
+		if sums[k], err = sums[k].Add(amount); err != nil {
+			return nil, twirp.InternalErrorWith(err)
+		}
 	}
-	return &pb.ListTagsResp{
-		Tags: tags,
-	}, nil
+
+	result := make([]*pb.Total, 0, len(totals))
+	for k, t := range totals {
+		t.Amount = formatAmount(sums[k].String(), t.Currency)
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.TagId != b.TagId {
+			return a.TagId < b.TagId
+		}
+		return a.Currency < b.Currency
+	})
+	return &pb.ListTotalsResp{Totals: result}, nil
+}
+
+// bookingDate returns the first available date of the transaction,
+// preferring transaction date over value date and payment date.
+func bookingDate(tx *pb.Transaction) (time.Time, error) {
+	for _, d := range []string{tx.TransactionDate, tx.ValueDate, tx.PaymentDate} {
+		if d != "" {
+			return time.Parse(time.RFC3339, d)
+		}
+	}
+	return time.Time{}, fmt.Errorf("transaction %v has no date", tx.Id)
 }
 
 // ListTransactions lists transactions. Optionally a filter can be provided.
-func (s *server) ListTransactions(_ context.Context, req *pb.ListTransactionsReq) (*pb.ListTransactionsResp, error) {
-	query, args, err := transactionFilterQuery(req.Filter)
+func (s *server) ListTransactions(ctx context.Context, req *pb.ListTransactionsReq) (*pb.ListTransactionsResp, error) {
+	filter, err := transactionFilter(req.Filter)
 	if err != nil {
 		return nil, err
 	}
-
-	rows, err := s.DB.NamedQuery(query, args)
+	household, err := s.household(ctx, database.RoleViewer)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	transactions := make([]*pb.Transaction, 0)
-	for rows.Next() {
-		var t pb.Transaction
-		_ = rows.StructScan(&t)
-		transactions = append(transactions, &t)
-	}
-	if rows.Err() != nil {
+	transactions, err := s.db(ctx).ListTransactions(household, filter)
+	if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
+	for _, t := range transactions {
+		t.Amount = formatAmount(t.Amount, t.Currency)
+	}
 
 	return &pb.ListTransactionsResp{Transactions: transactions}, nil
 }
 
 // UpdateTag sets the transaction tag id.
-func (s *server) UpdateTag(_ context.Context, req *pb.UpdateTagReq) (*pb.UpdateTagResp, error) {
+func (s *server) UpdateTag(ctx context.Context, req *pb.UpdateTagReq) (*pb.UpdateTagResp, error) {
 	if req.TransactionId == "" {
 		return nil, twirp.RequiredArgumentError("transaction_id")
 	}
+	if req.Version == 0 {
+		return nil, twirp.RequiredArgumentError("version")
+	}
 
 	_, err := strconv.ParseInt(req.TagId, 10, 64)
 	if req.TagId != "" && err != nil {
 		return nil, twirp.InvalidArgumentError("tag_id", err.Error())
 	}
 
-	res, err := s.DB.Exec("UPDATE records SET tag_id = $1 WHERE id = $2",
-		sql.NullString{String: req.TagId, Valid: req.TagId == ""},
-		req.TransactionId)
+	household, err := s.household(ctx, database.RoleEditor)
 	if err != nil {
-		return nil, twirp.InternalErrorWith(err)
+		return nil, err
 	}
-	if count, err := res.RowsAffected(); count != 1 {
+
+	opID, err := s.db(ctx).UpdateTag(household, userID(ctx), req.TransactionId, req.TagId, int(req.Version))
+	if err == database.ErrNotFound {
 		return nil, twirp.InvalidArgumentError("transaction_id", "not found in database")
+	} else if err == database.ErrInvalidTag {
+		return nil, twirp.InvalidArgumentError("tag_id", "not found in database")
+	} else if err == database.ErrVersionConflict {
+		return nil, s.versionConflict(ctx, household, req.TransactionId)
 	} else if err != nil {
 		return nil, twirp.InternalErrorWith(err)
 	}
-	return &pb.UpdateTagResp{}, nil
+	s.Events.Publish(events.Event{Type: events.Tag, Household: household, TransactionIDs: []string{req.TransactionId}, OperationID: opID})
+	return &pb.UpdateTagResp{OperationId: opID, Version: req.Version + 1}, nil
 }
 
-func transactionFilterQuery(tf *pb.TransactionFilter) (string, map[string]interface{}, error) {
-	if tf == nil {
-		return "", nil, twirp.RequiredArgumentError("filter")
+// versionConflict returns an aborted error with the current tag_id and
+// version of a transaction changed by someone else, so that the client can
+// show the current value.
+func (s *server) versionConflict(ctx context.Context, household, transactionID string) error {
+	transactions, err := s.db(ctx).ListTransactions(household, database.TransactionFilter{ID: transactionID})
+	if err != nil {
+		return twirp.InternalErrorWith(err)
 	}
-
-	query := &database.SelectQuery{
-		Columns: []string{"records.*"},
-		From: `records
-			LEFT OUTER JOIN imports ON records.import_id = imports.id`,
-		OrderBy: "transaction_date DESC, records.id",
+	twerr := twirp.NewError(twirp.Aborted, "transaction was changed by someone else")
+	if len(transactions) == 1 {
+		t := transactions[0]
+		twerr = twerr.WithMeta("tag_id", t.TagId).WithMeta("version", strconv.Itoa(int(t.Version)))
 	}
-	args := make(map[string]interface{})
+	return twerr
+}
 
-	if tf.Id != "" {
-		query.AndWhere("records.id = :record_id")
-		args["record_id"] = tf.Id
+// formatAmount formats a numeric database amount with at least as many
+// decimals as the currency uses, e.g. "10" EUR is formatted as "10.00".
+func formatAmount(amount, currencyCode string) string {
+	a, err := money.Parse(amount)
+	if err != nil {
+		return amount
 	}
-
-	if tf.Account != "" {
-		query.AndWhere("imports.account = :account")
-		args["account"] = tf.Account
+	if n, ok := currency.MinorUnits(currencyCode); ok {
+		a = a.WithMinScale(n)
 	}
+	return a.String()
+}
 
+// transactionFilter converts an RPC transaction filter to a database filter.
+func transactionFilter(tf *pb.TransactionFilter) (database.TransactionFilter, error) {
+	if tf == nil {
+		return database.TransactionFilter{}, twirp.RequiredArgumentError("filter")
+	}
+	filter := database.TransactionFilter{
+		ID:      tf.Id,
+		Account: tf.Account,
+		Query:   tf.Query,
+	}
 	if tf.Month != "" {
-		var startDate, endDate time.Time
-		var err error
-		startDate, err = time.Parse("2006-01", tf.Month)
+		month, err := time.Parse("2006-01", tf.Month)
 		if err != nil {
-			return "", nil, twirp.InvalidArgumentError("month", err.Error())
+			return filter, twirp.InvalidArgumentError("month", err.Error())
 		}
-		endDate = startDate.AddDate(0, 1, -1)
-		query.AndWhere("records.transaction_date BETWEEN :start AND :end")
-		args["start"] = startDate
-		args["end"] = endDate
+		filter.Month = month
 	}
-
-	if tf.Query != "" {
-		query.AndWhere(":search IN (payee_payer, records.account, transaction, reference, payer_reference, message)")
-		args["search"] = tf.Query
-	}
-
-	return query.SQL(), args, nil
+	return filter, nil
 }
@@ -5,14 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"flag"
-	"fmt"
 	"io/ioutil"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/joneskoo/mymonies/pkg/logging"
 	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
 	pb "github.com/joneskoo/mymonies/pkg/rpc/mymonies"
+	"github.com/twitchtv/twirp"
 )
 
 var (
@@ -22,8 +23,7 @@ var (
 
 func init() {
 	flag.BoolVar(&update, "update", false, "update and overwrite golden test data files")
-	flag.StringVar(&testDatabaseConn, "mymonies-test-db", "database=mymonies_test", "Database to use for mymonies unit tests")
-	flag.Parse()
+	flag.StringVar(&testDatabaseConn, "mymonies-test-db", "memory:", "Database to use for mymonies unit tests, e.g. database=mymonies_test")
 }
 
 func readFixture(t *testing.T, f string) []byte {
@@ -45,7 +45,9 @@ func jsonFixture(t *testing.T, f string, dst interface{}) {
 	}
 	d := json.NewDecoder(bytes.NewReader(b))
 	d.DisallowUnknownFields()
-	d.Decode(dst)
+	if err := d.Decode(dst); err != nil {
+		t.Fatalf("failed to decode fixture %q: %v", f, err)
+	}
 }
 
 func writeFixture(t *testing.T, f string, got interface{}) {
@@ -59,29 +61,89 @@ func writeFixture(t *testing.T, f string, got interface{}) {
 	}
 }
 
-func newServer(t *testing.T, sqlFixture string) *server {
-	db, err := database.Connect(testDatabaseConn)
+// fixture is test data stored to the database before a test.
+type fixture struct {
+	Tags          []string           `json:"tags"`
+	Imports       []*pb.AddImportReq `json:"imports"`
+	ExchangeRates []*pb.ExchangeRate `json:"exchange_rates"`
+}
+
+// newServer returns a server with an empty test database and data from
+// dataFixture, if any. All existing data in the test database is deleted.
+func newServer(t *testing.T, dataFixture string) *server {
+	db, err := database.Open(testDatabaseConn)
 	if err != nil {
 		t.Fatal("connect to test database failed:", err)
 	}
-	if err := db.DropTables(); err != nil {
-		t.Fatal("db.DropTables() returned error:", err)
+	if err := db.MigrateTo(0); err != nil {
+		t.Fatal("db.MigrateTo(0) returned error:", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatal("db.Migrate() returned error:", err)
+	}
+
+	var data fixture
+	jsonFixture(t, dataFixture, &data)
+	for _, name := range data.Tags {
+		if _, err := db.AddTag(database.DefaultHousehold, name); err != nil {
+			t.Fatal(err)
+		}
 	}
-	if err := db.CreateTables(); err != nil {
-		t.Fatal("db.CreateTables() returned error:", err)
+	for _, imp := range data.Imports {
+		if _, err := db.AddImport(database.DefaultHousehold, "", imp); err != nil {
+			t.Fatal(err)
+		}
 	}
-	query := string(readFixture(t, sqlFixture))
-	if _, err := db.Exec(query); err != nil {
-		t.Fatal(err)
+	if len(data.ExchangeRates) > 0 {
+		if err := db.AddExchangeRates(data.ExchangeRates); err != nil {
+			t.Fatal(err)
+		}
 	}
-	logger := make(mockLogger, 0)
-	return &server{DB: db, logger: logger}
+	return &server{DB: db, logger: logging.Discard()}
 }
 
-type mockLogger []string
-
-func (l mockLogger) Println(args ...interface{}) {
-	l = append(l, fmt.Sprint(args...))
+func Test_server_AddExchangeRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		req     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: "testdata/list-totals/data.json",
+			req:  "testdata/add-exchange-rates/valid/req.json",
+			want: "testdata/add-exchange-rates/valid/want.json",
+		},
+		{
+			name:    "invalid currency",
+			req:     "testdata/add-exchange-rates/invalid-currency/req.json",
+			wantErr: true,
+		},
+		{
+			name:    "base currency",
+			req:     "testdata/add-exchange-rates/base-currency/req.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(t, tt.data)
+			req := &pb.AddExchangeRatesReq{}
+			want := &pb.AddExchangeRatesResp{}
+			jsonFixture(t, tt.req, req)
+			jsonFixture(t, tt.want, want)
+			got, err := s.AddExchangeRates(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.AddExchangeRates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, want) {
+				t.Errorf("server.AddExchangeRates() = %v, want %v", got, want)
+			}
+		})
+	}
 }
 
 func Test_server_AddImport(t *testing.T) {
@@ -96,9 +158,10 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:  "example",
 				FileName: "data.txt",
+				Currency: "EUR",
 				Transactions: []*pb.Transaction{
 					&pb.Transaction{
-						Amount:          10.0,
+						Amount:          "10.00",
 						PaymentDate:     today,
 						TransactionDate: today,
 						ValueDate:       today,
@@ -112,9 +175,10 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:  "",
 				FileName: "data.txt",
+				Currency: "EUR",
 				Transactions: []*pb.Transaction{
 					&pb.Transaction{
-						Amount:          10.0,
+						Amount:          "10.00",
 						PaymentDate:     today,
 						TransactionDate: today,
 						ValueDate:       today,
@@ -128,9 +192,10 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:  "example",
 				FileName: "",
+				Currency: "EUR",
 				Transactions: []*pb.Transaction{
 					&pb.Transaction{
-						Amount:          10.0,
+						Amount:          "10.00",
 						PaymentDate:     today,
 						TransactionDate: today,
 						ValueDate:       today,
@@ -144,6 +209,7 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:      "example",
 				FileName:     "foo.txt",
+				Currency:     "EUR",
 				Transactions: []*pb.Transaction{},
 			},
 			wantErr: true,
@@ -153,9 +219,10 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:  "example",
 				FileName: "data.txt",
+				Currency: "EUR",
 				Transactions: []*pb.Transaction{
 					&pb.Transaction{
-						Amount:          10.0,
+						Amount:          "10.00",
 						PaymentDate:     "1.2.2015",
 						TransactionDate: today,
 						ValueDate:       today,
@@ -169,10 +236,97 @@ func Test_server_AddImport(t *testing.T) {
 			req: &pb.AddImportReq{
 				Account:      "example",
 				FileName:     "data.txt",
+				Currency:     "EUR",
 				Transactions: []*pb.Transaction{exampleTransaction},
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid-amount",
+			req: &pb.AddImportReq{
+				Account:  "example",
+				FileName: "data.txt",
+				Currency: "EUR",
+				Transactions: []*pb.Transaction{
+					&pb.Transaction{
+						Amount:          "10,00",
+						PaymentDate:     today,
+						TransactionDate: today,
+						ValueDate:       today,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing-currency",
+			req: &pb.AddImportReq{
+				Account:  "example",
+				FileName: "data.txt",
+				Transactions: []*pb.Transaction{
+					&pb.Transaction{
+						Amount:          "10.00",
+						PaymentDate:     today,
+						TransactionDate: today,
+						ValueDate:       today,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-currency",
+			req: &pb.AddImportReq{
+				Account:  "example",
+				FileName: "data.txt",
+				Currency: "euro",
+				Transactions: []*pb.Transaction{
+					&pb.Transaction{
+						Amount:          "10.00",
+						PaymentDate:     today,
+						TransactionDate: today,
+						ValueDate:       today,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid-transaction-currency",
+			req: &pb.AddImportReq{
+				Account:  "example",
+				FileName: "data.txt",
+				Currency: "EUR",
+				Transactions: []*pb.Transaction{
+					&pb.Transaction{
+						Amount:          "10.00",
+						PaymentDate:     today,
+						TransactionDate: today,
+						ValueDate:       today,
+						Currency:        "XYZ",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "foreign-transaction-currency",
+			req: &pb.AddImportReq{
+				Account:  "example",
+				FileName: "data.txt",
+				Currency: "EUR",
+				Transactions: []*pb.Transaction{
+					&pb.Transaction{
+						Amount:          "138.00",
+						PaymentDate:     today,
+						TransactionDate: today,
+						ValueDate:       today,
+						Currency:        "SEK",
+					},
+				},
+			},
+			want: &pb.AddImportResp{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -192,7 +346,7 @@ func Test_server_AddImport(t *testing.T) {
 var (
 	today              = date(time.Now())
 	exampleTransaction = &pb.Transaction{
-		Amount:          10.0,
+		Amount:          "10.00",
 		PaymentDate:     today,
 		TransactionDate: "1985-04-12T23:20:50.52Z",
 		ValueDate:       today,
@@ -206,14 +360,14 @@ func date(t time.Time) string {
 func Test_server_AddPattern(t *testing.T) {
 	tests := []struct {
 		name    string
-		sql     string
+		data    string
 		req     *pb.AddPatternReq
 		want    *pb.AddPatternResp
 		wantErr bool
 	}{
 		{
 			name: "valid",
-			sql:  "testdata/data.sql",
+			data: "testdata/data.json",
 			req: &pb.AddPatternReq{
 				Pattern: &pb.Pattern{
 					Account: "example",
@@ -221,12 +375,12 @@ func Test_server_AddPattern(t *testing.T) {
 					TagId:   "1",
 				},
 			},
-			want: &pb.AddPatternResp{},
+			want: &pb.AddPatternResp{OperationId: "1"},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newServer(t, tt.sql)
+			s := newServer(t, tt.data)
 			got, err := s.AddPattern(context.Background(), tt.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.AddPattern() error = %v, wantErr %v", err, tt.wantErr)
@@ -242,7 +396,7 @@ func Test_server_AddPattern(t *testing.T) {
 func Test_server_ListAccounts(t *testing.T) {
 	tests := []struct {
 		name    string
-		sql     string
+		data    string
 		req     *pb.ListAccountsReq
 		want    *pb.ListAccountsResp
 		wantErr bool
@@ -257,7 +411,7 @@ func Test_server_ListAccounts(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newServer(t, tt.sql)
+			s := newServer(t, tt.data)
 			got, err := s.ListAccounts(context.Background(), tt.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.ListAccounts() error = %v, wantErr %v", err, tt.wantErr)
@@ -273,14 +427,14 @@ func Test_server_ListAccounts(t *testing.T) {
 func Test_server_ListTags(t *testing.T) {
 	tests := []struct {
 		name    string
-		sql     string
+		data    string
 		req     *pb.ListTagsReq
 		want    *pb.ListTagsResp
 		wantErr bool
 	}{
 		{
 			name: "valid",
-			sql:  "testdata/data.sql",
+			data: "testdata/data.json",
 			req:  &pb.ListTagsReq{},
 			want: &pb.ListTagsResp{Tags: []*pb.Tag{
 				&pb.Tag{Id: "1", Name: "example"},
@@ -290,7 +444,7 @@ func Test_server_ListTags(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newServer(t, tt.sql)
+			s := newServer(t, tt.data)
 			got, err := s.ListTags(context.Background(), tt.req)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.ListTags() error = %v, wantErr %v", err, tt.wantErr)
@@ -303,17 +457,66 @@ func Test_server_ListTags(t *testing.T) {
 	}
 }
 
+func Test_server_ListTotals(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		req     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "by currency",
+			data: "testdata/list-totals/data.json",
+			req:  "testdata/list-totals/by-currency/req.json",
+			want: "testdata/list-totals/by-currency/want.json",
+		},
+		{
+			name: "base currency",
+			data: "testdata/list-totals/data.json",
+			req:  "testdata/list-totals/base-currency/req.json",
+			want: "testdata/list-totals/base-currency/want.json",
+		},
+		{
+			name:    "missing exchange rate",
+			data:    "testdata/list-totals/data.json",
+			req:     "testdata/list-totals/missing-rate/req.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(t, tt.data)
+			req := &pb.ListTotalsReq{}
+			want := &pb.ListTotalsResp{}
+			jsonFixture(t, tt.req, req)
+			jsonFixture(t, tt.want, want)
+			got, err := s.ListTotals(context.Background(), req)
+			if update && tt.want != "" {
+				writeFixture(t, tt.want, got)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.ListTotals() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, want) {
+				t.Errorf("server.ListTotals() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func Test_server_ListTransactions(t *testing.T) {
 	tests := []struct {
 		name    string
-		sql     string
+		data    string
 		req     string
 		want    string
 		wantErr bool
 	}{
 		{
 			name: "valid all transactions",
-			sql:  "testdata/list-transactions/data.sql",
+			data: "testdata/list-transactions/data.json",
 			req:  "testdata/list-transactions/valid-all-transactions/req.json",
 			want: "testdata/list-transactions/valid-all-transactions/want.json",
 		},
@@ -325,7 +528,7 @@ func Test_server_ListTransactions(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newServer(t, tt.sql)
+			s := newServer(t, tt.data)
 			req := &pb.ListTransactionsReq{}
 			want := &pb.ListTransactionsResp{}
 			jsonFixture(t, tt.req, req)
@@ -345,42 +548,150 @@ func Test_server_ListTransactions(t *testing.T) {
 	}
 }
 
+func Test_server_AddTag(t *testing.T) {
+	s := newServer(t, "testdata/update-tag/data.json")
+	tests := []struct {
+		name     string
+		wantCode twirp.ErrorCode
+	}{
+		{"groceries", twirp.NoError},
+		{"example", twirp.AlreadyExists},
+		{"", twirp.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := s.AddTag(context.Background(), &pb.AddTagReq{Name: tt.name})
+		if tt.wantCode != twirp.NoError {
+			if twerr, ok := err.(twirp.Error); !ok || twerr.Code() != tt.wantCode {
+				t.Errorf("server.AddTag(%q) error = %v, want %v", tt.name, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || got.Tag.Name != tt.name || got.Tag.Id == "" {
+			t.Errorf("server.AddTag(%q) = %v, %v; want new tag", tt.name, got, err)
+		}
+	}
+}
+
+func Test_server_BulkUpdateTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *pb.BulkUpdateTagReq
+		wantCount int32
+		wantErr   bool
+	}{
+		{
+			name:      "by id",
+			req:       &pb.BulkUpdateTagReq{TransactionIds: []string{"3", "4"}, TagId: "2"},
+			wantCount: 2,
+		},
+		{
+			name:      "by filter",
+			req:       &pb.BulkUpdateTagReq{Filter: &pb.TransactionFilter{Query: "cent payee"}, TagId: "1"},
+			wantCount: 2,
+		},
+		{
+			name:      "remove tags",
+			req:       &pb.BulkUpdateTagReq{Filter: &pb.TransactionFilter{Account: "foo"}},
+			wantCount: 2,
+		},
+		{
+			name:    "ids and filter",
+			req:     &pb.BulkUpdateTagReq{TransactionIds: []string{"3"}, Filter: &pb.TransactionFilter{}, TagId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "missing transaction ids",
+			req:     &pb.BulkUpdateTagReq{TagId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "transaction not found",
+			req:     &pb.BulkUpdateTagReq{TransactionIds: []string{"3", "99"}, TagId: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed tag id",
+			req:     &pb.BulkUpdateTagReq{TransactionIds: []string{"3"}, TagId: "x"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(t, "testdata/list-totals/data.json")
+			before, err := s.ListTransactions(context.Background(), &pb.ListTransactionsReq{Filter: &pb.TransactionFilter{}})
+			if err != nil {
+				t.Fatal("ListTransactions() returned error:", err)
+			}
+			got, err := s.BulkUpdateTag(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.BulkUpdateTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Count != tt.wantCount {
+				t.Errorf("server.BulkUpdateTag() count = %v, want %v", got.Count, tt.wantCount)
+			}
+			// The changes are one operation, which undoes all of them.
+			if err == nil {
+				if _, err := s.Undo(context.Background(), &pb.UndoReq{OperationId: got.OperationId}); err != nil {
+					t.Fatal("server.Undo() returned error:", err)
+				}
+			}
+			after, err := s.ListTransactions(context.Background(), &pb.ListTransactionsReq{Filter: &pb.TransactionFilter{}})
+			if err != nil {
+				t.Fatal("ListTransactions() returned error:", err)
+			}
+			// Changing and restoring a tag increments the version twice.
+			for _, t := range after.Transactions {
+				t.Version = 1
+			}
+			if !reflect.DeepEqual(before, after) {
+				t.Errorf("transactions after undo or error = %v, want %v", after, before)
+			}
+		})
+	}
+}
+
 func Test_server_UpdateTag(t *testing.T) {
 	tests := []struct {
 		name    string
-		sql     string
+		data    string
 		req     string
 		want    string
 		wantErr bool
 	}{
 		{
 			name: "valid",
-			sql:  "testdata/update-tag/data.sql",
+			data: "testdata/update-tag/data.json",
 			req:  "testdata/update-tag/valid/req.json",
 			want: "testdata/update-tag/valid/want.json",
 		},
 		{
 			name:    "missing transaction id",
-			sql:     "testdata/update-tag/data.sql",
+			data:    "testdata/update-tag/data.json",
 			req:     "testdata/update-tag/missing-transaction-id/req.json",
 			wantErr: true,
 		},
 		{
 			name:    "malformed tag id",
-			sql:     "testdata/update-tag/data.sql",
+			data:    "testdata/update-tag/data.json",
 			req:     "testdata/update-tag/malformed-tag-id/req.json",
 			wantErr: true,
 		},
 		{
 			name:    "malformed transaction id",
-			sql:     "testdata/update-tag/data.sql",
+			data:    "testdata/update-tag/data.json",
 			req:     "testdata/update-tag/malformed-transaction-id/req.json",
 			wantErr: true,
 		},
+		{
+			name:    "stale version",
+			data:    "testdata/update-tag/data.json",
+			req:     "testdata/update-tag/stale-version/req.json",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := newServer(t, tt.sql)
+			s := newServer(t, tt.data)
 			req := &pb.UpdateTagReq{}
 			want := &pb.UpdateTagResp{}
 			jsonFixture(t, tt.req, req)
@@ -399,3 +710,21 @@ func Test_server_UpdateTag(t *testing.T) {
 		})
 	}
 }
+
+func Test_server_UpdateTag_conflict(t *testing.T) {
+	s := newServer(t, "testdata/update-tag/data.json")
+	req := &pb.UpdateTagReq{TransactionId: "1", TagId: "1", Version: 1}
+	if _, err := s.UpdateTag(context.Background(), req); err != nil {
+		t.Fatal("server.UpdateTag() returned error:", err)
+	}
+	// A second edit based on the same version conflicts with the first.
+	req.TagId = "2"
+	_, err := s.UpdateTag(context.Background(), req)
+	twerr, ok := err.(twirp.Error)
+	if !ok || twerr.Code() != twirp.Aborted {
+		t.Fatalf("server.UpdateTag() of stale version error = %v, want %v", err, twirp.Aborted)
+	}
+	if got := [2]string{twerr.Meta("tag_id"), twerr.Meta("version")}; got != [2]string{"1", "2"} {
+		t.Errorf("server.UpdateTag() conflict tag_id, version = %v, want [1 2]", got)
+	}
+}
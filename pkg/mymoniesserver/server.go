@@ -1,30 +1,124 @@
 package mymoniesserver
 
 import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/twitchtv/twirp"
+
+	"github.com/joneskoo/mymonies/pkg/events"
+	"github.com/joneskoo/mymonies/pkg/metrics"
 	"github.com/joneskoo/mymonies/pkg/mymoniesserver/database"
 	"github.com/joneskoo/mymonies/pkg/rpc/mymonies"
 )
 
-func New(conn string, logger Logger) (mymonies.Mymonies, error) {
-	db, err := database.Connect(conn)
-	if err != nil {
-		return nil, err
+// DBOptions configure the database connection of Open.
+type DBOptions struct {
+	// SlowQuery is the duration of queries logged as warnings, see
+	// database.Storage.LogQueries.
+	SlowQuery time.Duration
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime configure the
+	// connection pool of SQL databases, see sql.DB. Zero values keep the
+	// defaults of database/sql.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// ConnectTimeout is how long to retry connecting while the database is
+	// not available, e.g. starting at the same time as the server. Zero
+	// tries once.
+	ConnectTimeout time.Duration
+}
+
+// maxRetryWait is the longest wait between connection attempts.
+const maxRetryWait = 10 * time.Second
+
+// Open connects to the database and migrates it to the latest schema.
+// Connecting is retried with exponential backoff until opts.ConnectTimeout
+// or until ctx is done.
+func Open(ctx context.Context, conn string, logger *slog.Logger, opts DBOptions) (database.Storage, error) {
+	deadline := time.Now().Add(opts.ConnectTimeout)
+	wait := 500 * time.Millisecond
+	var db database.Storage
+	for {
+		var err error
+		db, err = database.Open(conn)
+		if err == nil {
+			break
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, err
+		}
+		if wait > remaining {
+			wait = remaining
+		}
+		logger.Warn("database is not available, retrying", "error", err, "wait", wait)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > maxRetryWait {
+			wait = maxRetryWait
+		}
+	}
+	if p, ok := db.(pool); ok {
+		if opts.MaxOpenConns > 0 {
+			p.SetMaxOpenConns(opts.MaxOpenConns)
+		}
+		if opts.MaxIdleConns > 0 {
+			p.SetMaxIdleConns(opts.MaxIdleConns)
+		}
+		if opts.ConnMaxLifetime > 0 {
+			p.SetConnMaxLifetime(opts.ConnMaxLifetime)
+		}
 	}
-	logger.Println("Connected to database")
-	if err := db.CreateTables(); err != nil {
+	db.LogQueries(logger, opts.SlowQuery)
+	logger.Info("connected to database")
+	if err := db.Migrate(); err != nil {
+		db.Close()
 		return nil, err
 	}
-	server := &server{DB: db, logger: logger}
-	return server, nil
+	return db, nil
+}
+
+// pool is implemented by storages with a connection pool, i.e. *sqlx.DB.
+type pool interface {
+	SetMaxOpenConns(n int)
+	SetMaxIdleConns(n int)
+	SetConnMaxLifetime(d time.Duration)
+}
+
+// New returns a server with data in db. Changes to the data are published
+// to hub and imported and tagged records counted in m, if not nil.
+func New(db database.Storage, hub *events.Hub, m *metrics.Metrics, logger *slog.Logger) mymonies.Mymonies {
+	return &server{DB: db, Events: hub, Metrics: m, logger: logger}
 }
 
 type server struct {
-	DB *database.Postgres
+	DB      database.Storage
+	Events  *events.Hub
+	Metrics *metrics.Metrics
+
+	logger *slog.Logger
+}
 
-	logger Logger
+// db returns the storage logging queries with ctx, the context of the
+// request being served.
+func (s *server) db(ctx context.Context) database.Storage {
+	return s.DB.WithContext(ctx)
 }
 
-// Logger is a logging interface compatible with log.Logger.
-type Logger interface {
-	Println(args ...interface{})
+// writeError writes a Twirp error response from a plain HTTP handler, such
+// as the events and export endpoints.
+func writeError(w http.ResponseWriter, twerr twirp.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(twirp.ServerHTTPStatusFromErrorCode(twerr.Code()))
+	json.NewEncoder(w).Encode(map[string]string{
+		"code": string(twerr.Code()),
+		"msg":  twerr.Msg(),
+	})
 }
@@ -1,2 +0,0 @@
-INSERT INTO tags (name) VALUES ('example');
-INSERT INTO tags (name) VALUES ('example2');
@@ -13,16 +13,39 @@ It has these top-level messages:
 	Transaction
 	TransactionFilter
 	Pattern
+	ExchangeRate
+	Total
+	Household
+	HouseholdMember
+	TagChange
+	AddExchangeRatesReq
+	AddExchangeRatesResp
 	AddImportReq
 	AddImportResp
 	AddPatternReq
 	AddPatternResp
+	AddTagReq
+	AddTagResp
+	BulkUpdateTagReq
+	BulkUpdateTagResp
 	ListAccountsReq
 	ListAccountsResp
+	ListHouseholdMembersReq
+	ListHouseholdMembersResp
+	ListHouseholdsReq
+	ListHouseholdsResp
+	ListTagHistoryReq
+	ListTagHistoryResp
 	ListTagsReq
 	ListTagsResp
+	ListTotalsReq
+	ListTotalsResp
 	ListTransactionsReq
 	ListTransactionsResp
+	SetHouseholdMemberReq
+	SetHouseholdMemberResp
+	UndoReq
+	UndoResp
 	UpdateTagReq
 	UpdateTagResp
 */
@@ -44,7 +67,8 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Account struct {
-	Number string `protobuf:"bytes,1,opt,name=number" json:"number,omitempty"`
+	Number   string `protobuf:"bytes,1,opt,name=number" json:"number,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency" json:"currency,omitempty"`
 }
 
 func (m *Account) Reset()                    { *m = Account{} }
@@ -59,6 +83,13 @@ func (m *Account) GetNumber() string {
 	return ""
 }
 
+func (m *Account) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type Tag struct {
 	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -84,21 +115,23 @@ func (m *Tag) GetName() string {
 }
 
 type Transaction struct {
-	Id              string  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	TransactionDate string  `protobuf:"bytes,2,opt,name=transaction_date,json=transactionDate" json:"transaction_date,omitempty"`
-	ValueDate       string  `protobuf:"bytes,3,opt,name=value_date,json=valueDate" json:"value_date,omitempty"`
-	PaymentDate     string  `protobuf:"bytes,4,opt,name=payment_date,json=paymentDate" json:"payment_date,omitempty"`
-	Amount          float64 `protobuf:"fixed64,5,opt,name=amount" json:"amount,omitempty"`
-	PayeePayer      string  `protobuf:"bytes,6,opt,name=payee_payer,json=payeePayer" json:"payee_payer,omitempty"`
-	Account         string  `protobuf:"bytes,7,opt,name=account" json:"account,omitempty"`
-	Bic             string  `protobuf:"bytes,8,opt,name=bic" json:"bic,omitempty"`
-	Transaction     string  `protobuf:"bytes,9,opt,name=transaction" json:"transaction,omitempty"`
-	Reference       string  `protobuf:"bytes,10,opt,name=reference" json:"reference,omitempty"`
-	PayerReference  string  `protobuf:"bytes,11,opt,name=payer_reference,json=payerReference" json:"payer_reference,omitempty"`
-	Message         string  `protobuf:"bytes,12,opt,name=message" json:"message,omitempty"`
-	CardNumber      string  `protobuf:"bytes,13,opt,name=card_number,json=cardNumber" json:"card_number,omitempty"`
-	TagId           string  `protobuf:"bytes,14,opt,name=tag_id,json=tagId" json:"tag_id,omitempty"`
-	ImportId        string  `protobuf:"bytes,15,opt,name=import_id,json=importId" json:"import_id,omitempty"`
+	Id              string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	TransactionDate string `protobuf:"bytes,2,opt,name=transaction_date,json=transactionDate" json:"transaction_date,omitempty"`
+	ValueDate       string `protobuf:"bytes,3,opt,name=value_date,json=valueDate" json:"value_date,omitempty"`
+	PaymentDate     string `protobuf:"bytes,4,opt,name=payment_date,json=paymentDate" json:"payment_date,omitempty"`
+	Amount          string `protobuf:"bytes,17,opt,name=amount" json:"amount,omitempty"`
+	PayeePayer      string `protobuf:"bytes,6,opt,name=payee_payer,json=payeePayer" json:"payee_payer,omitempty"`
+	Account         string `protobuf:"bytes,7,opt,name=account" json:"account,omitempty"`
+	Bic             string `protobuf:"bytes,8,opt,name=bic" json:"bic,omitempty"`
+	Transaction     string `protobuf:"bytes,9,opt,name=transaction" json:"transaction,omitempty"`
+	Reference       string `protobuf:"bytes,10,opt,name=reference" json:"reference,omitempty"`
+	PayerReference  string `protobuf:"bytes,11,opt,name=payer_reference,json=payerReference" json:"payer_reference,omitempty"`
+	Message         string `protobuf:"bytes,12,opt,name=message" json:"message,omitempty"`
+	CardNumber      string `protobuf:"bytes,13,opt,name=card_number,json=cardNumber" json:"card_number,omitempty"`
+	TagId           string `protobuf:"bytes,14,opt,name=tag_id,json=tagId" json:"tag_id,omitempty"`
+	ImportId        string `protobuf:"bytes,15,opt,name=import_id,json=importId" json:"import_id,omitempty"`
+	Currency        string `protobuf:"bytes,16,opt,name=currency" json:"currency,omitempty"`
+	Version         int32  `protobuf:"varint,18,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *Transaction) Reset()                    { *m = Transaction{} }
@@ -134,11 +167,11 @@ func (m *Transaction) GetPaymentDate() string {
 	return ""
 }
 
-func (m *Transaction) GetAmount() float64 {
+func (m *Transaction) GetAmount() string {
 	if m != nil {
 		return m.Amount
 	}
-	return 0
+	return ""
 }
 
 func (m *Transaction) GetPayeePayer() string {
@@ -211,6 +244,20 @@ func (m *Transaction) GetImportId() string {
 	return ""
 }
 
+func (m *Transaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Transaction) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type TransactionFilter struct {
 	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Account string `protobuf:"bytes,2,opt,name=account" json:"account,omitempty"`
@@ -283,16 +330,273 @@ func (m *Pattern) GetTagId() string {
 	return ""
 }
 
+type ExchangeRate struct {
+	Date     string `protobuf:"bytes,1,opt,name=date" json:"date,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency" json:"currency,omitempty"`
+	Rate     string `protobuf:"bytes,3,opt,name=rate" json:"rate,omitempty"`
+}
+
+func (m *ExchangeRate) Reset()                    { *m = ExchangeRate{} }
+func (m *ExchangeRate) String() string            { return proto.CompactTextString(m) }
+func (*ExchangeRate) ProtoMessage()               {}
+func (*ExchangeRate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ExchangeRate) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *ExchangeRate) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *ExchangeRate) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+type Total struct {
+	Month    string `protobuf:"bytes,1,opt,name=month" json:"month,omitempty"`
+	TagId    string `protobuf:"bytes,2,opt,name=tag_id,json=tagId" json:"tag_id,omitempty"`
+	Currency string `protobuf:"bytes,3,opt,name=currency" json:"currency,omitempty"`
+	Amount   string `protobuf:"bytes,4,opt,name=amount" json:"amount,omitempty"`
+	Count    int32  `protobuf:"varint,5,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *Total) Reset()                    { *m = Total{} }
+func (m *Total) String() string            { return proto.CompactTextString(m) }
+func (*Total) ProtoMessage()               {}
+func (*Total) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Total) GetMonth() string {
+	if m != nil {
+		return m.Month
+	}
+	return ""
+}
+
+func (m *Total) GetTagId() string {
+	if m != nil {
+		return m.TagId
+	}
+	return ""
+}
+
+func (m *Total) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Total) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Total) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Household struct {
+	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Role string `protobuf:"bytes,3,opt,name=role" json:"role,omitempty"`
+}
+
+func (m *Household) Reset()                    { *m = Household{} }
+func (m *Household) String() string            { return proto.CompactTextString(m) }
+func (*Household) ProtoMessage()               {}
+func (*Household) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Household) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Household) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Household) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+type HouseholdMember struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Role     string `protobuf:"bytes,2,opt,name=role" json:"role,omitempty"`
+}
+
+func (m *HouseholdMember) Reset()                    { *m = HouseholdMember{} }
+func (m *HouseholdMember) String() string            { return proto.CompactTextString(m) }
+func (*HouseholdMember) ProtoMessage()               {}
+func (*HouseholdMember) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *HouseholdMember) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *HouseholdMember) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+type TagChange struct {
+	OperationId   string `protobuf:"bytes,1,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
+	OldTagId      string `protobuf:"bytes,3,opt,name=old_tag_id,json=oldTagId" json:"old_tag_id,omitempty"`
+	NewTagId      string `protobuf:"bytes,4,opt,name=new_tag_id,json=newTagId" json:"new_tag_id,omitempty"`
+	Username      string `protobuf:"bytes,5,opt,name=username" json:"username,omitempty"`
+	Time          string `protobuf:"bytes,6,opt,name=time" json:"time,omitempty"`
+	Cause         string `protobuf:"bytes,7,opt,name=cause" json:"cause,omitempty"`
+	PatternId     string `protobuf:"bytes,8,opt,name=pattern_id,json=patternId" json:"pattern_id,omitempty"`
+	ImportId      string `protobuf:"bytes,9,opt,name=import_id,json=importId" json:"import_id,omitempty"`
+	UndoOf        string `protobuf:"bytes,10,opt,name=undo_of,json=undoOf" json:"undo_of,omitempty"`
+	Undone        bool   `protobuf:"varint,11,opt,name=undone" json:"undone,omitempty"`
+}
+
+func (m *TagChange) Reset()                    { *m = TagChange{} }
+func (m *TagChange) String() string            { return proto.CompactTextString(m) }
+func (*TagChange) ProtoMessage()               {}
+func (*TagChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *TagChange) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *TagChange) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *TagChange) GetOldTagId() string {
+	if m != nil {
+		return m.OldTagId
+	}
+	return ""
+}
+
+func (m *TagChange) GetNewTagId() string {
+	if m != nil {
+		return m.NewTagId
+	}
+	return ""
+}
+
+func (m *TagChange) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *TagChange) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *TagChange) GetCause() string {
+	if m != nil {
+		return m.Cause
+	}
+	return ""
+}
+
+func (m *TagChange) GetPatternId() string {
+	if m != nil {
+		return m.PatternId
+	}
+	return ""
+}
+
+func (m *TagChange) GetImportId() string {
+	if m != nil {
+		return m.ImportId
+	}
+	return ""
+}
+
+func (m *TagChange) GetUndoOf() string {
+	if m != nil {
+		return m.UndoOf
+	}
+	return ""
+}
+
+func (m *TagChange) GetUndone() bool {
+	if m != nil {
+		return m.Undone
+	}
+	return false
+}
+
+type AddExchangeRatesReq struct {
+	Rates []*ExchangeRate `protobuf:"bytes,1,rep,name=rates" json:"rates,omitempty"`
+}
+
+func (m *AddExchangeRatesReq) Reset()                    { *m = AddExchangeRatesReq{} }
+func (m *AddExchangeRatesReq) String() string            { return proto.CompactTextString(m) }
+func (*AddExchangeRatesReq) ProtoMessage()               {}
+func (*AddExchangeRatesReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *AddExchangeRatesReq) GetRates() []*ExchangeRate {
+	if m != nil {
+		return m.Rates
+	}
+	return nil
+}
+
+type AddExchangeRatesResp struct {
+}
+
+func (m *AddExchangeRatesResp) Reset()                    { *m = AddExchangeRatesResp{} }
+func (m *AddExchangeRatesResp) String() string            { return proto.CompactTextString(m) }
+func (*AddExchangeRatesResp) ProtoMessage()               {}
+func (*AddExchangeRatesResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
 type AddImportReq struct {
 	Account      string         `protobuf:"bytes,1,opt,name=account" json:"account,omitempty"`
 	FileName     string         `protobuf:"bytes,2,opt,name=file_name,json=fileName" json:"file_name,omitempty"`
 	Transactions []*Transaction `protobuf:"bytes,3,rep,name=transactions" json:"transactions,omitempty"`
+	Currency     string         `protobuf:"bytes,4,opt,name=currency" json:"currency,omitempty"`
 }
 
 func (m *AddImportReq) Reset()                    { *m = AddImportReq{} }
 func (m *AddImportReq) String() string            { return proto.CompactTextString(m) }
 func (*AddImportReq) ProtoMessage()               {}
-func (*AddImportReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*AddImportReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *AddImportReq) GetAccount() string {
 	if m != nil {
@@ -315,13 +619,20 @@ func (m *AddImportReq) GetTransactions() []*Transaction {
 	return nil
 }
 
+func (m *AddImportReq) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type AddImportResp struct {
 }
 
 func (m *AddImportResp) Reset()                    { *m = AddImportResp{} }
 func (m *AddImportResp) String() string            { return proto.CompactTextString(m) }
 func (*AddImportResp) ProtoMessage()               {}
-func (*AddImportResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*AddImportResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 type AddPatternReq struct {
 	Pattern *Pattern `protobuf:"bytes,1,opt,name=pattern" json:"pattern,omitempty"`
@@ -330,7 +641,7 @@ type AddPatternReq struct {
 func (m *AddPatternReq) Reset()                    { *m = AddPatternReq{} }
 func (m *AddPatternReq) String() string            { return proto.CompactTextString(m) }
 func (*AddPatternReq) ProtoMessage()               {}
-func (*AddPatternReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*AddPatternReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *AddPatternReq) GetPattern() *Pattern {
 	if m != nil {
@@ -340,12 +651,109 @@ func (m *AddPatternReq) GetPattern() *Pattern {
 }
 
 type AddPatternResp struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
 }
 
 func (m *AddPatternResp) Reset()                    { *m = AddPatternResp{} }
 func (m *AddPatternResp) String() string            { return proto.CompactTextString(m) }
 func (*AddPatternResp) ProtoMessage()               {}
-func (*AddPatternResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*AddPatternResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *AddPatternResp) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+type AddTagReq struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *AddTagReq) Reset()                    { *m = AddTagReq{} }
+func (m *AddTagReq) String() string            { return proto.CompactTextString(m) }
+func (*AddTagReq) ProtoMessage()               {}
+func (*AddTagReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *AddTagReq) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type AddTagResp struct {
+	Tag *Tag `protobuf:"bytes,1,opt,name=tag" json:"tag,omitempty"`
+}
+
+func (m *AddTagResp) Reset()                    { *m = AddTagResp{} }
+func (m *AddTagResp) String() string            { return proto.CompactTextString(m) }
+func (*AddTagResp) ProtoMessage()               {}
+func (*AddTagResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *AddTagResp) GetTag() *Tag {
+	if m != nil {
+		return m.Tag
+	}
+	return nil
+}
+
+type BulkUpdateTagReq struct {
+	// Transactions to tag, either by id or by filter.
+	TransactionIds []string           `protobuf:"bytes,1,rep,name=transaction_ids,json=transactionIds" json:"transaction_ids,omitempty"`
+	Filter         *TransactionFilter `protobuf:"bytes,2,opt,name=filter" json:"filter,omitempty"`
+	TagId          string             `protobuf:"bytes,3,opt,name=tag_id,json=tagId" json:"tag_id,omitempty"`
+}
+
+func (m *BulkUpdateTagReq) Reset()                    { *m = BulkUpdateTagReq{} }
+func (m *BulkUpdateTagReq) String() string            { return proto.CompactTextString(m) }
+func (*BulkUpdateTagReq) ProtoMessage()               {}
+func (*BulkUpdateTagReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *BulkUpdateTagReq) GetTransactionIds() []string {
+	if m != nil {
+		return m.TransactionIds
+	}
+	return nil
+}
+
+func (m *BulkUpdateTagReq) GetFilter() *TransactionFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *BulkUpdateTagReq) GetTagId() string {
+	if m != nil {
+		return m.TagId
+	}
+	return ""
+}
+
+type BulkUpdateTagResp struct {
+	Count       int32  `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	OperationId string `protobuf:"bytes,2,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
+}
+
+func (m *BulkUpdateTagResp) Reset()                    { *m = BulkUpdateTagResp{} }
+func (m *BulkUpdateTagResp) String() string            { return proto.CompactTextString(m) }
+func (*BulkUpdateTagResp) ProtoMessage()               {}
+func (*BulkUpdateTagResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *BulkUpdateTagResp) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *BulkUpdateTagResp) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
 
 type ListAccountsReq struct {
 }
@@ -353,7 +761,7 @@ type ListAccountsReq struct {
 func (m *ListAccountsReq) Reset()                    { *m = ListAccountsReq{} }
 func (m *ListAccountsReq) String() string            { return proto.CompactTextString(m) }
 func (*ListAccountsReq) ProtoMessage()               {}
-func (*ListAccountsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*ListAccountsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 type ListAccountsResp struct {
 	Accounts []*Account `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
@@ -362,7 +770,7 @@ type ListAccountsResp struct {
 func (m *ListAccountsResp) Reset()                    { *m = ListAccountsResp{} }
 func (m *ListAccountsResp) String() string            { return proto.CompactTextString(m) }
 func (*ListAccountsResp) ProtoMessage()               {}
-func (*ListAccountsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*ListAccountsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ListAccountsResp) GetAccounts() []*Account {
 	if m != nil {
@@ -371,13 +779,93 @@ func (m *ListAccountsResp) GetAccounts() []*Account {
 	return nil
 }
 
+type ListHouseholdMembersReq struct {
+}
+
+func (m *ListHouseholdMembersReq) Reset()                    { *m = ListHouseholdMembersReq{} }
+func (m *ListHouseholdMembersReq) String() string            { return proto.CompactTextString(m) }
+func (*ListHouseholdMembersReq) ProtoMessage()               {}
+func (*ListHouseholdMembersReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+type ListHouseholdMembersResp struct {
+	Members []*HouseholdMember `protobuf:"bytes,1,rep,name=members" json:"members,omitempty"`
+}
+
+func (m *ListHouseholdMembersResp) Reset()                    { *m = ListHouseholdMembersResp{} }
+func (m *ListHouseholdMembersResp) String() string            { return proto.CompactTextString(m) }
+func (*ListHouseholdMembersResp) ProtoMessage()               {}
+func (*ListHouseholdMembersResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ListHouseholdMembersResp) GetMembers() []*HouseholdMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type ListHouseholdsReq struct {
+}
+
+func (m *ListHouseholdsReq) Reset()                    { *m = ListHouseholdsReq{} }
+func (m *ListHouseholdsReq) String() string            { return proto.CompactTextString(m) }
+func (*ListHouseholdsReq) ProtoMessage()               {}
+func (*ListHouseholdsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+type ListHouseholdsResp struct {
+	Households []*Household `protobuf:"bytes,1,rep,name=households" json:"households,omitempty"`
+}
+
+func (m *ListHouseholdsResp) Reset()                    { *m = ListHouseholdsResp{} }
+func (m *ListHouseholdsResp) String() string            { return proto.CompactTextString(m) }
+func (*ListHouseholdsResp) ProtoMessage()               {}
+func (*ListHouseholdsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ListHouseholdsResp) GetHouseholds() []*Household {
+	if m != nil {
+		return m.Households
+	}
+	return nil
+}
+
+type ListTagHistoryReq struct {
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
+}
+
+func (m *ListTagHistoryReq) Reset()                    { *m = ListTagHistoryReq{} }
+func (m *ListTagHistoryReq) String() string            { return proto.CompactTextString(m) }
+func (*ListTagHistoryReq) ProtoMessage()               {}
+func (*ListTagHistoryReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *ListTagHistoryReq) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+type ListTagHistoryResp struct {
+	Changes []*TagChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *ListTagHistoryResp) Reset()                    { *m = ListTagHistoryResp{} }
+func (m *ListTagHistoryResp) String() string            { return proto.CompactTextString(m) }
+func (*ListTagHistoryResp) ProtoMessage()               {}
+func (*ListTagHistoryResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *ListTagHistoryResp) GetChanges() []*TagChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
 type ListTagsReq struct {
 }
 
 func (m *ListTagsReq) Reset()                    { *m = ListTagsReq{} }
 func (m *ListTagsReq) String() string            { return proto.CompactTextString(m) }
 func (*ListTagsReq) ProtoMessage()               {}
-func (*ListTagsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ListTagsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 type ListTagsResp struct {
 	Tags []*Tag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
@@ -386,7 +874,7 @@ type ListTagsResp struct {
 func (m *ListTagsResp) Reset()                    { *m = ListTagsResp{} }
 func (m *ListTagsResp) String() string            { return proto.CompactTextString(m) }
 func (*ListTagsResp) ProtoMessage()               {}
-func (*ListTagsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*ListTagsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *ListTagsResp) GetTags() []*Tag {
 	if m != nil {
@@ -395,6 +883,48 @@ func (m *ListTagsResp) GetTags() []*Tag {
 	return nil
 }
 
+type ListTotalsReq struct {
+	Filter *TransactionFilter `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
+	// Convert amounts to ISO 4217 currency using the exchange rate of each
+	// transaction date. If empty, totals are listed separately per currency.
+	BaseCurrency string `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency" json:"base_currency,omitempty"`
+}
+
+func (m *ListTotalsReq) Reset()                    { *m = ListTotalsReq{} }
+func (m *ListTotalsReq) String() string            { return proto.CompactTextString(m) }
+func (*ListTotalsReq) ProtoMessage()               {}
+func (*ListTotalsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ListTotalsReq) GetFilter() *TransactionFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *ListTotalsReq) GetBaseCurrency() string {
+	if m != nil {
+		return m.BaseCurrency
+	}
+	return ""
+}
+
+type ListTotalsResp struct {
+	Totals []*Total `protobuf:"bytes,1,rep,name=totals" json:"totals,omitempty"`
+}
+
+func (m *ListTotalsResp) Reset()                    { *m = ListTotalsResp{} }
+func (m *ListTotalsResp) String() string            { return proto.CompactTextString(m) }
+func (*ListTotalsResp) ProtoMessage()               {}
+func (*ListTotalsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ListTotalsResp) GetTotals() []*Total {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
 type ListTransactionsReq struct {
 	Filter *TransactionFilter `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
 }
@@ -402,7 +932,7 @@ type ListTransactionsReq struct {
 func (m *ListTransactionsReq) Reset()                    { *m = ListTransactionsReq{} }
 func (m *ListTransactionsReq) String() string            { return proto.CompactTextString(m) }
 func (*ListTransactionsReq) ProtoMessage()               {}
-func (*ListTransactionsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*ListTransactionsReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *ListTransactionsReq) GetFilter() *TransactionFilter {
 	if m != nil {
@@ -418,7 +948,7 @@ type ListTransactionsResp struct {
 func (m *ListTransactionsResp) Reset()                    { *m = ListTransactionsResp{} }
 func (m *ListTransactionsResp) String() string            { return proto.CompactTextString(m) }
 func (*ListTransactionsResp) ProtoMessage()               {}
-func (*ListTransactionsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ListTransactionsResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *ListTransactionsResp) GetTransactions() []*Transaction {
 	if m != nil {
@@ -427,15 +957,83 @@ func (m *ListTransactionsResp) GetTransactions() []*Transaction {
 	return nil
 }
 
+type SetHouseholdMemberReq struct {
+	Username string `protobuf:"bytes,1,opt,name=username" json:"username,omitempty"`
+	Role     string `protobuf:"bytes,2,opt,name=role" json:"role,omitempty"`
+}
+
+func (m *SetHouseholdMemberReq) Reset()                    { *m = SetHouseholdMemberReq{} }
+func (m *SetHouseholdMemberReq) String() string            { return proto.CompactTextString(m) }
+func (*SetHouseholdMemberReq) ProtoMessage()               {}
+func (*SetHouseholdMemberReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *SetHouseholdMemberReq) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *SetHouseholdMemberReq) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+type SetHouseholdMemberResp struct {
+}
+
+func (m *SetHouseholdMemberResp) Reset()                    { *m = SetHouseholdMemberResp{} }
+func (m *SetHouseholdMemberResp) String() string            { return proto.CompactTextString(m) }
+func (*SetHouseholdMemberResp) ProtoMessage()               {}
+func (*SetHouseholdMemberResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+type UndoReq struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
+}
+
+func (m *UndoReq) Reset()                    { *m = UndoReq{} }
+func (m *UndoReq) String() string            { return proto.CompactTextString(m) }
+func (*UndoReq) ProtoMessage()               {}
+func (*UndoReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *UndoReq) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+type UndoResp struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
+}
+
+func (m *UndoResp) Reset()                    { *m = UndoResp{} }
+func (m *UndoResp) String() string            { return proto.CompactTextString(m) }
+func (*UndoResp) ProtoMessage()               {}
+func (*UndoResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *UndoResp) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
 type UpdateTagReq struct {
 	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
 	TagId         string `protobuf:"bytes,2,opt,name=tag_id,json=tagId" json:"tag_id,omitempty"`
+	// Version of the transaction the change is based on. If the transaction
+	// has been changed since, the update fails with an aborted error with the
+	// current tag_id and version in the error metadata.
+	Version int32 `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *UpdateTagReq) Reset()                    { *m = UpdateTagReq{} }
 func (m *UpdateTagReq) String() string            { return proto.CompactTextString(m) }
 func (*UpdateTagReq) ProtoMessage()               {}
-func (*UpdateTagReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*UpdateTagReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *UpdateTagReq) GetTransactionId() string {
 	if m != nil {
@@ -451,13 +1049,36 @@ func (m *UpdateTagReq) GetTagId() string {
 	return ""
 }
 
+func (m *UpdateTagReq) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type UpdateTagResp struct {
+	OperationId string `protobuf:"bytes,1,opt,name=operation_id,json=operationId" json:"operation_id,omitempty"`
+	Version     int32  `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
 }
 
 func (m *UpdateTagResp) Reset()                    { *m = UpdateTagResp{} }
 func (m *UpdateTagResp) String() string            { return proto.CompactTextString(m) }
 func (*UpdateTagResp) ProtoMessage()               {}
-func (*UpdateTagResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*UpdateTagResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *UpdateTagResp) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func (m *UpdateTagResp) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
 
 func init() {
 	proto.RegisterType((*Account)(nil), "com.github.joneskoo.mymonies.Account")
@@ -465,16 +1086,39 @@ func init() {
 	proto.RegisterType((*Transaction)(nil), "com.github.joneskoo.mymonies.Transaction")
 	proto.RegisterType((*TransactionFilter)(nil), "com.github.joneskoo.mymonies.TransactionFilter")
 	proto.RegisterType((*Pattern)(nil), "com.github.joneskoo.mymonies.Pattern")
+	proto.RegisterType((*ExchangeRate)(nil), "com.github.joneskoo.mymonies.ExchangeRate")
+	proto.RegisterType((*Total)(nil), "com.github.joneskoo.mymonies.Total")
+	proto.RegisterType((*Household)(nil), "com.github.joneskoo.mymonies.Household")
+	proto.RegisterType((*HouseholdMember)(nil), "com.github.joneskoo.mymonies.HouseholdMember")
+	proto.RegisterType((*TagChange)(nil), "com.github.joneskoo.mymonies.TagChange")
+	proto.RegisterType((*AddExchangeRatesReq)(nil), "com.github.joneskoo.mymonies.AddExchangeRatesReq")
+	proto.RegisterType((*AddExchangeRatesResp)(nil), "com.github.joneskoo.mymonies.AddExchangeRatesResp")
 	proto.RegisterType((*AddImportReq)(nil), "com.github.joneskoo.mymonies.AddImportReq")
 	proto.RegisterType((*AddImportResp)(nil), "com.github.joneskoo.mymonies.AddImportResp")
 	proto.RegisterType((*AddPatternReq)(nil), "com.github.joneskoo.mymonies.AddPatternReq")
 	proto.RegisterType((*AddPatternResp)(nil), "com.github.joneskoo.mymonies.AddPatternResp")
+	proto.RegisterType((*AddTagReq)(nil), "com.github.joneskoo.mymonies.AddTagReq")
+	proto.RegisterType((*AddTagResp)(nil), "com.github.joneskoo.mymonies.AddTagResp")
+	proto.RegisterType((*BulkUpdateTagReq)(nil), "com.github.joneskoo.mymonies.BulkUpdateTagReq")
+	proto.RegisterType((*BulkUpdateTagResp)(nil), "com.github.joneskoo.mymonies.BulkUpdateTagResp")
 	proto.RegisterType((*ListAccountsReq)(nil), "com.github.joneskoo.mymonies.ListAccountsReq")
 	proto.RegisterType((*ListAccountsResp)(nil), "com.github.joneskoo.mymonies.ListAccountsResp")
+	proto.RegisterType((*ListHouseholdMembersReq)(nil), "com.github.joneskoo.mymonies.ListHouseholdMembersReq")
+	proto.RegisterType((*ListHouseholdMembersResp)(nil), "com.github.joneskoo.mymonies.ListHouseholdMembersResp")
+	proto.RegisterType((*ListHouseholdsReq)(nil), "com.github.joneskoo.mymonies.ListHouseholdsReq")
+	proto.RegisterType((*ListHouseholdsResp)(nil), "com.github.joneskoo.mymonies.ListHouseholdsResp")
+	proto.RegisterType((*ListTagHistoryReq)(nil), "com.github.joneskoo.mymonies.ListTagHistoryReq")
+	proto.RegisterType((*ListTagHistoryResp)(nil), "com.github.joneskoo.mymonies.ListTagHistoryResp")
 	proto.RegisterType((*ListTagsReq)(nil), "com.github.joneskoo.mymonies.ListTagsReq")
 	proto.RegisterType((*ListTagsResp)(nil), "com.github.joneskoo.mymonies.ListTagsResp")
+	proto.RegisterType((*ListTotalsReq)(nil), "com.github.joneskoo.mymonies.ListTotalsReq")
+	proto.RegisterType((*ListTotalsResp)(nil), "com.github.joneskoo.mymonies.ListTotalsResp")
 	proto.RegisterType((*ListTransactionsReq)(nil), "com.github.joneskoo.mymonies.ListTransactionsReq")
 	proto.RegisterType((*ListTransactionsResp)(nil), "com.github.joneskoo.mymonies.ListTransactionsResp")
+	proto.RegisterType((*SetHouseholdMemberReq)(nil), "com.github.joneskoo.mymonies.SetHouseholdMemberReq")
+	proto.RegisterType((*SetHouseholdMemberResp)(nil), "com.github.joneskoo.mymonies.SetHouseholdMemberResp")
+	proto.RegisterType((*UndoReq)(nil), "com.github.joneskoo.mymonies.UndoReq")
+	proto.RegisterType((*UndoResp)(nil), "com.github.joneskoo.mymonies.UndoResp")
 	proto.RegisterType((*UpdateTagReq)(nil), "com.github.joneskoo.mymonies.UpdateTagReq")
 	proto.RegisterType((*UpdateTagResp)(nil), "com.github.joneskoo.mymonies.UpdateTagResp")
 }
@@ -482,53 +1126,99 @@ func init() {
 func init() { proto.RegisterFile("service.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 765 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdb, 0x6a, 0xdb, 0x4a,
-	0x14, 0x45, 0x76, 0x7c, 0xdb, 0xbe, 0x66, 0x4e, 0x4e, 0x10, 0x4e, 0x0e, 0xc7, 0x11, 0x84, 0xc6,
-	0x69, 0xeb, 0x52, 0x97, 0x3e, 0x97, 0x94, 0x5e, 0x30, 0x24, 0xc1, 0x88, 0xe4, 0xa5, 0x0f, 0x35,
-	0x63, 0x69, 0xa2, 0xa8, 0x8d, 0xa4, 0xb1, 0x66, 0x1c, 0xf0, 0x3f, 0xf4, 0xb1, 0xff, 0xd4, 0xdf,
-	0x2a, 0x73, 0x91, 0x3d, 0xae, 0xc1, 0x72, 0xe8, 0x8b, 0xd1, 0xac, 0xbd, 0xd6, 0xd6, 0xbe, 0xac,
-	0x11, 0x86, 0x26, 0x23, 0xe9, 0x63, 0xe8, 0x91, 0x01, 0x4d, 0x13, 0x9e, 0xa0, 0x63, 0x2f, 0x89,
-	0x06, 0x41, 0xc8, 0xef, 0xe7, 0xd3, 0xc1, 0xb7, 0x24, 0x26, 0xec, 0x7b, 0x92, 0x0c, 0xa2, 0x45,
-	0x94, 0xc4, 0x21, 0x61, 0xce, 0x09, 0x54, 0x2e, 0x3c, 0x2f, 0x99, 0xc7, 0x1c, 0x1d, 0x42, 0x39,
-	0x9e, 0x47, 0x53, 0x92, 0xda, 0x56, 0xcf, 0x3a, 0xab, 0xb9, 0xfa, 0xe4, 0xf4, 0xa1, 0x78, 0x83,
-	0x03, 0xd4, 0x82, 0x42, 0xe8, 0xeb, 0x50, 0x21, 0xf4, 0x11, 0x82, 0xbd, 0x18, 0x47, 0xc4, 0x2e,
-	0x48, 0x44, 0x3e, 0x3b, 0xbf, 0x8a, 0x50, 0xbf, 0x49, 0x71, 0xcc, 0xb0, 0xc7, 0xc3, 0x24, 0xde,
-	0xd0, 0xf4, 0xa1, 0xc3, 0x57, 0xe1, 0x89, 0x8f, 0x79, 0xa6, 0x6f, 0x1b, 0xf8, 0x07, 0xcc, 0x09,
-	0xfa, 0x0f, 0xe0, 0x11, 0x3f, 0xcc, 0x89, 0x22, 0x15, 0x25, 0xa9, 0x26, 0x11, 0x19, 0x3e, 0x81,
-	0x06, 0xc5, 0x8b, 0x88, 0xc4, 0x5c, 0x11, 0xf6, 0x24, 0xa1, 0xae, 0x31, 0x49, 0x39, 0x84, 0x32,
-	0x8e, 0x44, 0x67, 0x76, 0xa9, 0x67, 0x9d, 0x59, 0xae, 0x3e, 0xa1, 0xff, 0x41, 0xd0, 0x08, 0x99,
-	0x88, 0xdf, 0xd4, 0x2e, 0x4b, 0x25, 0x48, 0x68, 0x2c, 0x10, 0x64, 0x43, 0x05, 0xab, 0x99, 0xd8,
-	0x15, 0x19, 0xcc, 0x8e, 0xa8, 0x03, 0xc5, 0x69, 0xe8, 0xd9, 0x55, 0x89, 0x8a, 0x47, 0xd4, 0x83,
-	0xba, 0x51, 0xb9, 0x5d, 0x53, 0x65, 0x18, 0x10, 0x3a, 0x86, 0x5a, 0x4a, 0xee, 0x48, 0x4a, 0x62,
-	0x8f, 0xd8, 0xa0, 0xfa, 0x58, 0x02, 0xe8, 0x19, 0xb4, 0x65, 0x19, 0x93, 0x15, 0xa7, 0x2e, 0x39,
-	0x2d, 0x09, 0xbb, 0x4b, 0xa2, 0x0d, 0x95, 0x88, 0x30, 0x86, 0x03, 0x62, 0x37, 0x54, 0x51, 0xfa,
-	0x28, 0xfa, 0xf1, 0x70, 0xea, 0x4f, 0xf4, 0xf2, 0x9a, 0xaa, 0x1f, 0x01, 0x5d, 0x4b, 0x04, 0xfd,
-	0x0b, 0x65, 0x8e, 0x83, 0x49, 0xe8, 0xdb, 0x2d, 0x19, 0x2b, 0x71, 0x1c, 0x8c, 0x7c, 0x74, 0x04,
-	0xb5, 0x30, 0xa2, 0x49, 0xca, 0x45, 0xa4, 0x2d, 0x23, 0x55, 0x05, 0x8c, 0x7c, 0x27, 0x84, 0x7d,
-	0x63, 0x91, 0x9f, 0xc2, 0x07, 0x4e, 0xd2, 0x8d, 0x75, 0x1a, 0x83, 0x2a, 0xac, 0x0f, 0xea, 0x00,
-	0x4a, 0x51, 0x12, 0xf3, 0x7b, 0xbd, 0x38, 0x75, 0x10, 0xe8, 0x6c, 0x4e, 0xd2, 0x85, 0xde, 0x96,
-	0x3a, 0x38, 0x63, 0xa8, 0x8c, 0x31, 0xe7, 0x24, 0x8d, 0xcd, 0x84, 0xd6, 0x46, 0x42, 0x25, 0x2d,
-	0x18, 0x52, 0xa3, 0xb3, 0xa2, 0xd1, 0x99, 0xf3, 0xd3, 0x82, 0xc6, 0x85, 0xef, 0x8f, 0x64, 0x33,
-	0x2e, 0x99, 0x6d, 0xc9, 0x7b, 0x04, 0xb5, 0xbb, 0xf0, 0x81, 0x4c, 0x0c, 0x2b, 0x57, 0x05, 0x70,
-	0x8d, 0x23, 0x82, 0xae, 0xa0, 0x61, 0x6c, 0x92, 0xd9, 0xc5, 0x5e, 0xf1, 0xac, 0x3e, 0xec, 0x0f,
-	0xb6, 0xdd, 0xa8, 0x81, 0x31, 0x36, 0x77, 0x4d, 0xee, 0xb4, 0xa1, 0x69, 0x54, 0xc5, 0xa8, 0x33,
-	0x96, 0x80, 0x6e, 0x5e, 0xd4, 0xf9, 0x0e, 0x2a, 0x54, 0x9d, 0x64, 0x9d, 0xf5, 0xe1, 0xe9, 0xf6,
-	0x77, 0x65, 0xd2, 0x4c, 0xe5, 0x74, 0xa0, 0x65, 0x66, 0x64, 0xd4, 0xd9, 0x87, 0xf6, 0x65, 0xc8,
-	0xb8, 0xbe, 0xe4, 0xcc, 0x25, 0x33, 0xe7, 0x16, 0x3a, 0xeb, 0x10, 0xa3, 0xe8, 0x02, 0xaa, 0x7a,
-	0x24, 0xcc, 0xb6, 0x64, 0x9b, 0x39, 0xaf, 0xd6, 0x6a, 0x77, 0x29, 0x73, 0x9a, 0x50, 0x17, 0x69,
-	0x6f, 0x70, 0x20, 0xdf, 0xf2, 0x11, 0x1a, 0xab, 0x23, 0xa3, 0xe8, 0x2d, 0xec, 0x71, 0x1c, 0x64,
-	0xd9, 0x4f, 0x72, 0x86, 0x88, 0x03, 0x57, 0xd2, 0x9d, 0xaf, 0xf0, 0x8f, 0x4c, 0x63, 0x0c, 0x52,
-	0x4c, 0xea, 0x33, 0x94, 0xef, 0xa4, 0x29, 0xf5, 0xa0, 0x5e, 0xed, 0xbc, 0x14, 0xe5, 0x65, 0x57,
-	0xcb, 0x1d, 0x02, 0x07, 0x9b, 0xf9, 0x19, 0xdd, 0xd8, 0xbd, 0xf5, 0x77, 0xbb, 0xbf, 0x84, 0xc6,
-	0x2d, 0x15, 0x5f, 0x2a, 0xd1, 0x19, 0x99, 0xa1, 0x53, 0x68, 0x99, 0x5f, 0xc2, 0xe5, 0xb5, 0x6a,
-	0x1a, 0xe8, 0xc8, 0x37, 0x0c, 0x5e, 0x30, 0x0d, 0xde, 0x86, 0xa6, 0x91, 0x8d, 0xd1, 0xe1, 0x8f,
-	0x12, 0x54, 0xaf, 0x74, 0x15, 0xc8, 0x87, 0xda, 0xd2, 0x67, 0xe8, 0x3c, 0x67, 0x8d, 0xc6, 0x35,
-	0xe9, 0x3e, 0xdf, 0x99, 0xcb, 0x28, 0x0a, 0x00, 0x56, 0x56, 0x43, 0xf9, 0xd2, 0x95, 0xcd, 0xbb,
-	0x2f, 0x76, 0x27, 0x33, 0x8a, 0x22, 0x65, 0xa4, 0xcc, 0xae, 0xe8, 0xe5, 0x76, 0xf5, 0x1f, 0x6e,
-	0xef, 0x0e, 0x9e, 0x42, 0x67, 0x14, 0x61, 0xa8, 0x66, 0xbe, 0x45, 0xfd, 0x7c, 0xad, 0xb6, 0x7b,
-	0xf7, 0x7c, 0x57, 0x2a, 0xa3, 0x68, 0xa1, 0x2e, 0xa0, 0xe9, 0x39, 0xf4, 0x7a, 0x07, 0xfd, 0xfa,
-	0x1d, 0xe8, 0x0e, 0x9f, 0x2a, 0x61, 0x54, 0x78, 0x63, 0xe9, 0x9c, 0x3c, 0x6f, 0x98, 0x86, 0xcd,
-	0xf3, 0xc6, 0x9a, 0x1d, 0xdf, 0xc3, 0x97, 0x6a, 0x16, 0x99, 0x96, 0xe5, 0xdf, 0x90, 0x37, 0xbf,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x09, 0x44, 0xa6, 0x90, 0x97, 0x08, 0x00, 0x00,
+	// 1492 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x72, 0xdb, 0x44,
+	0x18, 0x1e, 0xf9, 0xec, 0xdf, 0x87, 0x24, 0xdb, 0x34, 0x15, 0x6e, 0x98, 0xa6, 0xdb, 0x29, 0x49,
+	0x7a, 0x70, 0x4b, 0x42, 0xb9, 0x80, 0x61, 0x20, 0x2d, 0xa5, 0x0d, 0xd3, 0x94, 0x8c, 0x48, 0xa6,
+	0x33, 0x30, 0xe0, 0x51, 0xac, 0x8d, 0x23, 0x6a, 0x4b, 0x5b, 0xad, 0xdc, 0x92, 0x0b, 0xe0, 0x82,
+	0xe1, 0x82, 0x67, 0xe0, 0x25, 0x78, 0x08, 0x1e, 0x8c, 0xd9, 0x83, 0xa4, 0x95, 0x9c, 0x5a, 0x32,
+	0xe5, 0xc6, 0xa3, 0xfd, 0xf7, 0x3f, 0x1f, 0xbe, 0x7f, 0xc7, 0xd0, 0x61, 0x24, 0x78, 0xed, 0x0e,
+	0x49, 0x9f, 0x06, 0x7e, 0xe8, 0xa3, 0xf5, 0xa1, 0x3f, 0xe9, 0x8f, 0xdc, 0xf0, 0x6c, 0x7a, 0xd2,
+	0xff, 0xc9, 0xf7, 0x08, 0x7b, 0xe9, 0xfb, 0xfd, 0xc9, 0xf9, 0xc4, 0xf7, 0x5c, 0xc2, 0xf0, 0x67,
+	0x50, 0xdf, 0x1b, 0x0e, 0xfd, 0xa9, 0x17, 0xa2, 0x35, 0xa8, 0x79, 0xd3, 0xc9, 0x09, 0x09, 0x4c,
+	0x63, 0xc3, 0xd8, 0x6a, 0x5a, 0xea, 0x84, 0x7a, 0xd0, 0x18, 0x4e, 0x83, 0x80, 0x78, 0xc3, 0x73,
+	0xb3, 0x24, 0x6e, 0xe2, 0x33, 0xde, 0x86, 0xf2, 0x91, 0x3d, 0x42, 0x5d, 0x28, 0xb9, 0x8e, 0x12,
+	0x2b, 0xb9, 0x0e, 0x42, 0x50, 0xf1, 0xec, 0x09, 0x51, 0xec, 0xe2, 0x1b, 0xff, 0x5e, 0x81, 0xd6,
+	0x51, 0x60, 0x7b, 0xcc, 0x1e, 0x86, 0xae, 0xef, 0xcd, 0xc8, 0x6c, 0xc3, 0x72, 0x98, 0x5c, 0x0f,
+	0x1c, 0x3b, 0x8c, 0xe4, 0x97, 0x34, 0xfa, 0x97, 0x76, 0x48, 0xd0, 0xfb, 0x00, 0xaf, 0xed, 0xf1,
+	0x94, 0x48, 0xa6, 0xb2, 0x60, 0x6a, 0x0a, 0x8a, 0xb8, 0xbe, 0x0e, 0x6d, 0x6a, 0x9f, 0x4f, 0x88,
+	0x17, 0x4a, 0x86, 0x8a, 0x60, 0x68, 0x29, 0x9a, 0x60, 0x59, 0x83, 0x9a, 0x3d, 0xe1, 0x51, 0x9b,
+	0x2b, 0x32, 0x56, 0x79, 0x42, 0xd7, 0x80, 0xb3, 0x11, 0x32, 0xe0, 0xbf, 0x81, 0x59, 0x13, 0x97,
+	0x20, 0x48, 0x87, 0x9c, 0x82, 0x4c, 0xa8, 0xdb, 0x32, 0x5f, 0x66, 0x5d, 0x5c, 0x46, 0x47, 0xb4,
+	0x0c, 0xe5, 0x13, 0x77, 0x68, 0x36, 0x04, 0x95, 0x7f, 0xa2, 0x0d, 0x68, 0x69, 0x9e, 0x9b, 0x4d,
+	0xe9, 0x86, 0x46, 0x42, 0xeb, 0xd0, 0x0c, 0xc8, 0x29, 0xe1, 0xb9, 0x24, 0x26, 0xc8, 0x38, 0x62,
+	0x02, 0xda, 0x84, 0x25, 0xe1, 0xc6, 0x20, 0xe1, 0x69, 0x09, 0x9e, 0xae, 0x20, 0x5b, 0x31, 0xa3,
+	0x09, 0xf5, 0x09, 0x61, 0xcc, 0x1e, 0x11, 0xb3, 0x2d, 0x9d, 0x52, 0x47, 0x1e, 0xcf, 0xd0, 0x0e,
+	0x9c, 0x81, 0x2a, 0x6c, 0x47, 0xc6, 0xc3, 0x49, 0xcf, 0x65, 0x71, 0x2f, 0x43, 0x2d, 0xb4, 0x47,
+	0x03, 0xd7, 0x31, 0xbb, 0xe2, 0xae, 0x1a, 0xda, 0xa3, 0x7d, 0x07, 0x5d, 0x85, 0xa6, 0x3b, 0xa1,
+	0x7e, 0x10, 0xf2, 0x9b, 0x25, 0x59, 0x74, 0x49, 0xd8, 0x77, 0x52, 0x0d, 0xb1, 0x9c, 0x6e, 0x08,
+	0xee, 0xca, 0x6b, 0x12, 0x30, 0x1e, 0x2f, 0xda, 0x30, 0xb6, 0xaa, 0x56, 0x74, 0xfc, 0xba, 0xd2,
+	0xa8, 0x2e, 0xd7, 0xb0, 0x0b, 0x2b, 0x5a, 0x13, 0x7c, 0xe5, 0x8e, 0x43, 0x12, 0xcc, 0xb4, 0x82,
+	0x96, 0xe4, 0x52, 0x3a, 0xc9, 0xab, 0x50, 0x9d, 0xf8, 0x5e, 0x78, 0xa6, 0x8a, 0x2e, 0x0f, 0x9c,
+	0xfa, 0x6a, 0x4a, 0x82, 0x73, 0x55, 0x69, 0x79, 0xc0, 0x87, 0x50, 0x3f, 0xb4, 0xc3, 0x90, 0x04,
+	0x9e, 0xae, 0xd0, 0x98, 0x51, 0x28, 0x45, 0x4b, 0x9a, 0xa8, 0x96, 0x95, 0xb2, 0x96, 0x15, 0x6c,
+	0x41, 0xfb, 0xf1, 0xcf, 0xc3, 0x33, 0xdb, 0x1b, 0x11, 0x8b, 0x77, 0x11, 0x82, 0x8a, 0x68, 0x30,
+	0xa9, 0x53, 0x7c, 0xcf, 0x9b, 0x16, 0xce, 0x1f, 0x24, 0x1d, 0x2b, 0xbe, 0xf1, 0xaf, 0x50, 0x3d,
+	0xf2, 0x43, 0x7b, 0x9c, 0x84, 0x66, 0xe8, 0xa1, 0x25, 0x9e, 0x94, 0xf4, 0xfa, 0xe8, 0x56, 0xca,
+	0x19, 0x2b, 0x49, 0x6f, 0x57, 0x52, 0xbd, 0xbd, 0x0a, 0x55, 0x99, 0x82, 0xaa, 0x28, 0x8c, 0x3c,
+	0xe0, 0x47, 0xd0, 0x7c, 0xea, 0x4f, 0x19, 0x39, 0xf3, 0xc7, 0x4e, 0x91, 0x39, 0x16, 0x41, 0xf8,
+	0xe3, 0x24, 0x08, 0x7f, 0x4c, 0xf0, 0x1e, 0x2c, 0xc5, 0x4a, 0x0e, 0x48, 0x84, 0x1a, 0x53, 0x46,
+	0x02, 0x21, 0x2e, 0x15, 0xc6, 0xe7, 0x58, 0x45, 0x49, 0x53, 0xf1, 0x4f, 0x09, 0x9a, 0x47, 0xf6,
+	0xe8, 0x91, 0xc8, 0x2e, 0x1f, 0x61, 0x9f, 0x92, 0xc0, 0x16, 0x50, 0x10, 0xbb, 0xd4, 0x8a, 0x69,
+	0xfb, 0x0e, 0xba, 0x09, 0x5d, 0x1d, 0x2f, 0xe2, 0x0c, 0x75, 0x34, 0xea, 0xbe, 0x83, 0xd6, 0x01,
+	0xfc, 0xb1, 0x33, 0x48, 0x95, 0xb3, 0xe1, 0x8f, 0x9d, 0x23, 0x91, 0xc7, 0x75, 0x00, 0x8f, 0xbc,
+	0x89, 0x6e, 0x65, 0xbe, 0x1a, 0x1e, 0x79, 0x73, 0x14, 0x65, 0x39, 0x8e, 0xa1, 0x3a, 0x1b, 0x43,
+	0xe8, 0x4e, 0x88, 0x82, 0x08, 0xf1, 0x2d, 0x32, 0x6c, 0x4f, 0x19, 0x51, 0xd0, 0x20, 0x0f, 0x1c,
+	0xad, 0xa8, 0xec, 0x43, 0x6e, 0x43, 0xe2, 0x43, 0x53, 0x51, 0xb2, 0xa3, 0xd6, 0xcc, 0x8c, 0xda,
+	0x15, 0xa8, 0x4f, 0x3d, 0xc7, 0x1f, 0xf8, 0xa7, 0x0a, 0x1e, 0x6a, 0xfc, 0xf8, 0xcd, 0x29, 0x2f,
+	0x32, 0xff, 0xf2, 0x24, 0x24, 0x34, 0x2c, 0x75, 0xc2, 0x2f, 0xe0, 0xd2, 0x9e, 0xe3, 0xe8, 0x5d,
+	0xca, 0x2c, 0xf2, 0x0a, 0x7d, 0x01, 0x55, 0xde, 0x6d, 0xcc, 0x34, 0x36, 0xca, 0x5b, 0xad, 0x9d,
+	0x5b, 0xfd, 0x79, 0x4b, 0xa1, 0xaf, 0x8b, 0x5b, 0x52, 0x10, 0xaf, 0xc1, 0xea, 0xac, 0x62, 0x46,
+	0xf1, 0xdf, 0x06, 0xb4, 0xf7, 0x1c, 0x67, 0x5f, 0x78, 0xcc, 0x4d, 0xbd, 0x7d, 0xd6, 0xae, 0x42,
+	0xf3, 0xd4, 0x1d, 0x93, 0x81, 0xd6, 0x52, 0x0d, 0x4e, 0x78, 0xce, 0xf3, 0x79, 0x00, 0x6d, 0xad,
+	0x70, 0xcc, 0x2c, 0x0b, 0x47, 0xb7, 0xe7, 0x3b, 0xaa, 0x41, 0x89, 0x95, 0x12, 0x4f, 0x0d, 0x48,
+	0x25, 0xb3, 0xb4, 0x96, 0xa0, 0xa3, 0x79, 0xcc, 0x28, 0x3e, 0x14, 0x04, 0x05, 0x16, 0x3c, 0x86,
+	0xcf, 0xa1, 0xae, 0x0a, 0x24, 0x62, 0x68, 0xed, 0xdc, 0x9c, 0xef, 0x47, 0x24, 0x1a, 0x49, 0xe1,
+	0x5d, 0xe8, 0xea, 0x1a, 0x19, 0x2d, 0xd0, 0xd1, 0xf8, 0x1a, 0x34, 0xf7, 0x1c, 0xde, 0x98, 0xdc,
+	0x85, 0x68, 0xf4, 0x0c, 0x6d, 0x85, 0xee, 0x01, 0x44, 0x0c, 0x8c, 0xa2, 0x5d, 0x28, 0x87, 0xf6,
+	0x48, 0x39, 0x78, 0x3d, 0x27, 0x51, 0xf6, 0xc8, 0xe2, 0xdc, 0xf8, 0x2f, 0x03, 0x96, 0x1f, 0x4e,
+	0xc7, 0x2f, 0x8f, 0x29, 0x47, 0x2b, 0x65, 0x6b, 0x13, 0x96, 0xd2, 0xa3, 0x24, 0xfb, 0xa4, 0x69,
+	0x75, 0x53, 0xb3, 0xc4, 0xd0, 0x13, 0xa8, 0x9d, 0x0a, 0xc8, 0x16, 0xe5, 0x6b, 0xed, 0xdc, 0x2b,
+	0x5c, 0x1e, 0x89, 0xf4, 0x96, 0x12, 0x7f, 0x1b, 0xc0, 0x3e, 0x83, 0x95, 0x8c, 0x73, 0x8c, 0x26,
+	0xb8, 0x65, 0x68, 0xb8, 0x35, 0x93, 0xcf, 0xd2, 0x6c, 0x3e, 0x57, 0x60, 0xe9, 0x99, 0xcb, 0x42,
+	0xf5, 0xbe, 0xe1, 0x73, 0x80, 0x8f, 0x61, 0x39, 0x4d, 0x62, 0x14, 0xed, 0x41, 0x43, 0x75, 0x68,
+	0x34, 0x1e, 0x39, 0xd5, 0x56, 0xd2, 0x56, 0x2c, 0x86, 0xdf, 0x83, 0x2b, 0x5c, 0x6d, 0x06, 0x03,
+	0x85, 0xc5, 0x21, 0x98, 0x17, 0x5f, 0x31, 0x8a, 0x9e, 0xf0, 0xbd, 0x2d, 0x8e, 0xca, 0xf0, 0xdd,
+	0xf9, 0x86, 0x33, 0x4a, 0xac, 0x48, 0x1a, 0x5f, 0x82, 0x95, 0x94, 0x11, 0x61, 0xf9, 0x07, 0x40,
+	0x59, 0xa2, 0xb0, 0x09, 0x67, 0x31, 0x45, 0x99, 0xdd, 0x2c, 0x68, 0xd6, 0xd2, 0x44, 0xf1, 0x27,
+	0xd2, 0xe6, 0x91, 0x3d, 0x7a, 0xea, 0xb2, 0xd0, 0x0f, 0xce, 0x79, 0x27, 0xcd, 0x82, 0xb2, 0x71,
+	0x01, 0x28, 0xe3, 0x17, 0x80, 0xb2, 0xb2, 0xa2, 0x10, 0x75, 0x89, 0x2e, 0x05, 0xfd, 0x8a, 0xd7,
+	0x85, 0x15, 0xc9, 0xe1, 0x0e, 0xb4, 0x94, 0x62, 0x91, 0x82, 0xc7, 0xd0, 0x4e, 0x8e, 0x8c, 0xa2,
+	0x07, 0x50, 0x09, 0xed, 0x51, 0xa4, 0xbe, 0xc0, 0xcc, 0x08, 0x76, 0xfc, 0x0b, 0x74, 0x84, 0x1a,
+	0xbe, 0xa7, 0x05, 0x9c, 0x26, 0x73, 0x60, 0xbc, 0xdb, 0x1c, 0xdc, 0x80, 0xce, 0x89, 0xcd, 0xc8,
+	0x20, 0xf3, 0x64, 0x68, 0x73, 0xe2, 0xa3, 0x08, 0xaf, 0x0e, 0xa0, 0xab, 0x9b, 0x67, 0x14, 0x7d,
+	0x0a, 0xb5, 0x50, 0x9c, 0x54, 0x24, 0x37, 0x72, 0xec, 0x73, 0x5e, 0x4b, 0x89, 0xe0, 0x1f, 0xe1,
+	0x92, 0x50, 0x97, 0x38, 0xf5, 0xbf, 0xc6, 0x84, 0x09, 0xac, 0xce, 0xea, 0x67, 0x74, 0x06, 0xe1,
+	0x8d, 0x77, 0x42, 0x78, 0xfc, 0x04, 0x2e, 0x7f, 0x4b, 0xb2, 0x73, 0xc5, 0x03, 0x59, 0xf4, 0xe5,
+	0x61, 0xc2, 0xda, 0x45, 0x8a, 0x18, 0xc5, 0x77, 0xa0, 0x7e, 0xec, 0x39, 0x3e, 0x57, 0x5a, 0x00,
+	0xbe, 0xef, 0x42, 0x43, 0x72, 0x17, 0x43, 0xfb, 0x53, 0x68, 0xa7, 0x40, 0xb8, 0xd8, 0xe8, 0xbc,
+	0xed, 0x41, 0xa8, 0xbd, 0xbb, 0xcb, 0xa9, 0x77, 0x37, 0x7e, 0x06, 0x9d, 0x34, 0x9e, 0xe6, 0xfb,
+	0xa6, 0x6b, 0x2b, 0xa5, 0xb4, 0xed, 0xfc, 0xd9, 0x81, 0xc6, 0x81, 0x2a, 0x0e, 0x3a, 0x87, 0xe5,
+	0xec, 0x9b, 0x00, 0x7d, 0x98, 0x83, 0x9d, 0xb3, 0x8f, 0x93, 0xde, 0xce, 0xa2, 0x22, 0x8c, 0x22,
+	0x07, 0x9a, 0xf1, 0x0e, 0x47, 0xb7, 0x72, 0x15, 0xc4, 0xcf, 0x93, 0xde, 0xed, 0xc2, 0xbc, 0x8c,
+	0xa2, 0x11, 0x40, 0xb2, 0xc6, 0x51, 0xbe, 0x68, 0xf2, 0x84, 0xe8, 0xdd, 0x29, 0xce, 0xcc, 0x28,
+	0xfa, 0x1e, 0x6a, 0x72, 0xb3, 0xa3, 0xcd, 0x5c, 0x39, 0xd9, 0x2f, 0xbd, 0xad, 0x62, 0x8c, 0x8c,
+	0x22, 0x0a, 0x9d, 0xd4, 0x56, 0x45, 0xfd, 0xf9, 0xa2, 0xd9, 0xf7, 0x41, 0xef, 0xde, 0x42, 0xfc,
+	0x8c, 0xa2, 0x89, 0xc4, 0xdd, 0x68, 0xcd, 0xa2, 0x9c, 0xbd, 0x96, 0xd9, 0xd2, 0xbd, 0xfe, 0x22,
+	0xec, 0x8c, 0xa2, 0x3f, 0x0c, 0x09, 0x39, 0xd9, 0x25, 0x8b, 0x1e, 0xe4, 0x2b, 0xba, 0x60, 0x67,
+	0xf7, 0x3e, 0xfe, 0x2f, 0x62, 0x8c, 0x22, 0x06, 0xdd, 0xd4, 0x1d, 0x43, 0xf7, 0x16, 0xd0, 0x24,
+	0x4c, 0xdf, 0x5f, 0x4c, 0x20, 0x31, 0x9a, 0xec, 0xd2, 0x22, 0x46, 0x53, 0x5b, 0xbb, 0x77, 0x7f,
+	0x31, 0x01, 0x46, 0x91, 0x0d, 0x0d, 0x45, 0x65, 0x68, 0xbb, 0x90, 0xb4, 0x88, 0xee, 0x56, 0x51,
+	0x56, 0x39, 0x7b, 0xc9, 0xd6, 0xcb, 0x9b, 0xbd, 0xd4, 0x7a, 0xee, 0xdd, 0x29, 0xce, 0xcc, 0x28,
+	0x47, 0xb1, 0xec, 0xbe, 0xca, 0x43, 0xb1, 0x0b, 0xf6, 0x67, 0x6f, 0x67, 0x51, 0x11, 0x46, 0xd1,
+	0x6f, 0x80, 0x66, 0x57, 0x0f, 0xda, 0x9d, 0xaf, 0xe9, 0xc2, 0xad, 0xd7, 0xfb, 0x68, 0x71, 0x21,
+	0x46, 0xd1, 0x31, 0x54, 0xf8, 0xce, 0x42, 0x39, 0x2f, 0x5e, 0xb5, 0x05, 0x7b, 0x1f, 0x14, 0x61,
+	0x93, 0xe8, 0x9c, 0xa0, 0x4d, 0x4e, 0xd1, 0x53, 0x48, 0x73, 0xbb, 0x30, 0x2f, 0xa3, 0x0f, 0xe1,
+	0xbb, 0x46, 0x74, 0x73, 0x52, 0x13, 0x7f, 0x76, 0xee, 0xfe, 0x3b, 0x00, 0x21, 0x84, 0xab, 0x0c,
+	0xfd, 0x14, 0x00, 0x00,
 }
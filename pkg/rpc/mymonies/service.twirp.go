@@ -33,16 +33,34 @@ import url "net/url"
 // ==================
 
 type Mymonies interface {
+	AddExchangeRates(context.Context, *AddExchangeRatesReq) (*AddExchangeRatesResp, error)
+
 	AddImport(context.Context, *AddImportReq) (*AddImportResp, error)
 
 	AddPattern(context.Context, *AddPatternReq) (*AddPatternResp, error)
 
+	AddTag(context.Context, *AddTagReq) (*AddTagResp, error)
+
+	BulkUpdateTag(context.Context, *BulkUpdateTagReq) (*BulkUpdateTagResp, error)
+
 	ListAccounts(context.Context, *ListAccountsReq) (*ListAccountsResp, error)
 
+	ListHouseholdMembers(context.Context, *ListHouseholdMembersReq) (*ListHouseholdMembersResp, error)
+
+	ListHouseholds(context.Context, *ListHouseholdsReq) (*ListHouseholdsResp, error)
+
+	ListTagHistory(context.Context, *ListTagHistoryReq) (*ListTagHistoryResp, error)
+
 	ListTags(context.Context, *ListTagsReq) (*ListTagsResp, error)
 
+	ListTotals(context.Context, *ListTotalsReq) (*ListTotalsResp, error)
+
 	ListTransactions(context.Context, *ListTransactionsReq) (*ListTransactionsResp, error)
 
+	SetHouseholdMember(context.Context, *SetHouseholdMemberReq) (*SetHouseholdMemberResp, error)
+
+	Undo(context.Context, *UndoReq) (*UndoResp, error)
+
 	UpdateTag(context.Context, *UpdateTagReq) (*UpdateTagResp, error)
 }
 
@@ -52,19 +70,28 @@ type Mymonies interface {
 
 type mymoniesProtobufClient struct {
 	client HTTPClient
-	urls   [6]string
+	urls   [15]string
 }
 
 // NewMymoniesProtobufClient creates a Protobuf client that implements the Mymonies interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
 func NewMymoniesProtobufClient(addr string, client HTTPClient) Mymonies {
 	prefix := urlBase(addr) + MymoniesPathPrefix
-	urls := [6]string{
+	urls := [15]string{
+		prefix + "AddExchangeRates",
 		prefix + "AddImport",
 		prefix + "AddPattern",
+		prefix + "AddTag",
+		prefix + "BulkUpdateTag",
 		prefix + "ListAccounts",
+		prefix + "ListHouseholdMembers",
+		prefix + "ListHouseholds",
+		prefix + "ListTagHistory",
 		prefix + "ListTags",
+		prefix + "ListTotals",
 		prefix + "ListTransactions",
+		prefix + "SetHouseholdMember",
+		prefix + "Undo",
 		prefix + "UpdateTag",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
@@ -79,12 +106,21 @@ func NewMymoniesProtobufClient(addr string, client HTTPClient) Mymonies {
 	}
 }
 
+func (c *mymoniesProtobufClient) AddExchangeRates(ctx context.Context, in *AddExchangeRatesReq) (*AddExchangeRatesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "AddExchangeRates")
+	out := new(AddExchangeRatesResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[0], in, out)
+	return out, err
+}
+
 func (c *mymoniesProtobufClient) AddImport(ctx context.Context, in *AddImportReq) (*AddImportResp, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
 	out := new(AddImportResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[0], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[1], in, out)
 	return out, err
 }
 
@@ -93,7 +129,25 @@ func (c *mymoniesProtobufClient) AddPattern(ctx context.Context, in *AddPatternR
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
 	out := new(AddPatternResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[1], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[2], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) AddTag(ctx context.Context, in *AddTagReq) (*AddTagResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "AddTag")
+	out := new(AddTagResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[3], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) BulkUpdateTag(ctx context.Context, in *BulkUpdateTagReq) (*BulkUpdateTagResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkUpdateTag")
+	out := new(BulkUpdateTagResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[4], in, out)
 	return out, err
 }
 
@@ -102,7 +156,34 @@ func (c *mymoniesProtobufClient) ListAccounts(ctx context.Context, in *ListAccou
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
 	out := new(ListAccountsResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[2], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[5], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) ListHouseholdMembers(ctx context.Context, in *ListHouseholdMembersReq) (*ListHouseholdMembersResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholdMembers")
+	out := new(ListHouseholdMembersResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[6], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) ListHouseholds(ctx context.Context, in *ListHouseholdsReq) (*ListHouseholdsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholds")
+	out := new(ListHouseholdsResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[7], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) ListTagHistory(ctx context.Context, in *ListTagHistoryReq) (*ListTagHistoryResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTagHistory")
+	out := new(ListTagHistoryResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[8], in, out)
 	return out, err
 }
 
@@ -111,7 +192,16 @@ func (c *mymoniesProtobufClient) ListTags(ctx context.Context, in *ListTagsReq)
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
 	out := new(ListTagsResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[3], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[9], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) ListTotals(ctx context.Context, in *ListTotalsReq) (*ListTotalsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTotals")
+	out := new(ListTotalsResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[10], in, out)
 	return out, err
 }
 
@@ -120,7 +210,25 @@ func (c *mymoniesProtobufClient) ListTransactions(ctx context.Context, in *ListT
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
 	out := new(ListTransactionsResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[4], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[11], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) SetHouseholdMember(ctx context.Context, in *SetHouseholdMemberReq) (*SetHouseholdMemberResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "SetHouseholdMember")
+	out := new(SetHouseholdMemberResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[12], in, out)
+	return out, err
+}
+
+func (c *mymoniesProtobufClient) Undo(ctx context.Context, in *UndoReq) (*UndoResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "Undo")
+	out := new(UndoResp)
+	err := doProtobufRequest(ctx, c.client, c.urls[13], in, out)
 	return out, err
 }
 
@@ -129,7 +237,7 @@ func (c *mymoniesProtobufClient) UpdateTag(ctx context.Context, in *UpdateTagReq
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "UpdateTag")
 	out := new(UpdateTagResp)
-	err := doProtobufRequest(ctx, c.client, c.urls[5], in, out)
+	err := doProtobufRequest(ctx, c.client, c.urls[14], in, out)
 	return out, err
 }
 
@@ -139,19 +247,28 @@ func (c *mymoniesProtobufClient) UpdateTag(ctx context.Context, in *UpdateTagReq
 
 type mymoniesJSONClient struct {
 	client HTTPClient
-	urls   [6]string
+	urls   [15]string
 }
 
 // NewMymoniesJSONClient creates a JSON client that implements the Mymonies interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
 func NewMymoniesJSONClient(addr string, client HTTPClient) Mymonies {
 	prefix := urlBase(addr) + MymoniesPathPrefix
-	urls := [6]string{
+	urls := [15]string{
+		prefix + "AddExchangeRates",
 		prefix + "AddImport",
 		prefix + "AddPattern",
+		prefix + "AddTag",
+		prefix + "BulkUpdateTag",
 		prefix + "ListAccounts",
+		prefix + "ListHouseholdMembers",
+		prefix + "ListHouseholds",
+		prefix + "ListTagHistory",
 		prefix + "ListTags",
+		prefix + "ListTotals",
 		prefix + "ListTransactions",
+		prefix + "SetHouseholdMember",
+		prefix + "Undo",
 		prefix + "UpdateTag",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
@@ -166,12 +283,21 @@ func NewMymoniesJSONClient(addr string, client HTTPClient) Mymonies {
 	}
 }
 
+func (c *mymoniesJSONClient) AddExchangeRates(ctx context.Context, in *AddExchangeRatesReq) (*AddExchangeRatesResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "AddExchangeRates")
+	out := new(AddExchangeRatesResp)
+	err := doJSONRequest(ctx, c.client, c.urls[0], in, out)
+	return out, err
+}
+
 func (c *mymoniesJSONClient) AddImport(ctx context.Context, in *AddImportReq) (*AddImportResp, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
 	out := new(AddImportResp)
-	err := doJSONRequest(ctx, c.client, c.urls[0], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[1], in, out)
 	return out, err
 }
 
@@ -180,7 +306,25 @@ func (c *mymoniesJSONClient) AddPattern(ctx context.Context, in *AddPatternReq)
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
 	out := new(AddPatternResp)
-	err := doJSONRequest(ctx, c.client, c.urls[1], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[2], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) AddTag(ctx context.Context, in *AddTagReq) (*AddTagResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "AddTag")
+	out := new(AddTagResp)
+	err := doJSONRequest(ctx, c.client, c.urls[3], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) BulkUpdateTag(ctx context.Context, in *BulkUpdateTagReq) (*BulkUpdateTagResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkUpdateTag")
+	out := new(BulkUpdateTagResp)
+	err := doJSONRequest(ctx, c.client, c.urls[4], in, out)
 	return out, err
 }
 
@@ -189,7 +333,34 @@ func (c *mymoniesJSONClient) ListAccounts(ctx context.Context, in *ListAccountsR
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
 	out := new(ListAccountsResp)
-	err := doJSONRequest(ctx, c.client, c.urls[2], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[5], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) ListHouseholdMembers(ctx context.Context, in *ListHouseholdMembersReq) (*ListHouseholdMembersResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholdMembers")
+	out := new(ListHouseholdMembersResp)
+	err := doJSONRequest(ctx, c.client, c.urls[6], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) ListHouseholds(ctx context.Context, in *ListHouseholdsReq) (*ListHouseholdsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholds")
+	out := new(ListHouseholdsResp)
+	err := doJSONRequest(ctx, c.client, c.urls[7], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) ListTagHistory(ctx context.Context, in *ListTagHistoryReq) (*ListTagHistoryResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTagHistory")
+	out := new(ListTagHistoryResp)
+	err := doJSONRequest(ctx, c.client, c.urls[8], in, out)
 	return out, err
 }
 
@@ -198,7 +369,16 @@ func (c *mymoniesJSONClient) ListTags(ctx context.Context, in *ListTagsReq) (*Li
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
 	out := new(ListTagsResp)
-	err := doJSONRequest(ctx, c.client, c.urls[3], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[9], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) ListTotals(ctx context.Context, in *ListTotalsReq) (*ListTotalsResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTotals")
+	out := new(ListTotalsResp)
+	err := doJSONRequest(ctx, c.client, c.urls[10], in, out)
 	return out, err
 }
 
@@ -207,7 +387,25 @@ func (c *mymoniesJSONClient) ListTransactions(ctx context.Context, in *ListTrans
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
 	out := new(ListTransactionsResp)
-	err := doJSONRequest(ctx, c.client, c.urls[4], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[11], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) SetHouseholdMember(ctx context.Context, in *SetHouseholdMemberReq) (*SetHouseholdMemberResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "SetHouseholdMember")
+	out := new(SetHouseholdMemberResp)
+	err := doJSONRequest(ctx, c.client, c.urls[12], in, out)
+	return out, err
+}
+
+func (c *mymoniesJSONClient) Undo(ctx context.Context, in *UndoReq) (*UndoResp, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "com.github.joneskoo.mymonies")
+	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
+	ctx = ctxsetters.WithMethodName(ctx, "Undo")
+	out := new(UndoResp)
+	err := doJSONRequest(ctx, c.client, c.urls[13], in, out)
 	return out, err
 }
 
@@ -216,7 +414,7 @@ func (c *mymoniesJSONClient) UpdateTag(ctx context.Context, in *UpdateTagReq) (*
 	ctx = ctxsetters.WithServiceName(ctx, "Mymonies")
 	ctx = ctxsetters.WithMethodName(ctx, "UpdateTag")
 	out := new(UpdateTagResp)
-	err := doJSONRequest(ctx, c.client, c.urls[5], in, out)
+	err := doJSONRequest(ctx, c.client, c.urls[14], in, out)
 	return out, err
 }
 
@@ -268,21 +466,48 @@ func (s *mymoniesServer) ServeHTTP(resp http.ResponseWriter, req *http.Request)
 	}
 
 	switch req.URL.Path {
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/AddExchangeRates":
+		s.serveAddExchangeRates(ctx, resp, req)
+		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/AddImport":
 		s.serveAddImport(ctx, resp, req)
 		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/AddPattern":
 		s.serveAddPattern(ctx, resp, req)
 		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/AddTag":
+		s.serveAddTag(ctx, resp, req)
+		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/BulkUpdateTag":
+		s.serveBulkUpdateTag(ctx, resp, req)
+		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListAccounts":
 		s.serveListAccounts(ctx, resp, req)
 		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListHouseholdMembers":
+		s.serveListHouseholdMembers(ctx, resp, req)
+		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListHouseholds":
+		s.serveListHouseholds(ctx, resp, req)
+		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListTagHistory":
+		s.serveListTagHistory(ctx, resp, req)
+		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListTags":
 		s.serveListTags(ctx, resp, req)
 		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListTotals":
+		s.serveListTotals(ctx, resp, req)
+		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/ListTransactions":
 		s.serveListTransactions(ctx, resp, req)
 		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/SetHouseholdMember":
+		s.serveSetHouseholdMember(ctx, resp, req)
+		return
+	case "/twirp/com.github.joneskoo.mymonies.Mymonies/Undo":
+		s.serveUndo(ctx, resp, req)
+		return
 	case "/twirp/com.github.joneskoo.mymonies.Mymonies/UpdateTag":
 		s.serveUpdateTag(ctx, resp, req)
 		return
@@ -294,7 +519,7 @@ func (s *mymoniesServer) ServeHTTP(resp http.ResponseWriter, req *http.Request)
 	}
 }
 
-func (s *mymoniesServer) serveAddImport(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddExchangeRates(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -302,9 +527,9 @@ func (s *mymoniesServer) serveAddImport(ctx context.Context, resp http.ResponseW
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveAddImportJSON(ctx, resp, req)
+		s.serveAddExchangeRatesJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveAddImportProtobuf(ctx, resp, req)
+		s.serveAddExchangeRatesProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -312,16 +537,16 @@ func (s *mymoniesServer) serveAddImport(ctx context.Context, resp http.ResponseW
 	}
 }
 
-func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddExchangeRatesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
+	ctx = ctxsetters.WithMethodName(ctx, "AddExchangeRates")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(AddImportReq)
+	reqContent := new(AddExchangeRatesReq)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request json")
@@ -330,7 +555,7 @@ func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.Respo
 	}
 
 	// Call service method
-	var respContent *AddImportResp
+	var respContent *AddExchangeRatesResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -339,7 +564,7 @@ func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.Respo
 				panic(r)
 			}
 		}()
-		respContent, err = s.AddImport(ctx, reqContent)
+		respContent, err = s.AddExchangeRates(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -347,7 +572,7 @@ func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.Respo
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddImportResp and nil error while calling AddImport. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddExchangeRatesResp and nil error while calling AddExchangeRates. nil responses are not supported"))
 		return
 	}
 
@@ -374,9 +599,9 @@ func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.Respo
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddExchangeRatesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
+	ctx = ctxsetters.WithMethodName(ctx, "AddExchangeRates")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -389,7 +614,7 @@ func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.R
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
 		return
 	}
-	reqContent := new(AddImportReq)
+	reqContent := new(AddExchangeRatesReq)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request proto")
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
@@ -397,7 +622,7 @@ func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.R
 	}
 
 	// Call service method
-	var respContent *AddImportResp
+	var respContent *AddExchangeRatesResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -406,7 +631,7 @@ func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.R
 				panic(r)
 			}
 		}()
-		respContent, err = s.AddImport(ctx, reqContent)
+		respContent, err = s.AddExchangeRates(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -414,7 +639,7 @@ func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.R
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddImportResp and nil error while calling AddImport. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddExchangeRatesResp and nil error while calling AddExchangeRates. nil responses are not supported"))
 		return
 	}
 
@@ -438,7 +663,7 @@ func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveAddPattern(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddImport(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -446,9 +671,9 @@ func (s *mymoniesServer) serveAddPattern(ctx context.Context, resp http.Response
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveAddPatternJSON(ctx, resp, req)
+		s.serveAddImportJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveAddPatternProtobuf(ctx, resp, req)
+		s.serveAddImportProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -456,16 +681,16 @@ func (s *mymoniesServer) serveAddPattern(ctx context.Context, resp http.Response
 	}
 }
 
-func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddImportJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
+	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(AddPatternReq)
+	reqContent := new(AddImportReq)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request json")
@@ -474,7 +699,7 @@ func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.Resp
 	}
 
 	// Call service method
-	var respContent *AddPatternResp
+	var respContent *AddImportResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -483,7 +708,7 @@ func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.Resp
 				panic(r)
 			}
 		}()
-		respContent, err = s.AddPattern(ctx, reqContent)
+		respContent, err = s.AddImport(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -491,7 +716,7 @@ func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.Resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddPatternResp and nil error while calling AddPattern. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddImportResp and nil error while calling AddImport. nil responses are not supported"))
 		return
 	}
 
@@ -518,9 +743,9 @@ func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.Resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddImportProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
+	ctx = ctxsetters.WithMethodName(ctx, "AddImport")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -533,7 +758,7 @@ func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
 		return
 	}
-	reqContent := new(AddPatternReq)
+	reqContent := new(AddImportReq)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request proto")
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
@@ -541,7 +766,7 @@ func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.
 	}
 
 	// Call service method
-	var respContent *AddPatternResp
+	var respContent *AddImportResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -550,7 +775,7 @@ func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.
 				panic(r)
 			}
 		}()
-		respContent, err = s.AddPattern(ctx, reqContent)
+		respContent, err = s.AddImport(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -558,7 +783,7 @@ func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddPatternResp and nil error while calling AddPattern. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddImportResp and nil error while calling AddImport. nil responses are not supported"))
 		return
 	}
 
@@ -582,7 +807,7 @@ func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListAccounts(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddPattern(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -590,9 +815,9 @@ func (s *mymoniesServer) serveListAccounts(ctx context.Context, resp http.Respon
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveListAccountsJSON(ctx, resp, req)
+		s.serveAddPatternJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveListAccountsProtobuf(ctx, resp, req)
+		s.serveAddPatternProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -600,16 +825,16 @@ func (s *mymoniesServer) serveListAccounts(ctx context.Context, resp http.Respon
 	}
 }
 
-func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddPatternJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
+	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(ListAccountsReq)
+	reqContent := new(AddPatternReq)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request json")
@@ -618,7 +843,7 @@ func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.Re
 	}
 
 	// Call service method
-	var respContent *ListAccountsResp
+	var respContent *AddPatternResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -627,7 +852,7 @@ func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.Re
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListAccounts(ctx, reqContent)
+		respContent, err = s.AddPattern(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -635,7 +860,7 @@ func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.Re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAccountsResp and nil error while calling ListAccounts. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddPatternResp and nil error while calling AddPattern. nil responses are not supported"))
 		return
 	}
 
@@ -662,9 +887,9 @@ func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.Re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddPatternProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
+	ctx = ctxsetters.WithMethodName(ctx, "AddPattern")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -677,7 +902,7 @@ func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp htt
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
 		return
 	}
-	reqContent := new(ListAccountsReq)
+	reqContent := new(AddPatternReq)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request proto")
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
@@ -685,7 +910,7 @@ func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp htt
 	}
 
 	// Call service method
-	var respContent *ListAccountsResp
+	var respContent *AddPatternResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -694,7 +919,7 @@ func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp htt
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListAccounts(ctx, reqContent)
+		respContent, err = s.AddPattern(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -702,7 +927,7 @@ func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp htt
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAccountsResp and nil error while calling ListAccounts. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddPatternResp and nil error while calling AddPattern. nil responses are not supported"))
 		return
 	}
 
@@ -726,7 +951,7 @@ func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp htt
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListTags(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddTag(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -734,9 +959,9 @@ func (s *mymoniesServer) serveListTags(ctx context.Context, resp http.ResponseWr
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveListTagsJSON(ctx, resp, req)
+		s.serveAddTagJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveListTagsProtobuf(ctx, resp, req)
+		s.serveAddTagProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -744,16 +969,16 @@ func (s *mymoniesServer) serveListTags(ctx context.Context, resp http.ResponseWr
 	}
 }
 
-func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddTagJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx = ctxsetters.WithMethodName(ctx, "AddTag")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(ListTagsReq)
+	reqContent := new(AddTagReq)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request json")
@@ -762,7 +987,7 @@ func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.Respon
 	}
 
 	// Call service method
-	var respContent *ListTagsResp
+	var respContent *AddTagResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -771,7 +996,7 @@ func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.Respon
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListTags(ctx, reqContent)
+		respContent, err = s.AddTag(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -779,7 +1004,7 @@ func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.Respon
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResp and nil error while calling ListTags. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddTagResp and nil error while calling AddTag. nil responses are not supported"))
 		return
 	}
 
@@ -806,9 +1031,9 @@ func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.Respon
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveAddTagProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx = ctxsetters.WithMethodName(ctx, "AddTag")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -821,7 +1046,7 @@ func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.Re
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
 		return
 	}
-	reqContent := new(ListTagsReq)
+	reqContent := new(AddTagReq)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request proto")
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
@@ -829,7 +1054,7 @@ func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.Re
 	}
 
 	// Call service method
-	var respContent *ListTagsResp
+	var respContent *AddTagResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -838,7 +1063,7 @@ func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.Re
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListTags(ctx, reqContent)
+		respContent, err = s.AddTag(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -846,7 +1071,7 @@ func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.Re
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResp and nil error while calling ListTags. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *AddTagResp and nil error while calling AddTag. nil responses are not supported"))
 		return
 	}
 
@@ -870,7 +1095,7 @@ func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.Re
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListTransactions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveBulkUpdateTag(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -878,9 +1103,9 @@ func (s *mymoniesServer) serveListTransactions(ctx context.Context, resp http.Re
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveListTransactionsJSON(ctx, resp, req)
+		s.serveBulkUpdateTagJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveListTransactionsProtobuf(ctx, resp, req)
+		s.serveBulkUpdateTagProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -888,16 +1113,16 @@ func (s *mymoniesServer) serveListTransactions(ctx context.Context, resp http.Re
 	}
 }
 
-func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveBulkUpdateTagJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkUpdateTag")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
 
-	reqContent := new(ListTransactionsReq)
+	reqContent := new(BulkUpdateTagReq)
 	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
 	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request json")
@@ -906,7 +1131,7 @@ func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp htt
 	}
 
 	// Call service method
-	var respContent *ListTransactionsResp
+	var respContent *BulkUpdateTagResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -915,7 +1140,7 @@ func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp htt
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListTransactions(ctx, reqContent)
+		respContent, err = s.BulkUpdateTag(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -923,7 +1148,7 @@ func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp htt
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTransactionsResp and nil error while calling ListTransactions. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkUpdateTagResp and nil error while calling BulkUpdateTag. nil responses are not supported"))
 		return
 	}
 
@@ -950,9 +1175,9 @@ func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp htt
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *mymoniesServer) serveBulkUpdateTagProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
+	ctx = ctxsetters.WithMethodName(ctx, "BulkUpdateTag")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -965,7 +1190,7 @@ func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
 		return
 	}
-	reqContent := new(ListTransactionsReq)
+	reqContent := new(BulkUpdateTagReq)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		err = wrapErr(err, "failed to parse request proto")
 		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
@@ -973,7 +1198,7 @@ func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp
 	}
 
 	// Call service method
-	var respContent *ListTransactionsResp
+	var respContent *BulkUpdateTagResp
 	func() {
 		defer func() {
 			// In case of a panic, serve a 500 error and then panic.
@@ -982,7 +1207,7 @@ func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp
 				panic(r)
 			}
 		}()
-		respContent, err = s.ListTransactions(ctx, reqContent)
+		respContent, err = s.BulkUpdateTag(ctx, reqContent)
 	}()
 
 	if err != nil {
@@ -990,7 +1215,1303 @@ func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTransactionsResp and nil error while calling ListTransactions. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BulkUpdateTagResp and nil error while calling BulkUpdateTag. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListAccounts(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListAccountsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListAccountsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListAccountsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListAccountsReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListAccountsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListAccounts(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAccountsResp and nil error while calling ListAccounts. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListAccountsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListAccounts")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListAccountsReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListAccountsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListAccounts(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListAccountsResp and nil error while calling ListAccounts. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListHouseholdMembers(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListHouseholdMembersJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListHouseholdMembersProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListHouseholdMembersJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholdMembers")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListHouseholdMembersReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListHouseholdMembersResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListHouseholdMembers(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListHouseholdMembersResp and nil error while calling ListHouseholdMembers. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListHouseholdMembersProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholdMembers")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListHouseholdMembersReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListHouseholdMembersResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListHouseholdMembers(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListHouseholdMembersResp and nil error while calling ListHouseholdMembers. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListHouseholds(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListHouseholdsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListHouseholdsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListHouseholdsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholds")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListHouseholdsReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListHouseholdsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListHouseholds(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListHouseholdsResp and nil error while calling ListHouseholds. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListHouseholdsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListHouseholds")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListHouseholdsReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListHouseholdsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListHouseholds(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListHouseholdsResp and nil error while calling ListHouseholds. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTagHistory(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTagHistoryJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTagHistoryProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListTagHistoryJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTagHistory")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListTagHistoryReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTagHistoryResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTagHistory(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagHistoryResp and nil error while calling ListTagHistory. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTagHistoryProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTagHistory")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListTagHistoryReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTagHistoryResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTagHistory(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagHistoryResp and nil error while calling ListTagHistory. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTags(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTagsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTagsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListTagsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListTagsReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTagsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTags(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResp and nil error while calling ListTags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTagsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListTagsReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTagsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTags(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResp and nil error while calling ListTags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTotals(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTotalsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTotalsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListTotalsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTotals")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListTotalsReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTotalsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTotals(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTotalsResp and nil error while calling ListTotals. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTotalsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTotals")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListTotalsReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTotalsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTotals(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTotalsResp and nil error while calling ListTotals. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTransactions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTransactionsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTransactionsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveListTransactionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(ListTransactionsReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTransactionsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTransactions(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTransactionsResp and nil error while calling ListTransactions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveListTransactionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTransactions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(ListTransactionsReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *ListTransactionsResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.ListTransactions(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTransactionsResp and nil error while calling ListTransactions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveSetHouseholdMember(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSetHouseholdMemberJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSetHouseholdMemberProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveSetHouseholdMemberJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetHouseholdMember")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(SetHouseholdMemberReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *SetHouseholdMemberResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.SetHouseholdMember(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetHouseholdMemberResp and nil error while calling SetHouseholdMember. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveSetHouseholdMemberProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SetHouseholdMember")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(SetHouseholdMemberReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *SetHouseholdMemberResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.SetHouseholdMember(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SetHouseholdMemberResp and nil error while calling SetHouseholdMember. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveUndo(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUndoJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUndoProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *mymoniesServer) serveUndoJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Undo")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(UndoReq)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *UndoResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Undo(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UndoResp and nil error while calling Undo. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *mymoniesServer) serveUndoProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "Undo")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(UndoReq)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *UndoResp
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Undo(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UndoResp and nil error while calling Undo. nil responses are not supported"))
 		return
 	}
 
@@ -1587,53 +3108,99 @@ func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) conte
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 765 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdb, 0x6a, 0xdb, 0x4a,
-	0x14, 0x45, 0x76, 0x7c, 0xdb, 0xbe, 0x66, 0x4e, 0x4e, 0x10, 0x4e, 0x0e, 0xc7, 0x11, 0x84, 0xc6,
-	0x69, 0xeb, 0x52, 0x97, 0x3e, 0x97, 0x94, 0x5e, 0x30, 0x24, 0xc1, 0x88, 0xe4, 0xa5, 0x0f, 0x35,
-	0x63, 0x69, 0xa2, 0xa8, 0x8d, 0xa4, 0xb1, 0x66, 0x1c, 0xf0, 0x3f, 0xf4, 0xb1, 0xff, 0xd4, 0xdf,
-	0x2a, 0x73, 0x91, 0x3d, 0xae, 0xc1, 0x72, 0xe8, 0x8b, 0xd1, 0xac, 0xbd, 0xd6, 0xd6, 0xbe, 0xac,
-	0x11, 0x86, 0x26, 0x23, 0xe9, 0x63, 0xe8, 0x91, 0x01, 0x4d, 0x13, 0x9e, 0xa0, 0x63, 0x2f, 0x89,
-	0x06, 0x41, 0xc8, 0xef, 0xe7, 0xd3, 0xc1, 0xb7, 0x24, 0x26, 0xec, 0x7b, 0x92, 0x0c, 0xa2, 0x45,
-	0x94, 0xc4, 0x21, 0x61, 0xce, 0x09, 0x54, 0x2e, 0x3c, 0x2f, 0x99, 0xc7, 0x1c, 0x1d, 0x42, 0x39,
-	0x9e, 0x47, 0x53, 0x92, 0xda, 0x56, 0xcf, 0x3a, 0xab, 0xb9, 0xfa, 0xe4, 0xf4, 0xa1, 0x78, 0x83,
-	0x03, 0xd4, 0x82, 0x42, 0xe8, 0xeb, 0x50, 0x21, 0xf4, 0x11, 0x82, 0xbd, 0x18, 0x47, 0xc4, 0x2e,
-	0x48, 0x44, 0x3e, 0x3b, 0xbf, 0x8a, 0x50, 0xbf, 0x49, 0x71, 0xcc, 0xb0, 0xc7, 0xc3, 0x24, 0xde,
-	0xd0, 0xf4, 0xa1, 0xc3, 0x57, 0xe1, 0x89, 0x8f, 0x79, 0xa6, 0x6f, 0x1b, 0xf8, 0x07, 0xcc, 0x09,
-	0xfa, 0x0f, 0xe0, 0x11, 0x3f, 0xcc, 0x89, 0x22, 0x15, 0x25, 0xa9, 0x26, 0x11, 0x19, 0x3e, 0x81,
-	0x06, 0xc5, 0x8b, 0x88, 0xc4, 0x5c, 0x11, 0xf6, 0x24, 0xa1, 0xae, 0x31, 0x49, 0x39, 0x84, 0x32,
-	0x8e, 0x44, 0x67, 0x76, 0xa9, 0x67, 0x9d, 0x59, 0xae, 0x3e, 0xa1, 0xff, 0x41, 0xd0, 0x08, 0x99,
-	0x88, 0xdf, 0xd4, 0x2e, 0x4b, 0x25, 0x48, 0x68, 0x2c, 0x10, 0x64, 0x43, 0x05, 0xab, 0x99, 0xd8,
-	0x15, 0x19, 0xcc, 0x8e, 0xa8, 0x03, 0xc5, 0x69, 0xe8, 0xd9, 0x55, 0x89, 0x8a, 0x47, 0xd4, 0x83,
-	0xba, 0x51, 0xb9, 0x5d, 0x53, 0x65, 0x18, 0x10, 0x3a, 0x86, 0x5a, 0x4a, 0xee, 0x48, 0x4a, 0x62,
-	0x8f, 0xd8, 0xa0, 0xfa, 0x58, 0x02, 0xe8, 0x19, 0xb4, 0x65, 0x19, 0x93, 0x15, 0xa7, 0x2e, 0x39,
-	0x2d, 0x09, 0xbb, 0x4b, 0xa2, 0x0d, 0x95, 0x88, 0x30, 0x86, 0x03, 0x62, 0x37, 0x54, 0x51, 0xfa,
-	0x28, 0xfa, 0xf1, 0x70, 0xea, 0x4f, 0xf4, 0xf2, 0x9a, 0xaa, 0x1f, 0x01, 0x5d, 0x4b, 0x04, 0xfd,
-	0x0b, 0x65, 0x8e, 0x83, 0x49, 0xe8, 0xdb, 0x2d, 0x19, 0x2b, 0x71, 0x1c, 0x8c, 0x7c, 0x74, 0x04,
-	0xb5, 0x30, 0xa2, 0x49, 0xca, 0x45, 0xa4, 0x2d, 0x23, 0x55, 0x05, 0x8c, 0x7c, 0x27, 0x84, 0x7d,
-	0x63, 0x91, 0x9f, 0xc2, 0x07, 0x4e, 0xd2, 0x8d, 0x75, 0x1a, 0x83, 0x2a, 0xac, 0x0f, 0xea, 0x00,
-	0x4a, 0x51, 0x12, 0xf3, 0x7b, 0xbd, 0x38, 0x75, 0x10, 0xe8, 0x6c, 0x4e, 0xd2, 0x85, 0xde, 0x96,
-	0x3a, 0x38, 0x63, 0xa8, 0x8c, 0x31, 0xe7, 0x24, 0x8d, 0xcd, 0x84, 0xd6, 0x46, 0x42, 0x25, 0x2d,
-	0x18, 0x52, 0xa3, 0xb3, 0xa2, 0xd1, 0x99, 0xf3, 0xd3, 0x82, 0xc6, 0x85, 0xef, 0x8f, 0x64, 0x33,
-	0x2e, 0x99, 0x6d, 0xc9, 0x7b, 0x04, 0xb5, 0xbb, 0xf0, 0x81, 0x4c, 0x0c, 0x2b, 0x57, 0x05, 0x70,
-	0x8d, 0x23, 0x82, 0xae, 0xa0, 0x61, 0x6c, 0x92, 0xd9, 0xc5, 0x5e, 0xf1, 0xac, 0x3e, 0xec, 0x0f,
-	0xb6, 0xdd, 0xa8, 0x81, 0x31, 0x36, 0x77, 0x4d, 0xee, 0xb4, 0xa1, 0x69, 0x54, 0xc5, 0xa8, 0x33,
-	0x96, 0x80, 0x6e, 0x5e, 0xd4, 0xf9, 0x0e, 0x2a, 0x54, 0x9d, 0x64, 0x9d, 0xf5, 0xe1, 0xe9, 0xf6,
-	0x77, 0x65, 0xd2, 0x4c, 0xe5, 0x74, 0xa0, 0x65, 0x66, 0x64, 0xd4, 0xd9, 0x87, 0xf6, 0x65, 0xc8,
-	0xb8, 0xbe, 0xe4, 0xcc, 0x25, 0x33, 0xe7, 0x16, 0x3a, 0xeb, 0x10, 0xa3, 0xe8, 0x02, 0xaa, 0x7a,
-	0x24, 0xcc, 0xb6, 0x64, 0x9b, 0x39, 0xaf, 0xd6, 0x6a, 0x77, 0x29, 0x73, 0x9a, 0x50, 0x17, 0x69,
-	0x6f, 0x70, 0x20, 0xdf, 0xf2, 0x11, 0x1a, 0xab, 0x23, 0xa3, 0xe8, 0x2d, 0xec, 0x71, 0x1c, 0x64,
-	0xd9, 0x4f, 0x72, 0x86, 0x88, 0x03, 0x57, 0xd2, 0x9d, 0xaf, 0xf0, 0x8f, 0x4c, 0x63, 0x0c, 0x52,
-	0x4c, 0xea, 0x33, 0x94, 0xef, 0xa4, 0x29, 0xf5, 0xa0, 0x5e, 0xed, 0xbc, 0x14, 0xe5, 0x65, 0x57,
-	0xcb, 0x1d, 0x02, 0x07, 0x9b, 0xf9, 0x19, 0xdd, 0xd8, 0xbd, 0xf5, 0x77, 0xbb, 0xbf, 0x84, 0xc6,
-	0x2d, 0x15, 0x5f, 0x2a, 0xd1, 0x19, 0x99, 0xa1, 0x53, 0x68, 0x99, 0x5f, 0xc2, 0xe5, 0xb5, 0x6a,
-	0x1a, 0xe8, 0xc8, 0x37, 0x0c, 0x5e, 0x30, 0x0d, 0xde, 0x86, 0xa6, 0x91, 0x8d, 0xd1, 0xe1, 0x8f,
-	0x12, 0x54, 0xaf, 0x74, 0x15, 0xc8, 0x87, 0xda, 0xd2, 0x67, 0xe8, 0x3c, 0x67, 0x8d, 0xc6, 0x35,
-	0xe9, 0x3e, 0xdf, 0x99, 0xcb, 0x28, 0x0a, 0x00, 0x56, 0x56, 0x43, 0xf9, 0xd2, 0x95, 0xcd, 0xbb,
-	0x2f, 0x76, 0x27, 0x33, 0x8a, 0x22, 0x65, 0xa4, 0xcc, 0xae, 0xe8, 0xe5, 0x76, 0xf5, 0x1f, 0x6e,
-	0xef, 0x0e, 0x9e, 0x42, 0x67, 0x14, 0x61, 0xa8, 0x66, 0xbe, 0x45, 0xfd, 0x7c, 0xad, 0xb6, 0x7b,
-	0xf7, 0x7c, 0x57, 0x2a, 0xa3, 0x68, 0xa1, 0x2e, 0xa0, 0xe9, 0x39, 0xf4, 0x7a, 0x07, 0xfd, 0xfa,
-	0x1d, 0xe8, 0x0e, 0x9f, 0x2a, 0x61, 0x54, 0x78, 0x63, 0xe9, 0x9c, 0x3c, 0x6f, 0x98, 0x86, 0xcd,
-	0xf3, 0xc6, 0x9a, 0x1d, 0xdf, 0xc3, 0x97, 0x6a, 0x16, 0x99, 0x96, 0xe5, 0xdf, 0x90, 0x37, 0xbf,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x09, 0x44, 0xa6, 0x90, 0x97, 0x08, 0x00, 0x00,
+	// 1492 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdb, 0x72, 0xdb, 0x44,
+	0x18, 0x1e, 0xf9, 0xec, 0xdf, 0x87, 0x24, 0xdb, 0x34, 0x15, 0x6e, 0x98, 0xa6, 0xdb, 0x29, 0x49,
+	0x7a, 0x70, 0x4b, 0x42, 0xb9, 0x80, 0x61, 0x20, 0x2d, 0xa5, 0x0d, 0xd3, 0x94, 0x8c, 0x48, 0xa6,
+	0x33, 0x30, 0xe0, 0x51, 0xac, 0x8d, 0x23, 0x6a, 0x4b, 0x5b, 0xad, 0xdc, 0x92, 0x0b, 0xe0, 0x82,
+	0xe1, 0x82, 0x67, 0xe0, 0x25, 0x78, 0x08, 0x1e, 0x8c, 0xd9, 0x83, 0xa4, 0x95, 0x9c, 0x5a, 0x32,
+	0xe5, 0xc6, 0xa3, 0xfd, 0xf7, 0x3f, 0x1f, 0xbe, 0x7f, 0xc7, 0xd0, 0x61, 0x24, 0x78, 0xed, 0x0e,
+	0x49, 0x9f, 0x06, 0x7e, 0xe8, 0xa3, 0xf5, 0xa1, 0x3f, 0xe9, 0x8f, 0xdc, 0xf0, 0x6c, 0x7a, 0xd2,
+	0xff, 0xc9, 0xf7, 0x08, 0x7b, 0xe9, 0xfb, 0xfd, 0xc9, 0xf9, 0xc4, 0xf7, 0x5c, 0xc2, 0xf0, 0x67,
+	0x50, 0xdf, 0x1b, 0x0e, 0xfd, 0xa9, 0x17, 0xa2, 0x35, 0xa8, 0x79, 0xd3, 0xc9, 0x09, 0x09, 0x4c,
+	0x63, 0xc3, 0xd8, 0x6a, 0x5a, 0xea, 0x84, 0x7a, 0xd0, 0x18, 0x4e, 0x83, 0x80, 0x78, 0xc3, 0x73,
+	0xb3, 0x24, 0x6e, 0xe2, 0x33, 0xde, 0x86, 0xf2, 0x91, 0x3d, 0x42, 0x5d, 0x28, 0xb9, 0x8e, 0x12,
+	0x2b, 0xb9, 0x0e, 0x42, 0x50, 0xf1, 0xec, 0x09, 0x51, 0xec, 0xe2, 0x1b, 0xff, 0x5e, 0x81, 0xd6,
+	0x51, 0x60, 0x7b, 0xcc, 0x1e, 0x86, 0xae, 0xef, 0xcd, 0xc8, 0x6c, 0xc3, 0x72, 0x98, 0x5c, 0x0f,
+	0x1c, 0x3b, 0x8c, 0xe4, 0x97, 0x34, 0xfa, 0x97, 0x76, 0x48, 0xd0, 0xfb, 0x00, 0xaf, 0xed, 0xf1,
+	0x94, 0x48, 0xa6, 0xb2, 0x60, 0x6a, 0x0a, 0x8a, 0xb8, 0xbe, 0x0e, 0x6d, 0x6a, 0x9f, 0x4f, 0x88,
+	0x17, 0x4a, 0x86, 0x8a, 0x60, 0x68, 0x29, 0x9a, 0x60, 0x59, 0x83, 0x9a, 0x3d, 0xe1, 0x51, 0x9b,
+	0x2b, 0x32, 0x56, 0x79, 0x42, 0xd7, 0x80, 0xb3, 0x11, 0x32, 0xe0, 0xbf, 0x81, 0x59, 0x13, 0x97,
+	0x20, 0x48, 0x87, 0x9c, 0x82, 0x4c, 0xa8, 0xdb, 0x32, 0x5f, 0x66, 0x5d, 0x5c, 0x46, 0x47, 0xb4,
+	0x0c, 0xe5, 0x13, 0x77, 0x68, 0x36, 0x04, 0x95, 0x7f, 0xa2, 0x0d, 0x68, 0x69, 0x9e, 0x9b, 0x4d,
+	0xe9, 0x86, 0x46, 0x42, 0xeb, 0xd0, 0x0c, 0xc8, 0x29, 0xe1, 0xb9, 0x24, 0x26, 0xc8, 0x38, 0x62,
+	0x02, 0xda, 0x84, 0x25, 0xe1, 0xc6, 0x20, 0xe1, 0x69, 0x09, 0x9e, 0xae, 0x20, 0x5b, 0x31, 0xa3,
+	0x09, 0xf5, 0x09, 0x61, 0xcc, 0x1e, 0x11, 0xb3, 0x2d, 0x9d, 0x52, 0x47, 0x1e, 0xcf, 0xd0, 0x0e,
+	0x9c, 0x81, 0x2a, 0x6c, 0x47, 0xc6, 0xc3, 0x49, 0xcf, 0x65, 0x71, 0x2f, 0x43, 0x2d, 0xb4, 0x47,
+	0x03, 0xd7, 0x31, 0xbb, 0xe2, 0xae, 0x1a, 0xda, 0xa3, 0x7d, 0x07, 0x5d, 0x85, 0xa6, 0x3b, 0xa1,
+	0x7e, 0x10, 0xf2, 0x9b, 0x25, 0x59, 0x74, 0x49, 0xd8, 0x77, 0x52, 0x0d, 0xb1, 0x9c, 0x6e, 0x08,
+	0xee, 0xca, 0x6b, 0x12, 0x30, 0x1e, 0x2f, 0xda, 0x30, 0xb6, 0xaa, 0x56, 0x74, 0xfc, 0xba, 0xd2,
+	0xa8, 0x2e, 0xd7, 0xb0, 0x0b, 0x2b, 0x5a, 0x13, 0x7c, 0xe5, 0x8e, 0x43, 0x12, 0xcc, 0xb4, 0x82,
+	0x96, 0xe4, 0x52, 0x3a, 0xc9, 0xab, 0x50, 0x9d, 0xf8, 0x5e, 0x78, 0xa6, 0x8a, 0x2e, 0x0f, 0x9c,
+	0xfa, 0x6a, 0x4a, 0x82, 0x73, 0x55, 0x69, 0x79, 0xc0, 0x87, 0x50, 0x3f, 0xb4, 0xc3, 0x90, 0x04,
+	0x9e, 0xae, 0xd0, 0x98, 0x51, 0x28, 0x45, 0x4b, 0x9a, 0xa8, 0x96, 0x95, 0xb2, 0x96, 0x15, 0x6c,
+	0x41, 0xfb, 0xf1, 0xcf, 0xc3, 0x33, 0xdb, 0x1b, 0x11, 0x8b, 0x77, 0x11, 0x82, 0x8a, 0x68, 0x30,
+	0xa9, 0x53, 0x7c, 0xcf, 0x9b, 0x16, 0xce, 0x1f, 0x24, 0x1d, 0x2b, 0xbe, 0xf1, 0xaf, 0x50, 0x3d,
+	0xf2, 0x43, 0x7b, 0x9c, 0x84, 0x66, 0xe8, 0xa1, 0x25, 0x9e, 0x94, 0xf4, 0xfa, 0xe8, 0x56, 0xca,
+	0x19, 0x2b, 0x49, 0x6f, 0x57, 0x52, 0xbd, 0xbd, 0x0a, 0x55, 0x99, 0x82, 0xaa, 0x28, 0x8c, 0x3c,
+	0xe0, 0x47, 0xd0, 0x7c, 0xea, 0x4f, 0x19, 0x39, 0xf3, 0xc7, 0x4e, 0x91, 0x39, 0x16, 0x41, 0xf8,
+	0xe3, 0x24, 0x08, 0x7f, 0x4c, 0xf0, 0x1e, 0x2c, 0xc5, 0x4a, 0x0e, 0x48, 0x84, 0x1a, 0x53, 0x46,
+	0x02, 0x21, 0x2e, 0x15, 0xc6, 0xe7, 0x58, 0x45, 0x49, 0x53, 0xf1, 0x4f, 0x09, 0x9a, 0x47, 0xf6,
+	0xe8, 0x91, 0xc8, 0x2e, 0x1f, 0x61, 0x9f, 0x92, 0xc0, 0x16, 0x50, 0x10, 0xbb, 0xd4, 0x8a, 0x69,
+	0xfb, 0x0e, 0xba, 0x09, 0x5d, 0x1d, 0x2f, 0xe2, 0x0c, 0x75, 0x34, 0xea, 0xbe, 0x83, 0xd6, 0x01,
+	0xfc, 0xb1, 0x33, 0x48, 0x95, 0xb3, 0xe1, 0x8f, 0x9d, 0x23, 0x91, 0xc7, 0x75, 0x00, 0x8f, 0xbc,
+	0x89, 0x6e, 0x65, 0xbe, 0x1a, 0x1e, 0x79, 0x73, 0x14, 0x65, 0x39, 0x8e, 0xa1, 0x3a, 0x1b, 0x43,
+	0xe8, 0x4e, 0x88, 0x82, 0x08, 0xf1, 0x2d, 0x32, 0x6c, 0x4f, 0x19, 0x51, 0xd0, 0x20, 0x0f, 0x1c,
+	0xad, 0xa8, 0xec, 0x43, 0x6e, 0x43, 0xe2, 0x43, 0x53, 0x51, 0xb2, 0xa3, 0xd6, 0xcc, 0x8c, 0xda,
+	0x15, 0xa8, 0x4f, 0x3d, 0xc7, 0x1f, 0xf8, 0xa7, 0x0a, 0x1e, 0x6a, 0xfc, 0xf8, 0xcd, 0x29, 0x2f,
+	0x32, 0xff, 0xf2, 0x24, 0x24, 0x34, 0x2c, 0x75, 0xc2, 0x2f, 0xe0, 0xd2, 0x9e, 0xe3, 0xe8, 0x5d,
+	0xca, 0x2c, 0xf2, 0x0a, 0x7d, 0x01, 0x55, 0xde, 0x6d, 0xcc, 0x34, 0x36, 0xca, 0x5b, 0xad, 0x9d,
+	0x5b, 0xfd, 0x79, 0x4b, 0xa1, 0xaf, 0x8b, 0x5b, 0x52, 0x10, 0xaf, 0xc1, 0xea, 0xac, 0x62, 0x46,
+	0xf1, 0xdf, 0x06, 0xb4, 0xf7, 0x1c, 0x67, 0x5f, 0x78, 0xcc, 0x4d, 0xbd, 0x7d, 0xd6, 0xae, 0x42,
+	0xf3, 0xd4, 0x1d, 0x93, 0x81, 0xd6, 0x52, 0x0d, 0x4e, 0x78, 0xce, 0xf3, 0x79, 0x00, 0x6d, 0xad,
+	0x70, 0xcc, 0x2c, 0x0b, 0x47, 0xb7, 0xe7, 0x3b, 0xaa, 0x41, 0x89, 0x95, 0x12, 0x4f, 0x0d, 0x48,
+	0x25, 0xb3, 0xb4, 0x96, 0xa0, 0xa3, 0x79, 0xcc, 0x28, 0x3e, 0x14, 0x04, 0x05, 0x16, 0x3c, 0x86,
+	0xcf, 0xa1, 0xae, 0x0a, 0x24, 0x62, 0x68, 0xed, 0xdc, 0x9c, 0xef, 0x47, 0x24, 0x1a, 0x49, 0xe1,
+	0x5d, 0xe8, 0xea, 0x1a, 0x19, 0x2d, 0xd0, 0xd1, 0xf8, 0x1a, 0x34, 0xf7, 0x1c, 0xde, 0x98, 0xdc,
+	0x85, 0x68, 0xf4, 0x0c, 0x6d, 0x85, 0xee, 0x01, 0x44, 0x0c, 0x8c, 0xa2, 0x5d, 0x28, 0x87, 0xf6,
+	0x48, 0x39, 0x78, 0x3d, 0x27, 0x51, 0xf6, 0xc8, 0xe2, 0xdc, 0xf8, 0x2f, 0x03, 0x96, 0x1f, 0x4e,
+	0xc7, 0x2f, 0x8f, 0x29, 0x47, 0x2b, 0x65, 0x6b, 0x13, 0x96, 0xd2, 0xa3, 0x24, 0xfb, 0xa4, 0x69,
+	0x75, 0x53, 0xb3, 0xc4, 0xd0, 0x13, 0xa8, 0x9d, 0x0a, 0xc8, 0x16, 0xe5, 0x6b, 0xed, 0xdc, 0x2b,
+	0x5c, 0x1e, 0x89, 0xf4, 0x96, 0x12, 0x7f, 0x1b, 0xc0, 0x3e, 0x83, 0x95, 0x8c, 0x73, 0x8c, 0x26,
+	0xb8, 0x65, 0x68, 0xb8, 0x35, 0x93, 0xcf, 0xd2, 0x6c, 0x3e, 0x57, 0x60, 0xe9, 0x99, 0xcb, 0x42,
+	0xf5, 0xbe, 0xe1, 0x73, 0x80, 0x8f, 0x61, 0x39, 0x4d, 0x62, 0x14, 0xed, 0x41, 0x43, 0x75, 0x68,
+	0x34, 0x1e, 0x39, 0xd5, 0x56, 0xd2, 0x56, 0x2c, 0x86, 0xdf, 0x83, 0x2b, 0x5c, 0x6d, 0x06, 0x03,
+	0x85, 0xc5, 0x21, 0x98, 0x17, 0x5f, 0x31, 0x8a, 0x9e, 0xf0, 0xbd, 0x2d, 0x8e, 0xca, 0xf0, 0xdd,
+	0xf9, 0x86, 0x33, 0x4a, 0xac, 0x48, 0x1a, 0x5f, 0x82, 0x95, 0x94, 0x11, 0x61, 0xf9, 0x07, 0x40,
+	0x59, 0xa2, 0xb0, 0x09, 0x67, 0x31, 0x45, 0x99, 0xdd, 0x2c, 0x68, 0xd6, 0xd2, 0x44, 0xf1, 0x27,
+	0xd2, 0xe6, 0x91, 0x3d, 0x7a, 0xea, 0xb2, 0xd0, 0x0f, 0xce, 0x79, 0x27, 0xcd, 0x82, 0xb2, 0x71,
+	0x01, 0x28, 0xe3, 0x17, 0x80, 0xb2, 0xb2, 0xa2, 0x10, 0x75, 0x89, 0x2e, 0x05, 0xfd, 0x8a, 0xd7,
+	0x85, 0x15, 0xc9, 0xe1, 0x0e, 0xb4, 0x94, 0x62, 0x91, 0x82, 0xc7, 0xd0, 0x4e, 0x8e, 0x8c, 0xa2,
+	0x07, 0x50, 0x09, 0xed, 0x51, 0xa4, 0xbe, 0xc0, 0xcc, 0x08, 0x76, 0xfc, 0x0b, 0x74, 0x84, 0x1a,
+	0xbe, 0xa7, 0x05, 0x9c, 0x26, 0x73, 0x60, 0xbc, 0xdb, 0x1c, 0xdc, 0x80, 0xce, 0x89, 0xcd, 0xc8,
+	0x20, 0xf3, 0x64, 0x68, 0x73, 0xe2, 0xa3, 0x08, 0xaf, 0x0e, 0xa0, 0xab, 0x9b, 0x67, 0x14, 0x7d,
+	0x0a, 0xb5, 0x50, 0x9c, 0x54, 0x24, 0x37, 0x72, 0xec, 0x73, 0x5e, 0x4b, 0x89, 0xe0, 0x1f, 0xe1,
+	0x92, 0x50, 0x97, 0x38, 0xf5, 0xbf, 0xc6, 0x84, 0x09, 0xac, 0xce, 0xea, 0x67, 0x74, 0x06, 0xe1,
+	0x8d, 0x77, 0x42, 0x78, 0xfc, 0x04, 0x2e, 0x7f, 0x4b, 0xb2, 0x73, 0xc5, 0x03, 0x59, 0xf4, 0xe5,
+	0x61, 0xc2, 0xda, 0x45, 0x8a, 0x18, 0xc5, 0x77, 0xa0, 0x7e, 0xec, 0x39, 0x3e, 0x57, 0x5a, 0x00,
+	0xbe, 0xef, 0x42, 0x43, 0x72, 0x17, 0x43, 0xfb, 0x53, 0x68, 0xa7, 0x40, 0xb8, 0xd8, 0xe8, 0xbc,
+	0xed, 0x41, 0xa8, 0xbd, 0xbb, 0xcb, 0xa9, 0x77, 0x37, 0x7e, 0x06, 0x9d, 0x34, 0x9e, 0xe6, 0xfb,
+	0xa6, 0x6b, 0x2b, 0xa5, 0xb4, 0xed, 0xfc, 0xd9, 0x81, 0xc6, 0x81, 0x2a, 0x0e, 0x3a, 0x87, 0xe5,
+	0xec, 0x9b, 0x00, 0x7d, 0x98, 0x83, 0x9d, 0xb3, 0x8f, 0x93, 0xde, 0xce, 0xa2, 0x22, 0x8c, 0x22,
+	0x07, 0x9a, 0xf1, 0x0e, 0x47, 0xb7, 0x72, 0x15, 0xc4, 0xcf, 0x93, 0xde, 0xed, 0xc2, 0xbc, 0x8c,
+	0xa2, 0x11, 0x40, 0xb2, 0xc6, 0x51, 0xbe, 0x68, 0xf2, 0x84, 0xe8, 0xdd, 0x29, 0xce, 0xcc, 0x28,
+	0xfa, 0x1e, 0x6a, 0x72, 0xb3, 0xa3, 0xcd, 0x5c, 0x39, 0xd9, 0x2f, 0xbd, 0xad, 0x62, 0x8c, 0x8c,
+	0x22, 0x0a, 0x9d, 0xd4, 0x56, 0x45, 0xfd, 0xf9, 0xa2, 0xd9, 0xf7, 0x41, 0xef, 0xde, 0x42, 0xfc,
+	0x8c, 0xa2, 0x89, 0xc4, 0xdd, 0x68, 0xcd, 0xa2, 0x9c, 0xbd, 0x96, 0xd9, 0xd2, 0xbd, 0xfe, 0x22,
+	0xec, 0x8c, 0xa2, 0x3f, 0x0c, 0x09, 0x39, 0xd9, 0x25, 0x8b, 0x1e, 0xe4, 0x2b, 0xba, 0x60, 0x67,
+	0xf7, 0x3e, 0xfe, 0x2f, 0x62, 0x8c, 0x22, 0x06, 0xdd, 0xd4, 0x1d, 0x43, 0xf7, 0x16, 0xd0, 0x24,
+	0x4c, 0xdf, 0x5f, 0x4c, 0x20, 0x31, 0x9a, 0xec, 0xd2, 0x22, 0x46, 0x53, 0x5b, 0xbb, 0x77, 0x7f,
+	0x31, 0x01, 0x46, 0x91, 0x0d, 0x0d, 0x45, 0x65, 0x68, 0xbb, 0x90, 0xb4, 0x88, 0xee, 0x56, 0x51,
+	0x56, 0x39, 0x7b, 0xc9, 0xd6, 0xcb, 0x9b, 0xbd, 0xd4, 0x7a, 0xee, 0xdd, 0x29, 0xce, 0xcc, 0x28,
+	0x47, 0xb1, 0xec, 0xbe, 0xca, 0x43, 0xb1, 0x0b, 0xf6, 0x67, 0x6f, 0x67, 0x51, 0x11, 0x46, 0xd1,
+	0x6f, 0x80, 0x66, 0x57, 0x0f, 0xda, 0x9d, 0xaf, 0xe9, 0xc2, 0xad, 0xd7, 0xfb, 0x68, 0x71, 0x21,
+	0x46, 0xd1, 0x31, 0x54, 0xf8, 0xce, 0x42, 0x39, 0x2f, 0x5e, 0xb5, 0x05, 0x7b, 0x1f, 0x14, 0x61,
+	0x93, 0xe8, 0x9c, 0xa0, 0x4d, 0x4e, 0xd1, 0x53, 0x48, 0x73, 0xbb, 0x30, 0x2f, 0xa3, 0x0f, 0xe1,
+	0xbb, 0x46, 0x74, 0x73, 0x52, 0x13, 0x7f, 0x76, 0xee, 0xfe, 0x3b, 0x00, 0x21, 0x84, 0xab, 0x0c,
+	0xfd, 0x14, 0x00, 0x00,
 }
@@ -7,6 +7,6 @@ import (
 )
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00R\xa8dL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00index.htmlUT\x05\x00\x01\xec^\x9cZ\xccX\xddn\xdc8\xd2\xbd\xb6\x9f\xa2>]\xcd\xe0SK\xce/\x02\xa3\xbb1\xde\xccf\x90\xd9q\x90\x9dd\x91\x00\x8b\x85QMU\xab\xcb\xa6H\x85,jl\x04\xf36y\x86}\x01\xbf\xd8\x82\xd4Ow\xc7\x9e\x0c\xdc{\xb37\x0dJdU\x9d:u\xaa({\xbe\x91F/\x8f\x8f\xe7\x1b\xc2jy\x0c0\x17\x16M\xcb\xf3\x9b\xc6\x1a&?/\xfb\xe7\xb8\xa3\xd9\\\x81#\xbd\xc8\xbc\xdch\xf2\x1b\"\xc9`\xe3h\xbd\xc86\"\xad?-\xcb\x06\xafUe\x8a\x95\xb5\xe2\xc5a\x1b\x1f\x94m\xca\xe9E\xf9\xb48)NJ\xe5\xfd\xf6]\xd1\xb0)\x94\xf7\x19\xb0\x11\xaa\x1d\xcb\xcd\"\xf3\x1b|\xf2\xe2\xe9\xec'\xf3\xec\xc9\x8b\xa7\xd7\x9f\xfe\xfe\x08\xed\x87\x8fg\xff\x7f\xf2\xec\xc5\xaf\x1f\xdf^\xbf\xad\x9f\xafo\x9e\xbe\xfe\xd0\xbd\x7f\xb39\xf9\xeb\xe3\xe7O>6\xaf\xd4\xcf\xfa\xdd\xd9o\xfcS\xfd\xea\xecCY\x9d\xf1\xbb\xe7?\x7fl2P\xcezo\x1d\xd7l\x16\x19\x1akn\x1a\x1b|\x96R\xf2\xcaq+\xe0\x9d\xda\xa6\x10!_\xfa\x8a4w\xae0$\xa5i\x9b\xb2\x0b\xf4\xc3\xe3\xe2Y\xf1\xe8IY\xb1\x97\xf8\\\\\xfal9/{\x17\xc9\xdb\xff\xcdf\xdff\xc9\x91\xb7\xc1)\xf2\xff\x0b\xf9\xc3l\xb6E\xbdK\xc4\x16\xe5\xa5\xbf\x9bh2\xfb39\xec'\xda\x0cbJ9\xde\xe1}/\x9ck\xd5t\xbc\xf4\xe4:Vt!\xbf\xb1k\xef\xd2\xfd\x87N\xa6x\xfb&\xf3\xb2\x17\xf9\xf1|e\xab\x9b\xe5\xf1\xd1\xbc\xe2\x0e\xb8Zd\xd8\xb6\x19t3\xa5-^-\x8f\x8f\x8e\xe6\x82+\x1f\x17i\x05\x06\x1bZdcKd\xc9$K\xdbG\xe8\xabu:W\n\xae\x96\xc7_\x99\xbcg\xcd\x82-n$4(\xbd\xa184\x1e\x95\xb05~p2\xdfy7\xd3\xec\xa5\x7f}4o\x87\xc5Qtt:\xac\xe7\x9e4)\x99\xa1R6\x18\x81\xd3a\xe1\x17\xd9\xb8\xca\xe0\xb4?DU\xe1o\x8c\x9av\x12\x1d{\xe6c\xa8\xb2\xed\xd1\x1f\x1d\xcd7\x8f\x96\xef'\xd0 \xacY\xeb\xdb/\xf0\xf93\x8c!\x7f\xff}^n\x1eM\x06\x82+Mw\x93\x03\xa5\xd1\xfbE\xd6o\xa7\xdf\xd9\xc6v\xe4\xb21\xad\xb9\xc4\x8aL\xe7\xe2\xc3\x8cMG\xce\xd3t&\xb23\xad\xa3\xc5\xf2o\xec.1\xf8\xf6\xf6\x0bw\xb7_\xe6\xa5l\xf6\xf7\xcf\xf1\xca\xe3%\x96\xef\x10/\xf1\xce\xf6\x1e\xaa\x99\xe3z#\xd9\xf2\xfc\xf6\xcb\xed\x17w\x9f\xb3_\x82\xbd\xba\xda\xf72/\xb7\x88\xe2F\x12\xd5h1Hk|t\xd0\xcd\xd6\xd6-\xb2\xef\xe4:\x07\xae\xbe\x076\xb0\xcf\xd2\x0fJ\xb3\xbaZd\x8d\xadP\xbf\xdfn\xc1\x02\xb8\xcav\xd1T\xcb\xcf\x9fA\xae\x8b\x1d\xfb\x8b\n\x85\n\x1fV^\xdcw'\xf9\xa3\x93\xefSq\xa4\xba\xcf\xae\xc5\x1b\xa2\x8b\xf8\xeb\xee;\x05\xa9\x8f\x17\x99\xd0\xb5\xccPsmNa\xe0\xa7\xb7\xc7&V\xbf\x10\xfb\x8a\xaf\xa9\xfa\xee\xf1\xfd\xb1\xe6\x825\x9c\n\xd6\x89\xe4zW\x8c\x8b,\xa2\xc7\xfa\"&\x16[\xa6^\xee\xd9\x7f\xc5\xec\x0e\x97\xa9\xbf\xe2=4<&\xb2\xa0\x9b\xf1\xfa.q\x89R\xeb\xe9^J\xd7\xa8w\xc45H\xf7\x8f\x84:\xac}\x93\x81\xd7V\x16YD\xb4\xab\xcc\xbdj\xef+5q\xb1\x1b;Vj\x9f\xad\xa94;z\xf8\xe7\xd7\x98\xffu\xa7\xdcwY/\xf7[\xe4k\x14oc\xdd\xcb\xf8\xeb\x0e\x02\xf0M\xdd\xfcI\xec\xb3~d\x1c\x14ww\xdc<$\xe6_^\xbf<(\xde\x8a\xd5\x83c\x9d5\x87\xa7\xd7\x1c\x94\xdd\x8e\x8f\x83\xc2\xeel?8\xf6\xaf\xb4&GF\x1d&d7Z?8n\xd2\xee\x7f\x17<\xc9\xf7\xe2p\x08\xe7\xe4=\xd6\x87\xc5nz\xdb\x07\xc7|\x89\xaez\x13\x9a\xd5\x81}\xab\xd0U\x17&\xd9?8\xf4{\xac\x0f\x8a\xd9O\xf7o\x87\xdb\x1f\xed\xdb\xd9><%\xa7\xfdS4\x9b\x9co?\x8d\xee\xff\xda\x8aW5\x8b\x90\x0e>^\xe7\xc6\xdc\xfe{\xfc\xe6\xc2\xbafS\x8f\x93\xfb\x17\xeb\xa8\x01n}h\xa0\xb2\xda:\xf0,\x80\x0dI\x0e\xca\x1a\x1f\xef*	\x0e\xb0\xe2\x96\xbdbS\x03i\x96\x02\xdeQ\x05\x14@\x93\x05\x14\xa0k\xe8h\xc3*h\x04a\xa3\xb8\x8a\xb7c:\xd4:\x12\x0e\x0dt,Hp\x19\xbcX@\x05\xa4\x89\xd7d\xaa\x02\xde:$OF\xc0c\xcd\"\xec\xc1:\xc5)L\x0e\x9f\x02\xfb= \x0dIHo*n\xc8Hh\xc0XS\xc0\x8f\xd6\x90\x82\x065\xf9\x80\x15\xc2\x86L\xe5\xc8\xb1$g9x\xaa\xa0\x0b\xba\x0d\x12o\x8d>\xdfv\x83\x8e\xc4!\x04)\xe0\x1cI\x91A\x0f\x8e}\xf0`\xd8\xeb\x1c\xa8&/\xe8\x07\xeck\xc7\xa6f\xad\x11\xb8\xcaa\xcd\x86W\xc1\x03	\xe8Hb\x01\xe7\xd6\xad\x18\x18U\xd0\x9c<0 h^\x91\xb3\xd0qG\xcea\xfc\xee\x815\xb9\x01\xba\xe6:26\xb8*\xe0ep\xb8\xe2\x98f\x1f\xb1\xb5>\x90\xa3\xe4\xabgS\x05\x17\xe1U\xac\xa2=\x86:P>\x1e&\xad\xc9\x08\xf9O\x81\xa0a\x08Zc\xa3\xack\xc9\x01\x85\x91\"\n\xec\x1b[\x81\xe1\xd5f\xaa\xf5\x90tc5ya\x9a\x98)\xe0U\xf0\x8a\"\x0f\x92\n\x02]<\xb0\n:4y\xfa\x0b\xcdU\xa1\x19\xac\xa3d\xb6\x14k\xb2\x05\xbc\x0b\xbe%S\xb1\xf7\x04k\x0c*f\x99\x8fY\xa3\x00j\xfe\x14(\xd6\xc8\xa0\xa3\xbc'r(\xf1\xa8\x1b\xf2\x92\x03\xad\xd7\xac\x121\xa4i \x8f\x9c\xf5\xd0\x06\x17|tU\x05.\xe0M0\xaa\x97L\xd0\xe2X1y \x872R\x84J\x85\xc6\xa3\x01\xb1N\xac+\x86O\xa8d\x16$\x1d\x05\x04\x8f-\x93\xd9S]\xc5\xb5a\xef\xb9\x89\xd2\xad\x1dv\\!\xc4\x0f\xb9T5\x87>\xd6uT\x8b\x90\xd6\xf1\xfd$\xec\xce\xea -\xca l\x01t*\xc0\x8aWd\xaaHd\x0fNYS\x87D\xdcn\x0b\xfdC\x86\x9e	\xce`\x0e\x1d:\x0e\xfb\x1d\x11\xa5\n\x14\xf2IyS\x96\xd1$y@\x99\xe8L<\xbd\xdd\xa0O \xfb\xca\xf6l\xa4\x1e\xe9s\x02OM\x94M\xcf\"\xf9\x02\xce\xc8\x10\x1a0\x14\xc5\x95\x06E>I	\xd5^?F\xbaSa\xb1I.m\xc5\xb6\x17\xef\xf4v\x94]\xea\x91 \x83\x9c\x07&\x1b\xbc\xe6&\x8c4\xc6\xed\x01\xdf\x10/\x8f\x1d?H\x06$\xb8\x96=\xb4\xd6	\x16\xf0\xda\xa4\x88\xbbh\x926\xc7B\x9f\x0d\x00R\xa1\xc7\xaaD\xd9\xc4V\x99\n\x92x\x1b4\x1f56\x00\xca\xa1#\x0d\xca6\x8d\xadl\xdfz;\x8d\x92\xa0Ou\x9bR\xed\x8fQ\x18\x8bS\xc0\x8fQ\x9e\xdbA\xb55\xd1h\x1d\x91\xecN\x81\x89\xa8\x84(	;y\x1dA\x0ce\x1a\x83E^\x92X\xf6&o\x0ed\xb8\x89\x02\x1d\xcb	\xe2\xd8Kl\xbd\xbc\x97\xa2\xa7\x06\xbc\xd5:\xf5X\xc5&\xaa$\xdfm$\xf2C\xe9\x87\xde\x8d\x914\xaa\xa8\xf2\xdd\x1e\xdf\xa6\xd5K\xba'2@\x83q\xae')&Z\x87i8uP*\xc0v\xfe\xeb\x90D\x98\xa6t>\x14b\xd0ZD\xda\x06\xdd\xb1A\x07\x86\xd4\xd0\xf3\x14\xa0\xd5\xa8R]{\xbd\xf5.\xd1\xc8\x889\x07\x17\xc4\x85^\x93\xd3\xa43\xd6\xe4\xd3\x98\xe5j\xe8\xfdb\xe7f\x1d\x16~y|4/+\xee\x96\xc7\xf3\xb2\xbf\xb2\x8f\xe7\xe5\xf0/\xc3\xff\x04\x00\x00\xff\xffPK\x07\x080\x04\x90\x01\x1a\x07\x00\x00;\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00+\x9ccL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x00resources/css/mymonies.cssUT\x05\x00\x01\x82\xf8\x9aZt\x93Mn\xfb6\x10\xc5\xd7\xe2)\x06)\n$\x86\xe9(q\x0b\xd8\x14\xba\xe8.\x07(\xba)\xba\x18\x89#i\xea\x11)\x90\x94?Z\xe4\xee\x05-'\xb1\x93\xfc\x01-\xa4\xd1\xe3p~\x8f\x8f\x8f\x0b\xf5\xc2\x96`r-\x8b\x90\x85D\xc3(\x98r%\xb1\xc0\x9f\x13A\x8f\x11\xdaI\xe4\x04\xe2\xd1\x92U\x8bG\xf5\xd7^7\xe2q\xf77\xfc\xa7\n\xcbq\x14<\x19p\xdeQ\xa5^\x95Z\x0d\xde\xa2\xe8\x01\xe3.\x0bF\x1f9\xb1w\x06Z>\x92\xadT\xf1\xaffg\xe9h`\xbb\xddn*U$?\x1a(+U\x08\xb5i~;\xb0M\xbd\x81\xa7\xb2\xfc\xb9REO\xdc\xf5\xe9\xfd\xb3\xc6f\xd7\x05?9\xab\x1b/>\x18\x08]\x8d\xf7\xe5\x12\xe6g\xf5\xebCu5X\xc2Z(o\x13\xd0\xbd\x8d\xe2Gl8\x9d`\xb5\x8e@\x18o\x06?\x04\x1cG\n7p\xe7\x1e\xba!\x91J\x15{\n\x89\x1b\x14\x8d\xc2\x9d30\xb0\xb5r\xd3\xa2\xf1.!\xbb\xb9\xc9\x85eS\x96\xe3\xb1R\xc5\x80\xa1cg\xa0\x1c\x8f\x80S\xf2\x95*F\xb4\x96]g\xe09\x17\xd7\xb3\xee+\xe5Om\xdb\xe6\x1f>X\n:\xa0\xe5)\x1ax\x9e\xd5\xfe\xa8c\x8f\xd6\x1f\x0c\x94\xb9\x06\x9b\xf1\xf8\xd9\x97\xf5\xfa\xe1\x93\x0f(r\xe5A\xd1z\x97t\x8b\x03\xcb\xc9\xc0\x0b\xc9\x9e2\xe8\x12~\x0f\x8c\xb2\x84\x88.\xeaH\x81\xdbk\xd8\x9e\xd0R\x80~\x9dag:\xfd~\xa4o\xa3\xff\xf2\\o7\xeb\xebe\xb5\xb7\xa7\x8f\x15\x17\xf6\xf2Za\xa9\xc5I\x92\xae\xa7\x94\xbc\xcb\xdaV<&\x03!\xc7\xe1\xac|\\\xa8\x05\xfc\xd1\x13\xb4^\xc4\x1f\xd8u\x10\xd3I(\x02\x06:\xdb\xabq\x1c\x85s\xb8=\x90\xd0@.E8p\xea\xd5\x02>\x9c\xf8\xed\xee\xbc\xe5\x1d\x1czr\x90z\xe2\x00{\x8e\\\xb3\xe4\x98p\x84\xe4\xbbNr\xfe\xa1>\xe5\x9b\xb1\xfa'\xae\xf2e\xb8\xccJ.\xcd\xa7}I\x96\xb9E\x11\xc2=il\x12\xef\xe9\xc7\xaa\xb9\xc9\xe7\x0c-\xbfm\xf2]\xd2\xf4\x81\xea\x1d'}\xe6j}\x18\x0c\xc4\x06\x85\xee\x9fVO\xef'\xff\xb5\xfe\xaa\xfe\x0f\x00\x00\xff\xffPK\x07\x08\xed\xce\xc9\xea\x1d\x02\x00\x00\n\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xf9\xa8dL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x18\x00	\x00resources/js/mymonies.jsUT\x05\x00\x01'`\x9cZ\xbc\x18]o\xe3\xb8\xf1]\xbfb\xa0\x14'y\xcf\x92{\xafNd\xf4Z\xe4P`w[\xa0H\x17\x05\x16\xc1f,\x8e-\"\x14\xa9\x8a\x94\xbdF\xd6\xff\xbd \xf5-\xcbIZ\x14\xa7\x17Q\x9c\x0f\xce\xf7\x0cu\xe4\x92\xa9c\xac\xa4P\xc8 \x01.\xb9\xb9\xf5<A\x06\xb0(n=oW\xc9\xd4p%\x1d$\\\xc0\x8b\x07\x00\x16\x06	H:\xc2\x97\x8a\xc2z\xcf>$\xd6\x10\xdc`Q\x04\xcbn\x8f\xa1\xc15\xf48\xf6\xc9\x15C\xf1P\xa2\xd4\xe8\xb8\xafa\x87B\xd3r\x84\x84i\xaa*i\xd6\x10\x04\xb3\x00\xbd\x86\xaf\x8fc\x88\xc1\xbd^\xc3\xcby\xb2\xdb\x1f4\xa1\x19 \x1e\xd1\xa4\xd9T\xceN\x84\xce\x0c\x9d	\x86OU044\xd0G\x87X\x14qC\xbd\x88S\xcb;T\xf2_Y\xf9\x1br\xb1\xb8\x1dq8{\xe3\xd5yq\xeb\xb9\xc5j\x05\x7f\x11JSm.P\x12H\xa7X\x10<\xd3)\x86\x87\x8ckHQ\x06\x06\xb6\x04[UI\x06\\\x02\xe3\x07\xd0\xca\xfa\xa5\x01f(\x99 \xe0&vL\x99J\xab\x9c\xa4\x89\x91\xb1\xfb\x03I\xf3\x89kC\x92\xca\xd0\x7f\xa6SU\xf8\xcb^Y%?\xd2\xe9\x9f\x05\x844\xd4\x9a\xef \xa4\xf8\x99N\x90$	\xf8\xf7N&\x7fj\x17k\x80\xa9\x9b!\xa9\xfd<\xa7\xb0[}>\xe5Jr\xd2\xdf\x04\xd7\xe6\x9b\xf5f\xe8\xfbK\xebP\xd8+\xf3\x80{\xbd\x84\xa9\x19;i\x1b\x8c\xb0$=\x14&UR+A\xb1P{\x0b\x8a-\xd7\x81\x07\xda\xadx\xa7\xca{L\xb304\x0bH6N~\xbb\xff\xd5\xc4\x12sz\x84\x04L\xcc\xd9\x80r\xc8\xb8\xc5n\xc0\xe79u\xda\xb0\x1d\xaa\xf4k\xb3\xf7\xbaZ-\xd6T\xb5A\x90iH\x9c*\xedg+\xc7\xd9\xf3\xbc/\x15\xc5\xa9\xca\x0b%I\x9a00\xb8\xd5\xc1\xb2ac(/\x04\x1aZ\xc3S\xc7\xf6\xce\xc6\x10g\x89o1}H\x05j\x9d\xf8\xa9\x92\x06\xb9\xa42\xda\x89\x8a3\x7f3r\xf7\x9d\xc4C\x8b)\xf1\xb0\xc5\x12\xeaWD\xdf\x0b\x94\xac\xfd\x12|\x9f\x19\xd8\xee\xeb\xc5\x84\x89c\x84c6\xd1\xb6D\xc9|\xc8J\xda%\xfe\x8d\x0f\x7fJ\x05O\x9f\x13_\x93\xa0\xd4<\xe06\x0c\x82\x85\xbfim}\xb7\xc2\x8dw\xc9\xb5\x12\x13\xb6V\xde\xbc\x8c\xb02jF\nG#\xf8\x80&\xe2\x86r\xb0q| \x1f\x0e\xd1N\x95\xce@6\xe7j;\x1d\"\xbes[\xf1\x85uFl\x87\nF\x82\xcbg\x1f\xd6\xb5v\x96\xd6\xae\xe6\x94\xac\xf9.\xfc\xcd\xcb\x8b=\xcf\x05%\x9c\xcfN\xdd\xd9cV\x82\xcfXwU\x89\x89\xe3V\x12\x0f\x93\xad\xa1\xff#F\x06\xb9\xd0s\xae\xd2B\x99\xcd\xdd\xca\xbd&L\x19\x1f0\x9d|>\xd5U7'\x93)\xa6\x875\xb7W\x97\xb3iA1\x19\xb7\x99\xba\x1df*n]\xae^Vdg-\xfd\xab\xf3\x16$\xd0X\xcf\x15\xacQ\x0eCS~\xbau\x9d6\xb5\x80\xb6s\x8d\xea}I\xa6*%8\x07\xb8N\x02\xe7\xdb!AZ\x12\x1ab#\x9aNl[@\xec\xfa\x0fi\xc6\x05+I\x8eHs\x9b\xb5s\xa4\x1dz\xaf6\xcd(m\x8brW\xda\x85J\xd1\xd6\x8e8C\x9d9\xa5\xc9\xc5\xd5\x85\x99~\xfc\x80\x10^!\x0b\x02\xf8\xe9\xa7\x86\xb8\xde\xb8	\x16s\x1d\x90\x86\xd66eEW[\xdc\xa2S\xdb\x95\xfd\xcb\xda\xf4vi:D:S\xc7\xc4o\x8f\x9c\x96\xa2\xec\x17\x9b$]\x82d\xbfL\xe0\xb3a;\x88\xd1&>\x8bR\x15\xa3\xe8\xe4l\x0d/P\xd2\xbf+^\x12[;=\x87#\x84=\xf1:\xc6\x9bA\xd5\xaa\xd3\x0cC\xd3\xd8RyQ\x19\xcb\xb4'\xb5n\xb9\x18H\x1a~\xc1M\x00?\xd7!\xc4\xd9L\x80\xcfZ\xbfo\xd5\x91mYo\xba\xe2\xfd\x86}\x9a?\xb1N\xf8\xa8\xe9Z\xaf\x9fW\xe3\xc2!\xca\x15#\xd1\xd6F\xba\xe8D\xaa\xb0\nl\xa2\xe8n\xd5,\xe7\xe0m	G[\xc0\xdb\xae\xe9\xaa+\xc6\xb2\xca\xb7T\xba\xe0\x99r\xb8[\xd5\xc7\x8e\"\xe5bvlEs\xe3b\x93\xc7\x94s\x13\x06\xf5\xa8\xb8n\x11\x82e\x0dm\xbf\x17\xef\xac@#\x8dZ\xe25\xf8Q\xe4\xf7\xae\x1e\xc5\xcfE4\xf7S\xf4e\xc4\xd6\x84W2t\xff\x7fq\x13\x8c\\\xb1\xe5\x92\xad\x0f(*\xb2]\xc7N]\xb8\x7f\xec\xdal\xc8\xd9\xd2\xce\xf6\x8b\xba\xd9\xeeu\xd3\x05\xf7\xaf\xbb\xe8Z\x1e\xf7\xf6\x1a\xde\x14\x9a\xbb\xc3\xb5\xec\x9d5\x86\x1bp_7\x87\xcb)\xee\xd4\xb4\xf5!\xf1\x1d\xcd4fmYk\xa6\x02\x07\x8fr\xd4\xcf\xfd\x18\xd0\x04Ojo\x03v\xd8\xb9\xb4\xe4\x05\x83c\x89EA\xe5\xb5\xd9\xe6\x02\xbf\x1b\xef\xdaScmT13J\xcd\x93g\x84\xec\xeai\xd0\x16\x87\xc6\x04o\"\xdb\xe7\x01\x0b\xccL\x95\xa3\x04\xc3\x89)s\x9d\xf5\xcc\x04\xd2\xc3\\\x99z\xa7\x16[\xc5N\xef\xd4\xe1\x0dT\xfb0\xdaa%\x0cX\xd4\xdfA\xf8\x9dR\xe6\xdd.x\x1by[\x19\xa3\xe4\xf8\x88F\xa3\xa8\x86\xfd\x17\xf19|\xfe\xfe\xf1\x15c\xd4\x9c_\x11\xeb\x7f5\xd7\xea\xa2]\xbd\x7f\xfbn\xd5g\xf1\xe6\xe9\xad\xd6Y\x0f\xcc3U!\xb8\xb9\xc0\x8aZhPs\xf5V\x1f\xbc\x0f\xf0\x0f*\x04\xa6\x04&#8fJ\x10\x14\xb8'8r\x93\x81\xa1\xef\x06\xfc\x1drA\x0c\x8c\x02\xa1\x90\xf9\xb1\xf7a\xe5\x0d\xae\xed\xcdU2\xa4\xb2l\xbb\x87\xa0:\x10!\xe9\x07\xbe=\x99{Av\xa9\xff|z\xc0\xfd\xdf0\xa7\xb0\x8e\xec\xc5\xd7?>\xd6\xfd\xc3~\xc6\\J*\xff\xfa\xf0\xf9\x13$\x10\xd8\x01\xeb\xb7\x91\x00\xcdO\x1e;qPY\xc2\xcf\x10\xb8\xb1+\xb8\xb5\xf7O\xd4'\x99\xf6\xf7\xd9\xb9\x9f%\xcd\x8f\x92F\xd4\xc9_\x80!\xa6\xbb:w~\xd9qa\xa8\xbc\xfa\xd3\xa6Yt\xc0n\x10\x83\xd0\xca\xdb_\xf3\x07'X\xf3\xa0\xc1\xd1\xde\xf8r~\xf6\xbc\xff\x04\x00\x00\xff\xffPK\x07\x08((\xf4\xf7\xbc\x05\x00\x006\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00+\x9ccL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00resources/js/rpc/mymonies/service_twirp.d.tsUT\x05\x00\x01\x82\xf8\x9aZ\xb4UMk\x1b1\x10\xbd\xfbW\xcc\xcd\x8e1\xc9}C\x9bC\xa9\xa1\xd0@I{)\xa5,civ-\xba\x926#\xc9\x89	\xc9o/\x92\xd7\xde\x0f\xef&\x87\xb4\x97,y\xf3\xf4\xe6I\x1a=\xcf\xe8\xb1\xb6\xec\xa1\x08Fxe\x0d\xdc\xee\xb55\x8a\\\x8eR\xe65zOl\x16\x8exG\x1c!&\xe72p\x9e\x95)W\xd0\xe1\xe4L\xf7\x19\xa0\xd9\xaf\xc0\x9a\xefA\x88D\\7\xb2\x11\xfc\xccl9\x83\xf4\xf9\x84U\xb5A\xf1\xe7\"\x83\x9dU\xf2z\xdaF\xa5\x9c\xcfQ\x08\x1b\x8cw\x93Fz\xac\xffj\xc5c\xf9\x86\x8d\xc8\x18\xb7\xb0`r\x19|U\xce\xff\xc0\xd2\xdd\x91\xab\xadqt\x01\x1f>\xa6\xd6\xefu\xc6h\x1c\xa6\xca[\x0e;\xcc\x83\xd3\xe4\xa9E\xef\xe8>\x90\xf3S\xe6\xbb\xc4\x7f\xb6\x87PK\xf4\x14Oo\xd2|Ky\xe7\x15\xcf\xae\x96/\xf0\xd3\x06\x10h@\x92\xa8\x90	\xfc\xbe&\x07~\x8b\x1e\xe2\xbf\xb8CU\xe1\xa6\"\xd8)\x04\xa5\xa3meJ\xf0[\x02me\xa8\x08\x96W3e<q\x81\x82\xfa\x9d\xe0i\x06\x00\xb0\xa0\x0c\xfc\x83\xe2:\x15O\xed\x9fg\x9dum\xbdY$\xac\xa4\xe3\x9e\xaf\x13\xa2]9\x00\xc8c\xd6\xd0\xd3\x12\x0cn\xb0\xe69vi\x0e\xbbm6~\xcf\x8dR\xa1*O\x9cA\x87\xb0N\xd0\xc0\xf1\xc4\x0c4*\xdd\xe1\xeai\xfd\xfa=\xd0\xe9\xd4\x9a\xb5J\xf67\xd1\xd1\xca\xe3\xd5\xf7\xab;\xac\x02\x8d\xe05\xee5\x19?RA\x1d\x03\"\x03\x13\xf4&n\xaba\x13\xe5\xf1/\x0f\xc8\x878\xe9\x83\x1b%&-\xf6\x0bL\x051\x19qn\x8e8\x9f(jr\x0e\xcb\x01(\x90e~p<h\x8de><\xb1\xc3\x9c\xf6\xe0\xb198\xbb\xe2\xd3\x05\xdc\x8c\x1e\xc2\x00\xd5\xd6\xf8\xed\x00\xbb\x0f\xc4\xfb\x9b\xd7\xdb\x0e\xa3\xef82X\xc6Q\xc1\xb2\x19\x91s\xbbXNL\x88AM\xbd\x9ec/\xbbf[\x13{E\x0el\xd1}\xc0\xc1\xc5\x17-\xac\x89YWQ\x0c<\x86\x1dr|\xd9\x8d\x89T\x04\xbd_+\xaad;9\xa9\xcf\x97$\x16\xb3\xe2\x18\x1f\xabN\xaf$\xa6\xc9o\xadt\xa0\x8cS\x92@Z\xefI&\xd7n\x06\xcb\x97\xbe\x9fU\x9bE[\xd2\xc75\x08\xf3\xc4\xafQ\xd0\xfcr\xd6\xf1v\x82\xc1\x85\xcd7\xb6usH\xd1\xdb\xda2\xd0#\xea:\xca\x96jG\x06\xfcV9\x90T(\xa3\x0e)\xe9\xac&k\x08\x84\x0d\x95\x84\x07V\x9e\xb2C\xa6,_N\xb3\x04O'\xf5g(\xd8j\x98\xefm\xe0\xdb\xe4x~\xdd\xe17\xb4\xcb\xc2\xda\xc5E[\xb0|\xae\xb9\x04t\xd0\xc8\xbc.\xda\xc0\x97\xa3\xe2W\xe9;\xfcII\x946k\xff\x06\x00\x00\xff\xffPK\x07\x08\xe5b\xabR\xa5\x02\x00\x00\xe3\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00+\x9ccL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00resources/js/rpc/mymonies/service_twirp.jsUT\x05\x00\x01\x82\xf8\x9aZ\xcc\x94\xc1o\xeaF\x10\xc6\xef\xfe+\xa6>\x19\x95\xd8\xb4J/\x8d8DI\xa4\xb4JB\x14\x1c\xa97\xb4\xac\x07{\x93e\xd7\xdd\x9d\x85\xa0&\xff{\xb5k\x0c\x866<K\xef\x90\xc7\x01Y\x9eo\xbe\xfd\xe6\xc7,Y\x06W\xba@(Q\xa1a\x84\x05\xcc7P\x1bM\x9a\x9f\x95\xa8\xceh-L=\x9b\x1b\xbd\xb6h^,\xacF\xe9(\xfde\x08\xd7\x13x\x98\xe4ps\xfdG\x9eFY\x06V;\xc3\xf1w\xb0hV\x82c\x1a,\"_\x99\x19\xfc\xdb\xa1% \xf6\x8a\x16\xa8B\xb8\xcd\xf3GX\"U\xba\x18\xc2\xf3\xd3\x1d\xd4\x8c*H\xa8\x12\x16\xd6BJp\xd61)7\xc0\xb5\"&Th*\xf4\xd2?*\xb6\xc4\x81\xf7}\xb1Z\xc1\\\x17\x1b\xa0\x8aQ\xd37G\xb0\xa8\x08H\x87\x16\x1f\x06\xcd\x10\x18p&\xe5\x9c\xf1W\xd0\n\xac\xe3\x1c\xad]8	\xdbh\x16\x98*\x80y\xd7\x9dp\xa1\xcd\xbe\x1cN@c\xb4\x01\xed(\x8dV\xcc\xec\xe7\x1a\xc3\xc2)NB\xab\xa4\x9d\xc9\xcf3\x0c\xe1\x86\xa0\xd5\xb49\xd0?\xdex\x8f\x01\xfc\x13\x01x\x8f\xb7\xca\xc0\x18\x14\xae\xe1\xaf\xfb\xbb[\xa2\xfa\xa9\xf1L\x06\x17\x11\xf8j\xaak<\xb6%\xe3pW\xb7H\xdb\x9e[d\x05\x9a$\xbe\xe4\x1ck\x8a\x871\xabk)8\xf3\xc12\x0f+>\xd1t\xa5\x15\xa1\xa2\xb3|S\xe3'\xadm e\x90\x15\x1bK\x8c\x90WL\x95\xd8\x01\x00	6\xc3\x01\x88\x05$^\x1f\xd4S\xaf\x86\xf1\x18\xce\xdb\xf2^\xe0\x9d\x9c\xf5\xc5_G\xe7\xf0\xfe\x0e\xc7/\x7f\xdb\xf7\xc0\x9ef\x83\xc8\x7f>\x00\xa5\xc5\xff\xf5\x1bu[=\xf0\x15\x93\xce\x07\xfes:yHkf,nC\xdaZ+\x8b9\xbe\xd1\xce\xb7{X\xe8;>\xf1\xfb\xac\xc3*\x1c\x1bG\xed\xf7G\x00\xeeg\n+\xfe\xd3\x18\x94\x93\xb2\x1d\xa7\xf9\x11U\x91\x84\xc3,\x19\xa1J\xb1\xd8\x04\xed \xb8\x1dD\xdc\xc9\x83G(G\xfe\x80,\xdb\xdeB\x1b\xd6\xfd~\xb3\xd4J\xa0\xbd\x92\x02\x15Ea\xcb\xdbw3V\x14\xb3\x9a\x11\xa1Q\xdd\x8do\xae\x98\xaf\x9a\xb0\xe2\x1d\x99\xbf \xa7\xd7\x7f\xe1\xa4\x9c5	`\x0c\x87V\xf03\xc4Y\xf8\xf7\xc9b\xff\xcc\xf52-\x05Un\x9e\xbeh\x85\xf6U\xebt\xb9\x0d\x97\xb6)\x83\xb2\xd1_\x16\xc5c\x93#\xf6\x03\xb7\xb75\x89\x1f'\xd3<\x1ev\xcf\xee\x97\xfa\xc2#;@\"\x85\xa5\x19\xe3\\;E\xf6\x14\x94\x03\xe1\xd7b\xb9\x13\x96.\xb7Iz\x80\xe9\x97\xfc\x134\xc4\xcaoc\xf1\xa2\xafG\x92\xb3\xb27\x8e\x93\x89?Ca\x98\xb2,`\xe8\x81\xa4#\xfe\x01\xd0t\xd2\xf4F\xd4g\x82\xff\xa2ru\xc1\x08=\xe0S\x8c\xf6\xaa\xaf\x85\xf3\x1cr\xe4\xac\xecA\xa5G\xe6\x80\xe3\xdf\x00\x00\x00\xff\xffPK\x07\x08\xdf\x936^\xbc\x02\x00\x00\x8f	\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00+\x9ccL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00robots.txtUT\x05\x00\x01\x82\xf8\x9aZ\n-N-\xd2MLO\xcd+\xb1R\xd0\xe2r\xc9,N\xcc\xc9\xc9/\xb7R\xd0\xe7\x02\x04\x00\x00\xff\xffPK\x07\x08B\x84\xa4\x8f \x00\x00\x00\x1a\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00R\xa8dL0\x04\x90\x01\x1a\x07\x00\x00;\x14\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00index.htmlUT\x05\x00\x01\xec^\x9cZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00+\x9ccL\xed\xce\xc9\xea\x1d\x02\x00\x00\n\x04\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81[\x07\x00\x00resources/css/mymonies.cssUT\x05\x00\x01\x82\xf8\x9aZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xf9\xa8dL((\xf4\xf7\xbc\x05\x00\x006\x13\x00\x00\x18\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc9	\x00\x00resources/js/mymonies.jsUT\x05\x00\x01'`\x9cZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00+\x9ccL\xe5b\xabR\xa5\x02\x00\x00\xe3\x08\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd4\x0f\x00\x00resources/js/rpc/mymonies/service_twirp.d.tsUT\x05\x00\x01\x82\xf8\x9aZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00+\x9ccL\xdf\x936^\xbc\x02\x00\x00\x8f	\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdc\x12\x00\x00resources/js/rpc/mymonies/service_twirp.jsUT\x05\x00\x01\x82\xf8\x9aZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00+\x9ccLB\x84\xa4\x8f \x00\x00\x00\x1a\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf9\x15\x00\x00robots.txtUT\x05\x00\x01\x82\xf8\x9aZPK\x05\x06\x00\x00\x00\x00\x06\x00\x06\x00\xe6\x01\x00\x00Z\x16\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x18\x94R]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00index.htmlUT\x05\x00\x01\xd1\x10\xd5j\xccX\xdbn\xdcF\xd2\xbe\x96\x9e\xa2~^\xe5\xc7\xce\x90v\xec\x04\x81\xc1\x19D\x9bl\x82\x1clxc/b`\xb10j\x9a5\x9c\x92\xfa@wWO$\x04y\x1b?\xc3\xbe\x80^l\xd1\xcd\xc3\x0c%\xd9\x8e\xb47{3 \xbb\xbbN_}_\xb1\xa5z'F\xafOO\xeb\x1da\xb3>\x05\xa8\x85E\xd3\xfa\xf9\x95q\x96)\xd4U\xff\x9ev4\xdb\x0b\xf0\xa4WE\x90+MaG$\x05\xec<mW\xc5N\xa4\x0b\xcf\xaa\xca\xe0\xa5jl\xb9qN\x82x\xec\xd2\x8br\xa6\x9a\x16\xaa\xa7\xe5\xa3\xf2Q\xa5B8\xac\x95\x86m\xa9B(\x80\xadP\xebY\xaeVE\xd8\xe1\x93\xaf\x9e.\xbf\xb7_<\xf9\xea\xe9\xe5\xbb\xbf?F\xf7\xeb\x9b\xb3\xbf<\xfa\xe2\xab_\xde\xbc\xbc|\xd9~\xb9\xbdz\xfa\xc3\xaf\xfb\xd7/v\x8f\xfe\xf6\xf9\x97O\xde\x98\xef\xd4\x8f\xfa\xd5\xd9o\xfc}\xfb\xdd\xd9\xafUs\xc6\xaf\xbe\xfc\xf1\x8d)@y\x17\x82\xf3\xdc\xb2]\x15h\x9d\xbd2.\x86\"\x97\x14\x94\xe7N xu(!\xa5|\x1e\x1a\xd2\xbc\xf7\xa5%\xa9lg\xaa}\xa4\xaf?/\xbf(\x1f?\xa9\x1a\x0e\x92\xde\xcb\xf3P\xac\xeb\xaaw\x91\x01\xfa\xbf\xe5\xf2\xe3(y\n.zE\xe1\x7f\xa1~X.\x0fY\x1f\x03q\xc8\xf2<\xdc.4\x9b}\x8a\x0e\xf3B\xcd@\xa6\\\xe3-\xdcg\xe10\xca\xee6\xb0\x1f<\xee;5y\xaf\x02\xf9=+z+\xbf\xb1\xef\xee\xe1dtp\xc3\xa4\xaezM\x9c\xd6\x1b\xd7\\\xadOO\xea\x86\xf7\xc0\xcd\xaa\xc0\xae+`\xbfT\xda\xe1\xc5\xfa\xf4\xe4\xa4\x16\xdc\x84\xf4\x90\x9f\xc0\xa2\xa1U1*\xa8\xc8&E\xde\xce\xfb\x9a\xf2\x8a8A\x1d\nP\x1aCX\x15\xfdF\xfe]\xee\xdc\x9e\xfc`qRKJc:\x96^\x96l\xf7\xe4\x03\x8dGNj\xf1\xe3c:\xbf\xfe)\xc6\x0b\x8c\x81\xebJv\xb3\x8d\x9f\xa3\xbb\xb8\xc0\x9b\xcb\xb3\x1c\x96\x9e\xdb\x9d\x14\xeb\xd7\xd8\xe1N\xa2\xe1?w\xfcU4fv\xb2\xae\xa6\xa4\xd2r\x86r\x088\xe09\xbcy\xd8/\xb7\xce\xaf\n\x01\xb60\xc02z9\xa9\xa5Y\xff\xfe;Hi\x9c\x95\x1d\xfc\xf1G]Isk\x17\xdb\x17h\xe83)\x05\xdb\xb7\xdc\xfc\xff\x1d\xe7 \xcbqU\x08]\xca\x125\xb7\xf6\x19\x0c\xa5f\xff\xcaE+\x0f\xb0C3\x18B\xef&zOV]\xdd\xf04G\xe3\x00@]\xe5\x96\xe7\x82\xf2\xf3\xfa\xf4\x06\x8f^\xb3f\x19z\x81\xd2\xb3I<\xda\x80J\xd8\xd9\x11\xaa\xfahm\xa99\xc8\x80Q\xdd\x8d`%G\xcf\x86\xe7:\x90&%KT}\xd9\xcf\x86\x87\xb0*\xc6\xa7\x02\x9e\xf5\x87\xa8)\xc3\x95U\xd3N\xd6\xc8\xcc|\x0cUu}\xf6''\xf5\xee\xf1D \x14\x10\xd6\xac\xf5\xf5\xfb\x04\xd1\xe0%\xe3\xb3{<\x19\x1c	\xe3P\xc8\x9f\x90\xc7\x9f\xd2\xc7L \xc9b\xfd\x13\xfbs\x8c\xa1\xbb~\xcf\xfb\xeb\xf7\xc7\xbc\xed\xf7\x9f\xe3E\xc0s\xac^!\x9e\xcf\x05\xf0A\x05<\xbf~\x7f\xfd\xde\xdf\xe5\xec.\xd5\x1d\xf8pC\x1e7\xf4q,\x90\xcf\xe4r\x01\x89\xddI'3\x94\xbeV\x9a\xd5\xc5\xaa0\xaeA\xfd\xfa\xb0\x05+\xe0f`\xc8\xb1^.\xcb#\xfb\xb7\x0d\n\x95!n\x82\xf8\xcf\x1e-\x1e?\xba%\x9fIg\x97e\x87WDo\xd3\xaf\xbf\xeb\xd4'TvyC.\x97\x1f\xd2Kv\xb6\xae\x05[x&\xd8f\xac\xdbcN\xae\x8aTDV{\"\xa4`\xbb\x9eO\x869\xc0G#g\x92\xdc\xf0\x9a1\x83\xfd\x92\xb7\xb7\xf1\xcb\xc8\xba@w\"\xbbE=\x9b\xc1\xc9\xeb\x07\xf9:\x8c\xf6`\n\x08\xda\xc9\xaaH\x19\x1d:s\xa3\xe9s\xc2f,\x8e\xbb\x9a\x1a6\xab\xf6\xd0\xa1\xc3\xa9\xf0\xcf\x9b9\xff\xebV\xd7o\xb7\xf0\x08\xb6\xbb\xb2x\x99\xda_\xa5_\xff\xa0\x04>J\x9fO\xc4>\xeb'\xc7\x83\xe2\x1eO\x9d\xfb\xc4\xfc\xeb\x0f\xdf<(\xde\x86\xd5\xfd\xeb3\x0f/o\xa6\xaa\x8f\xa7\xf6a\xc5}\x02\xff#/\x0f\xc2\xe4(\xaf{\xc7\xfe\x85\xb6\x94\xb2~\x18\xed\xfdh}\xef\xb8\x99\xe9\xff]\xf0L\xf6\xb7\x0fO\xe19\x85\x80\xed\xc3b\x9b\xde\xf6\xdee\x7f\x83\xbey\x11\xcd\xe6\x81*W\xe8\x9b\xb76\xdb\xdf;\xf4kl\x1f\x14\xb3\xff\x16|<\xdc\xfcC0\xbb|\xa5\xb7\x8c\xdfp\x9d\xaa\xee\xbcO\xdd}EK\xdfw\x16!\x1dC\xba\x03X{\xfd\xef\xf1\xa2\x86m\xcb\xb6\x1d\xe7\xfc\xcf\xce\x93\x01\xeeB4\xd08\xed<\x04\x16@C\xb2\x00\xe5lH\xb7-\x89\x1e\xb0\xe1\x8e\x83b\xdb\x02i\x96\x12^Q\x03\x14A\x93\x03\x14\xa0K\xd8\xd3\x8eU\xd4\x08\xc2Vq\x13\xedp\xa8\xf3$\x1c\x0d\xecY\x90\xe0<\x06q\x80\nH\x13o\xc96%\xbc\xf4H\x81\xac@\xc0\x96E8\x80\xf3\x8as\x98\x05\xbc\x8b\x1cf\x89\x18\x92\x98W\x1a6d%\x1a\xb0\xce\x96\xf0\xad\xb3\xa4\xc0\xa0\xa6\x10\xb1A\xd8\x91m<y\x96\xecl\x01\x81\x1a\xd8G\xddEA\xa1\xa1\xden\x87\x9e\xc4#D)\xe19\x92\"\x8b\x01<\x87\x18\xc0r\xd0\x0b\xa0\x96\x82`\x18r\xdfz\xb6-k\x8d\xc0\xcd\x02\xb6ly\x13\x03\x90\x80v\x9eL	\xcf\x9d\xdf00\xaa\xa89{`@\xd0\xbc!\xef`\xcf{\xf2\x1e\xd3e	\xb6\xe4\x87\xd45\xb7	\xb1\xc1U	\xdfD\x8f\x1bNe\xf6\x11;\x17\"y\xca\xbez4U\xf4)\xbd\x86U\xb2\xc7\xd8FZ\x8c\x87Ik\xb2B\xe1]$0\x0cQk4\xca\xf9\x8e<P\x1c!\xa2\xc8\xc1\xb8\x06,ovS\xaf\x87\xa2\x8d\xd3\x14\x84iB\xa6\x84\xefbP\x94p\x90\xdc\x10\xd8\xa7\x03\x9b\xa8\xa3Y\xe4\x7f\x8d\xf8&\x9a\xc1:Q\xe6\x00\xb1&W\xc2\xab\x18:\xb2\x0d\x87@\xb0\xc5\xa8R\x95\x8b\xb1j\x14@\xcd\xef\"\xa5\x1eY\xf4\xb4\xe8\x81\x1cZ<\xf2\x86\x82,\x80\xb6[V\x19\x18\xd24\x80G\xde\x05\xe8\xa2\x8f!\xb9j\"\x97\xf0\"Z\xd5S&j\xf1\xac\x98\x02\x90G\x19!B\xa5\xa2	\x98\xfe\xa6\xf3\xe2|9\\\xb8\xb2Y\x94|\x14\x10\x02vLv\xc6\xba\x86[\xcb!\xb0I\xd4m=\xee\xb9AH\xd7\xbe\xdc5\x8f!\xf5ud\x8b\x90\xd61\x1c\x11{\xeft\x94\x0ee \xb6\x00z\x15a\xc3\x1b\xb2M\x02\xb2\xef\x9fr\xb6\x8d\x19\xb8c	\xfdC\x06\xcdDoq\x01{\xf4\x1c\xe7\x8aHT\x05\x8a\x8b\x89yS\x95\xc9${@\x99\xe0\xcc8\xbd\xdca\xc8I\xa6\x9ce@#k\xa4\xaf	\x02\x99D\x9b\x1eE\n%\x9c\x91%\xb4`)\x91+\x0f\x8a\xc5D%T3=&\xb8sc\xd1d\x97\xae\xe1D\x05j\x0e\xab#\xed\x12\xaf\x13\xf0=\x9d\x07$\x0d^\xb2\x89#\x8c\x10\xa7\xfc\x86x\x8b\xa4\xf8\x812 \xd1w\x1c\xa0s^\xb0\x84\x1fl\x8ex\x9cM\xe6\xe6\xd8\xe8\xb3!\x81\xdc\xe8\xb1+\x896I*SC2n\x03\xe7\xbd\x9b\x12Z\xc0\x9e4(g\x8ck\\/\xbd#\xa1d\x12L}\x9bJ\xed\x8fQ\x1c\x9bS\xc2\xb7i\xa2\x1d\x06\xd5\xc1D\xa3\xf3Dr<\x05&\xa0rF\x99\xd8\x19\xd61\x89\xa1Mc\xb0\x84K&\xcbl\xf2.\x80,\x9bD\xd0\xb1\x9d \x9e\x83$\xe9-z*\x062\x10\x9c\xd6Yc\x0d\xdb\xc4\x92a\xf6N6}\xeb\x07\xed\xa6H\x1aU\x0cs\x8d\x1f\xca\xea)\x9d\xd3\xa6\x08\x06\xd3\\\xcfT\xcc\xb0\x0e\xd3pRPn\xc0a\xfe\xeb\x98\x96\xfb)\xbd\x18\x1a1p-e\xdaE\xbdg\x8b\x1e,\xa9A\xf3\x14\xa1\xd3\xa8r_{\xbe\xf5.\xd1\xca\x98\xf3\x02|\x14\x1f{NN\x93\xce:\xbb\x98\xc6,7\x83\xf6\xcb\xa3/\xeb\xf0\x90\xfe\x9bVW\x0d\xef\xd7\xa7u\xd5\x7f\xb2O\xebj'F\xafOO\xff3\x00PK\x07\x08\x8c\xa1N\xdd\x8a\x07\x00\x00\xb4\x16\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd2\x9bR]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00login.htmlUT\x05\x00\x01]\x1e\xd5j\x94R\xddN37\x10\xbd\xce>\x85\xe5\xdb*q\xf8\x08\x08\xa5\xde\x95rQPA\xadh\xa9Dn\x1d\xdb\xd9u\xf0\xcf23\x86\xec\xfb\xf0&\xbcX\xb5\xbb4\x01\xa1\xa8\xfa.=s\xe6\xcc\xf1\x99#\x1b\n\xbe*\n\xd9Xe\xaa\x821I\x8e\xbc\xad\xfe\xe8B\x8a\xce\xa2\x14\xe3\xbb\xefx\x17\x9f\x18X_r\xa4\xce[l\xac%\xce\x1a\xb0\xdb\x927D-.\x85\x08j\xafM\x9cmR\"$Pm\xff\xd0)\x88CA,f\xf3\xd9\\h\xc4cm\x16\\\x9ciD\xce\\$[\x83\xa3\xae\xe4\xd8\xa8\xf3\xab\xc5\xf4&^\x9c_-\xf6\xcf\x7f\x9d\xa9\xf4\xb8^\xfd2\xbf\xb8\xfa{}\xbf\xbf\xaf/\xb7\xdd\xe2\xf7\xc7\x97\x7f\xfel\xe6\xbf\xfd\xb8<_\x87k}\xeb\x1fV\xaf\xee\xa6\xbe^=\n\xb3r\x0f\x97\xb7\xeb\xc0\x99\x86\x84\x98\xc0\xd5.\x96\\\xc5\x14\xbb\x902\xf2\xff\xfd\x12XL\x19\xb4\xc5Al\xf80d\xd09\xd8\x81\x1a\\K\x0cA\x7f\xc6\xeeP\xa8L\xcdl\x87\xbc\x92b\xc4T\x85\x14\xa3\xbf\x85\xdc$\xd3U\xc5D\x1a\xf7\xc2\xb4W\x88%\xd7)\x92r\xd1\x02g\x83\x8a\x92\x07\xb5\x9f\xbe:C\xcd\x92\xfdX\xd8\xf0+\x0b\nj\x17\xa7\x94\xda%[\xd8\xc0\xabb2\x91\xcd\xd9\xa735gCm\x9b 0gJ\xeeS\xed\xe2\x80\xfb\xb2\xab\xefOkH\xb9\x1d{\x13\xe9\xd5\xc6z\xb6MP\xf2\x8c\x16\xa2\n\x96Ww\xefo\x1d\xd1\xfb\xdb\xee\xfd\x8dr\x8c\x19\xa5\x18p\x1f3.\xb6\x99\x18u\xad-9\xd9=\xf1/\xf4\xfd\x7f y>\xe88p2\x95)\xe9\x14Zo\xc9~\xda5\xd4\xb7Igd`\x9f\xb3\x03k\x86-R\x18\xf7\xf2s\xfa[\x85\xf8\x9a\xc0\xf0\xeaAy\x85*\xaa\xd3\xb2\x0f\xd8\xd3\xd2\x8f\x90\xaf\xd2u\x06\xb0\x91\xa6\xc7\xfe)\xe1\xed\xc0c\x01\x12\x1c\xf6\xf4~M\x8d\x8a\xb5\x85>!\xed\x88\xdcd\xa2\x14?,\xc5\xbc	\xeeh\xea\x86\"\xdbP\x9c\xb6\xe0\x82\x82\x8eWw\x0ev*\x9b,\xc586R\xa8aYrF\x7f\x9bD\xabS4\xfd\xec\x7f	3\x0e[\xaf\xba%\x8b)\xf6\xf7\xb6@\xeai\xa0\xa5\x1c\\\xb4Q\n\xd5\xd3J\xd1'\xa6\x0f\xecx\x8ebr:\xf7C\xe4\xbe\x05\x7f\x0c|!EC\xc1W\xc5\xbf\x03\x00PK\x07\x08\xb8\xb9\x87\x15B\x02\x00\x00q\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xc8\xa4iL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x00resources/css/mymonies.cssUT\x05\x00\x01\xb9\xf0\xa2ZtS\xcdn\xf36\x10<\x8bO\xb1HQ 1LG\x89[\xc0\xa6\xd0Coy\x80\xa2\x97\xa2\x87\x95\xb8\x92\xb6^\x91\x02I\xf9\xa7E\xde\xbd\xa0\xe5$v\x92\x0f\xd0AZ\x0d\x873\xc3\xe1\xe3B\xbd\xb0%\x98\\\xcb\"d!\xd10\n\xa6<I,\xf0\xe7D\xd0c\x84v\x129\x81x\xb4d\xd5\xe2Q\xfd\xb5\xd7\x8dx\xdc\xfd\x0d\xff\xa9\xc2r\x1c\x05O\x06\x9cwT\xa9W\xa5V\x83\xb7(z\xc0\xb8\xcb\x80\xd1GN\xec\x9d\x81\x96\x8fd+U\xfc\xab\xd9Y:\x1a\xd8n\xb7\x9bJ\x15\xc9\x8f\x06\xcaJ\x15Bm\x9a\xdf\x0elSo\xe0\xa9,\x7f\xaeT\xd1\x13w}z\xff\xac\xb1\xd9u\xc1O\xce\xea\xc6\x8b\x0f\x06BW\xe3}\xb9\x84\xf9Y\xfd\xfaP]	KX\x0b\xe5m\x02\xba7)~\xc4\x86\xd3	V\xeb\x08\x84\xf1F\xf8!\xe08R\xb81w\xe6\xd0\x0d\x89T\xaa\xd8SH\xdc\xa0h\x14\xee\x9c\x81\x81\xad\x95\x1b\x8a\xc6\xbb\x84\xecf\x92\x8b\x97MY\x8e\xc7J\x15\x03\x86\x8e\x9d\x81r<\x02N\xc9W\xaa\x18\xd1Zv\x9d\x81\xe7<\\\xcf\xb8\xaf.\x7fj\xdb6\xff\xf0\xc1R\xd0\x01-O\xd1\xc0\xf3\x8c\xf6G\x1d{\xb4\xfe`\xa0\xcc3\xd8\x8c\xc7\xcf\xb9\xac\xd7\x0f\x9fr@\x91\xab\x0c\x8a\xd6\xbb\xa4[\x1cXN\x06^H\xf6\x94\x8d.\xe1\xf7\xc0(K\x88\xe8\xa2\x8e\x14\xb8\xbd6\xdb\x13Z\n\xd0\xafsb\xb3;\xfd~\xa4o\xd2\x7fy\xae\xb7\x9b\xf5\xf5\xb2\xda\xdb\xd3\xc7\x8a\x8b\xf7\xf2\x1aa\xa9\xc5I\x92\xae\xa7\x94\xbc\xcb\xd8V<&\x03!\xd7\xe1\x8c|\\\xa8\x05\xfc\xd1\x13\xb4^\xc4\x1f\xd8u\x10\xd3I(\x02\x06:\xc7\xabq\x1c\x85s\xb9=\x90\xd0@.E8p\xea\xd5\x02>\x1a\xf1\xdb\xddy\xcb;8\xf4\xe4 \xf5\xc4\x01\xf6\x1c\xb9f\xc95\xe1\x08\xc9w\x9d\xe4\xfeC}\xca7c\xf5O\\\xe5\xcbp\xd1J.\xcd\xa7}i\x96\xb9\xb5\"\x84{\xd2\xd8$\xde\xd3\x8fQ3\xc9\xe7\x0e-\xbf%\xf9\xaei\xfa@\xf5\x8e\x93>\xfbj}\x18\x0c\xc4\x06\x85\xee\x9fVO\xef'\xffu\xfe\xaa\xfe\x1f\x00PK\x07\x08\xed\xce\xc9\xea\x19\x02\x00\x00\n\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xc6\x94R]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x00resources/js/auth.jsUT\x05\x00\x01\x14\x12\xd5j\x9cU\xdfo\xdbF\x0c~\xd7_\xc17\xc9\xae#u{\xac\xa1\xe5!\xd8\x9a\x0e\xed:\xc4\x1e\x10\xa0k\x87\x8bD\xfb\x0e9\x1f\xb5;\xaa\x89\xb1\xf9\x7f\x1fx'\xcb\xbf\x82b\xa8\x9f\x0c\xf1#\xf9\x91\xfc\xc8\xab*X`\x08\x86\x1c(\xd7\xc2\xcd\xe2\xee\x17\xd0\xca\xb5\xd6\xb85\x04\xad<\xb6\xf0\xb0\x05\xd6\x08\xaa\xeb\"F\xfe[Z\x1b\x07\x9dZc\x99eU\x05K\x8d\x10\xd0\x7fE\x0f\x1e\xff\xee\x8d\xc7\x10q1\x1e\xd3#:Xy\xda\xc4o\x9b\xed\x86\x9c\xc1\xf0W\x13\xfc\n\x1a\xa2G\x83`$\xbfD\xba\xbf\x12\x9f\xabe\xf4\xd1\xa8Z\xf4b\xc4\xaf\xe8\xb7\xf0\xfb\xc7\xc52&\xc0\xc0\xb3\x91L@\x8b\x0dc\x0b\x9a\xfa\x80\x9al\x0b&H\xac\x80\x8e\xc5Y9\xb8\xbf\xfa0\xa4\xbd\xba\x1dQ)|\x19\xd9\xaf\xd1\xa1W\x12e\xf9d|\x07\x8d5\xe2\xdd(\x97s\x8a\xc5\x03>\xcc \x90T\xb2\x05\xe5\x11T\xdbb\x0bL\x03\xc5\xfb\x0f\xefo\x99\xbb\xbbD\xb2\xcc\x8aU\xef\x1a\x96\xfe\x16\x13\xf8'\x03\x00h\xc8\x05\x16r-\xd4\xe7\xf8\xce\x13\x13o;,\xc5>\x8f\xf8oB\xa0\x86C\x86\x07j\xb7\xfb,\x87L\xa9\xff5H\xbfc_\x8bI\n,?\xb3\x82\"\x02\x8e\xfd\xe4\xc7\xda\x842 \x0f\xccnc\xaf\x8a\xfcx>\xf9,\xc5>\n\xb7\x1b\xff\xa5*\x0f#\xa9\xc1R\xa3\xec\x82\xc9\x8bl\xd6\xc8\xef\x187E>\xcaa\x84\xe6g\xf4F\xc3\xff\xa6x9\xeb|v\xa0\xf2\"]\x8f\xdc{\x17\xa7R6\xca\xdaB\xea\x9fA\xech\xc2\xef\xe6\xd9n\"\xad\xcb\xc6\x86\x1fut\xa0\x96\xaa\xde(n4\xd4\xd0R\xd3o\xd0q\x99T^\xc6\xefEU\\\xbf\xf9\xf2\xef\xfc\xcf0\x9d\x9c\xecB]|\xfa2\xff<\x9dTC\xc2\x81Rt\x82kh\xb1\xa1\x16\xff\xb8{wC\x9b\x8e\x1c:.\xa2\xe9\xd3\x0f\x9f'\xf0\x06\xf2|\x9e\xed\xb2\xac\x9afSxK\"\xc8\xd3EM\xfb\xf2\xa0\x9a\xc7\xbd\xad\xe9\xbd\x17\x8d\xcb\x1a\x83Z1\xfa\x04/\xb3iu\xa8\xd1ck<6\xbc\xa4\xf7\xb2\xf4\xa3\x8a\xc7\xdad\xacQ\xe05\xe4\xc9_\xf3\xc6^;|\xe6:\x87W\x80\xee\x82\xf7\x85o\xd9)\xd6Nm\x10^]\x06.\xb5\nzr\xa8\xeeFY\x0b\xe4\xf6w\xeb\xc9\xb0\xde\xd7\xba\xc6V\x16\xbe\x0f\xe8g@>\xd9\\o\xad\xe8\x9c5z\x04\x13\xc0Q6\x850\xb8\x93\x07\xd5\xb3F\xc7f\xa8\xc3\x04hMP\x0f\x16\xdb\xd3V\xac\x91\x87\xa4\xc5\x98~\xdf\x8e4\xf8g\xed\xa1\x06\x87OgK\xbb\xdf\xb8g\xedK\xea\xd0\x15\xf9\xdb\x9f\x97\xf9\x0c\xf2JrW\x03\x97\xbd\xf0#\xcaYR\xb2\xdd\xc5\x04\xea\x9f\x0e\xe5\x16b\x0c\xac\xb8\x0fP\xd75\xfc\xf8\xfa5\\\xc3\xaf\x8b\x8f\xbf\x95\x9d\xf2\x01\xa3\xddc\xe8\xc8\x05\\\xe23\x8b6\xa4\x03'\xb1\xd1{\xf2/\x04?\x03\xca6\x08\xf5\xdd\x91\xe6-\xad\xa9\xe7\xe2{\xeb\x96\x13~(<\x05\xbb\xac;\x1d\xb63\xe9\xbd\xc8+\xea}\x11_\x00\x19\xf0\xd1\xc1\xa1U\xfc\xb0\"k\xe9I\x9e\xb3\xe1\xe1\x08q\x11<\xc6\xfe\nB\xf4\x7f:\xe8\xf4\xa2\x8c\x0fEa\xc6\xd3sr\xc3\xc27n\xd8\x0c\xcc\xfe\xd0\\\n:%/&\xf3l\x97\xfd7\x00PK\x07\x08\xdf\xc6\x03b/\x03\x00\x00\x82\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd4\x9bR]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x00resources/js/login.jsUT\x05\x00\x01a\x1e\xd5j\x9cT\xddN\xdc<\x10\xbd\xcfS\x0c78\xd1\xc7\xe7\xa0^\xb2\x8a\x90JQ\xa1\xdd\x02\xe2G\xed\xad\x1bO6\x86d\x9c\xda\x13\xd8U\xd9\xb7\xe9\x9b\xf4\xc5*\xe7g\x7f\x8a\x96J\xe4j\xb3>g|\xe6\xcc\x99\xa4)L\xed\xcc\x10\x14\xd6\xd5`\x0b\xa8\xc2\x9b,\xb9\xaed\x14\xa5)\\R\xb5\x00\x87\xda8\xcc\x19\x9e\x0c\x97\x86\x80K\xe3\xc1\x1bF\x19\xe5\x96<\x03\xe1\x9c!\x03\xc2'\xb8\xbb\x9e\xde\xa0ryy\xa5\x9c\xaa}\xacm\xde\xd6H,+\x9b+6\x96\xa4G\xe5\xf22\x913\xe4X\x10\xceY$\xf0\xfc\x0c\"\x15\x93\xa1\x9cW\x05^\x8c%\xe7,=+\xc7\xfe\xab\xe12\x16\xa9H`\x7f\x1f\xf6^\x1e\x84\x93\xe3^\xcaQ_mP\x87\xceY\xf76y\x1dU$\x93\xc8\x14\x10w/	\xfc\x8c\x00\x00V\xc4\x19\xf2i\x85\xa1\xc6\xfb\xc5\xb9^Q$\xe3\x9cO,1R\xb0F|F\xc7\xea\xc1\xb8{\xd5r[\x1bB\x02l~\xff\xb2D\xc6sk\x8e@\xc0\x7f\xbd\xd2I\xb4\x1c\x957\xce>\x1a\x8d\xce\x0f\xea\xbf}\x99\x9e17\xd7\xf8\xa3E\xcfq2\x89V\x08i\x1b\xa4X|<\xbd\x15\x07 R\xd5r\x99\xae\x0e\xc56\x92*\xab4dP\xb4\x94\x87\x9e!\x1e\xbb\nm\xaeKzV\xdcz\xc8\xb2\x0c\xde\x1d\x1e\x06\xdb?\xdd\\^\xc8F9\x8f\x1b0\x87\xbe\xb1\xe4\xf1\x16\xe7\x9cHkt>\x96\x0bO?\x83\xca\xd0\x03d\xbb]\x0b\xac\xa0r uxY:,\x82w}7\x01\x92v\xf9<\x0eC\xce:\xc3(\xb7\x1a\xef\xae\xcfOl\xddXB\xe2x\x0c\xcf\xdf\xc5</*\x94\xda\xf8\xa6R\x8bPU\xf4\x80e\xb4\xdc4\xc7#\xe9`l\xb4Sj'A$Ri}\xfa\x88\xc4S\xe3\x19	],|\xfb\xbd6,\x0e6\x8c\xc5\xd1\n\x94\x8d\xc3\x00\xff\x80\x85j\xabnxk{\xe6\xa5\xdb9\xe2\x80\x9a\x97n\x18\xf0\xd5\xe5\xcd\xc6\x84\x07-k\x90G\x1e\xa8g\xa8t\x105d\xf0\xff\xdbE\x83\x81\xa8\x9a\xa62\xfd*\xa6\xf7\xden\xb1_I\xc6\x98\x8e\x00\x1br\xb1\xd7\xe7bs\xd8o\xda\x8c\x7f,\xc5F\xe6\xc2\xe5\xdbi\xab\xfdl=\xe7\xf08\xe4\xd6\xd1\xfa\xbf\xe5\xea\xd7\x8bU\x87l\xf5\xa5\x19\xa2\xb0\xe9#\xe9\xb8\xbb\xd9\xb3343\xc5\"^\xb7\xd9zt\xa4j<\xda\x1d\xe8\x11\"\x12\xf9\xa8\xaa\x16\x0fV\xe4Fy\xffd\x9d~\x85<B\xb6\xc9\xcb$\x99D\xcbd\x12\xfd\x19\x00PK\x07\x08\x8e%\xc6\xeem\x02\x00\x00\xb6\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xf0\x96R]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x18\x00	\x00resources/js/mymonies.jsUT\x05\x00\x01$\x16\xd5j\xbc9m\x8f\xdb6\xd2\xdf\xf5+\xe6Q\x8aJNm\xb9}>z\xedm{E\x8a\x16M\xef\x80f\xdb; \x08\x1aZ\x1a[\xbcH\xa4\x8e\xa4\xbc1v\xfd\xdf\x0fCR\xefrv\x83;\x1c?\xecZ\xe4p\xdeg8\x1c\xdes\x91\xc9\xfbD\x8aB\xb2\x0cv\xc0\x0577AP\xa0\x01VU7A\xb0^\xc3\x9d4\xac\xd0\xc0\x14B*\xc5	\x95\xc1\x0c\x8c\x04\x93#\xec\x99FHk\xa5P\xa4g\xa85\x17G\xc0\x8fi\xce\xc4\x11A1\x83:	R)\xb4\xb1\x90?4\x80;\x88^\xfd\xfe[t\x13\x04\x87Z\xa4\x86Ka)\xc7\x0bx\x08\x00\x80h\xc3\x0e\x04\xde\xc3\x1f5\xc6n\x8e\x06\x16\x1b\x88^\xb0\xaa\x8a\x96\xed\\\xc6\x0c\xdb\xf8}\xcd(e\xc6\x8a;\xc5\x84f\x16\xfb\x06\x0e\xac\xd0\xd8m\xa2\xc1\xd2T\xd6\xc2l \x8af\x17\xf4\x06\xde\xbe\x1b\xae\x18v\xd4\x1bx\xb8\x8cf\xad\x82f\xa0;\x06fVk\x8dj\x03\xa2.\x8a\xe1|.k\x8d\xb9,\xb2\x99=\xed\xda\x06\n\x99\xb2\xe2\x8d\x91\x8a\x1d19\xa2\xf9\xd9`\x19G\xe5\xb9\x94\x82\xa3\xfe\xb3\x85\x8c\x16\xf0\xf88\x10\xb1\xc7}\x89&\x97\x99\x1e\xab\xcf\xb0\xe3_Y\x89\x1bh\xad\x13\xf3l1\x02\xa2q\x90\nbr\x16\xc1J\x04.\xc0\xe4\\'\xa4\xa59h\x1a\xfc\x00q\x0b\xf4\x96\xb6\xbd\x83\xddn\x07\xf3\xf8\x9b\xa1\xd0\xd4JX*7\xb3@\x97\xe0\xe9\x19\x8f$\x8an\x82\xf9\xad=\xc5\xdc3\x93\xe6c\xafj\x1d\xa6S\xcb\x1c\xd3u\x951\x83=\xef\xd31\xab\xaa\xc4\xef^$)\xe1\x8e\xa5\xf8G\xae~d\xbcX\\e\xc7\xfe\xba,n\x02\xfbc\xbd\x86\x1f\n\xa9\xd197H\x01\xa8SV!|\xc0s\x02w9\xd7\x902\x11\x19\xd8#\xece-2\xb2G\xc6O\xa0%E\x91_\xcc\x99\xc8\n\x04n\x12\x8b4\x93i]\xa20	\xcb\xb2W'\x14\xe65\xd7\x06\x05\xaa8\xfc\x80\xe7\xba\n\x97\x9d\xb0R\xfc\x82\xe7\xdf+\x88\xb1/5\x19\x14\x93\x0fx\xb6f\x0c_Y\x9e\xc2\xb1^H\x01\xe3\xa0\x84\x9d\x8b\xca9\x81\xed\xdc\x11\xcd\x1b\xd4\x9aK\x11\xc7\xda\xfdX\xc0\xee\xd6\"\xa3\xe8\x81\x1d4\xd3^G\xbf6\xee_pm\xba\x18\xd0q\x18.)l\xe1(\xcdO\xed\xec\x12\xc6Fhe\x1d\xc0\xc5\n\x07\xfeL\xf4;\xdc\xb0\x03\x85\xba?\xf1\xf8\x08o\xdfuV%\x0d\xfd\xdfpO\xa2e\x89q\x9c[q\xf2\x84gVy\x03\x98\x05|\xf9\xe5\x88RR\xa08\x9a\x1cn\xe1\xeb>;>av\x1c\xc0\x08\x95~\xfb\xf5\xbb\x84g7c\xcf\x9a\xd3\x19\xc5n_[w\xec\xf8\x84\x9e\x08b\xac!J\xf9\xb2\xc0\xa4\x90GZr\x19\xa1\xa3\xdfL%\x07\xa9^\xb14\x8fc\xd3\x1a\x96@\xdf\x9a\xc4g\x060	\xcf\x167\xb3\x88\x1b\xe8\xc5M_\x1c\x17}\xdf\xbb`\xd3\xb1_\xf41i\x13u3\xa7\xeb\xbdN\x15\xdf\xa3u|;}	\x82\xe0\x8f\x1a\x93T\x96\x95\x14(L\x1c\x19\xb6\xd7\xd1\xd2\xcbf\xb0\xac\nfp\x03\xef[a\xb6\x14c<\xdb\x85\x04\x19BZ0\xadwa*\x85a\\\xa0Z\x1d\x8a\x9ag\xe1m\x0bOc+\xd8\xa9\x81\x14\xec\xb4g\n\xdc\xbf\x15~\xac\x98\xc8\x9a\xaf\x82\x1fs\x03\xfb\xa3\xfb1BBc\xcb\x86hV{\xc5D\x16B\xae\xf0\xb0\x0b_\x84\xf0]Z\xf0\xf4\xc3.\xd4X`j\xee\xd8>\x8e\xa2Ex\xdb\x04\xcav\xcdn\x83)\xd6\xba\x18\xa1%~K\xb5b\xb5\x913\\\xd0\xd8\x16\xbc\xb7g\xc5\x0d\x96@\x87\xef	C8\xad\x0eRY\x05QNrz:\xad\xf8\xc1N%\x13\xed\\\x15pUp\xf1!\x84\x8d\x93\xce\xb0}B\xbf\xe6\x84\xa45\x9e-\xc2\xdb\x87\x07\xa2g\xdd	.\x17+n\x87\xbc\x1b\xdbu\xc1\xa7rm\xd7u13\xebt\xd9\xf0u\x90\xaa\\\x91\xb9\x95,\xa0\xff\xb1\xd2%\xe9\xec\xffC\xd0\xe6\\\xe0.\xbc\xe7\x99\xc97`\x958\xc1\xda\x0c\xa7\x97/\x94\x94f6\xf4\xbf	asbE\x8d\x13\xa0\xeb8\xbfs\x05\xd9.\x94\xe2\x8d\xe5\xbd\xcd\x81\xf1\x17H\xce\x9f\x18\xa6\x8eh\x12\x8bxq\xcd\xc2\xb2\xb2i\xdb[3'[\x8eX\xd0\x1ds\x94\xd5\xac\xfa\xf3N\xf9\x0e\xc1\x14\xfbv\xedT:\xb3\xa2+&z\x1e@!b\xf0\xa3	\x07z\xa2\xc3\xe0\n\xd3\x0f\x0f\x9eG\x82\xb1\x7f<7\xb3\xd0\xdb}m\x8cl	\xee\x8d\x80\xbd\x11dH\xfa'kSp\x81+\x8d\xa9\x14\x19S\xe7\xce\xf3\xa4x-\x8f\xb26\xe1\xed/\\\xfd\x93\xd5Y\x0du!\xf5v\xed\x10Ny\xdb\xaeI\xb2\xe1\xfcv-\xd8i4\xd5O.\xab\x0c\x0d\xe3\x85\x9e\x11u\xab\x0bin\xb7k\xfbo\x88a\x9d\xf1\x1e\xd2\xd1\xe7\xfbep\xa5\x0e\xec\x12\xc6\xb44\xf3\x05\xdc\xbe\x9f\xc0\xd9\xde\xa6\xf0\xe1\xb1D\x83\xc2\x8f\xeb\xefm*\x80\x1d\xf8\xd0l\x8a\xbe.\xb57g\xffL5\xd6hwRn\x15~\xfa\xca\xae\xb1\xb3O%\xd1S\x88\x1e\xae\xa0\xc7\x07]3\x06\xf4}9i\xf3\x8b-\xd5\xe1\xe2\x0f\"\xc7x\xaa\x90\x19\xcc\x06{Z\xc5\xc1\xce\xfd\xfe\"\xcdy\x91)\x14\x83\xad%\x15\x8as[[\xf0N\xf18\xa3v\xaa8\xda\xca\x8e.\n\x14\xb7I\xcetn\xd5\x8e6m\xb6b6\xe3\xf1\x11b\xf8\xc4\xb6(\xa2\x92\xc4m\xb6x\xa2\x17\xd1\xa2/]3\xb0oo\xa3\xeaQ\xcd\xde\xabp\xbd\xb2/\xcb\x80~\xce\x1c\xbdO\x9f\xbc\xa7\x95\xce\xe5\xfd.lH\x8e\x82c\x9b\x7fCI\xa8MA\xf97\xb7\xc1\xd3\x81\xd3\x8b\x12\x1f!\x95\x92\xd5\xe0\x9e\xc4\xb3\x0d<\x80\xc2\x7f\xd5\\a\xb6\xb1r6\xf6\xa3A\x14\xafC<\xe9T\x8d8\xfe\xe6:\xf6-YV\xb5!\xb2\xddV\xb2\xe9\x00W\x0f_\xf4\"\x82\xaf\x9c\xc7\xcd\xd4\x81W\xb4\xdf]`VT\x1a>i\x8a\xe7+\xf6\xfd<E\x17\x8c+\x7fQ\xfa4=\x7f\x06\x9fV\xa5\xcc\xb0h\x8e~\x1c\x97\x12\xfe\xcc\xba]\xad\xe6O\x9f\xd1\x99\xc6\xe8L\xf3\xf4{g\x19KD]\xeeQ\xd9\xf3\xac\xf9\x80\xcb\x05b\xfb\x9d6m\x8d\xcbe1%3<\xdc\xbc;M\xee\x97\x0d\xff@&\xf4\xc1\x8e%7q\xe4J\xd7M\x03\x10-\xddj\xf3\xbd\xe8\xac\xd8tCf=j&\xeda\xb6\x81p\xb5\xea\xea\x86\xa1\x93M\\\xbeQ\xcc\x9c[\xdb\x1d\x97Y\xb3\x1av\xfc\xaf\xd8rX\x83\xec\xb9\xc8\x9aZ\xc3\xdd\x19\xd8\xf1][j\xc6<[R\x1bcA\x06\xa5e_	\x1eg\xeb\x90\xd6D\xd7\x82\xbdQ\xf6\xb0\xf7\xe3\xbbAW\x92\xc0\xbc2\xec%\xf8\xd3\xea\xb0\x81\xc7\xad[R\x12\xd9\x85v\xcf\xd8\xb1)\xf7\xf92\xc5\xae\xafJ\xa6?t\x05\x89w\x9e\x94:\x06\xd1\\E7Ep\xafXU]-\xa4\xa6\xf0\xed\x15\xa7\xa1\x9ah#\xab\x99\xeb\xc4<\xbf9\xb2\xec*\xb565{\x15<	L\xe3\x8eU,7u\xc9\x04\x18\x8e\x994W\xa1\xe7\n\xa5Q\xee\x7f\xa6\x12\xf62;?S\x86'@idx`ua\x80@\xff\x07\xcc\x1f\xa44\xcf6\xc1\xd3\xc0\xc3\xe2\xd9\x91\xf0\x12\xad\xdc\xdag\xf8g\x7f\xfc\xed\x97\xebD\xafV\xd8\xff\xb1\xad\xbb\x1a\xe0\xf3\xa7\xb7\xeb.\x8ao\xdf?u\xbe\xba\xba~&+D/z\xa7\xb0\x83Z5=\x88\xc8a\x0d\xd6/\x83\x97\xf0\x1bV\x05K\xd1\xf6\xe4\xefsY T\xec\x88p\xcfM\x0et_\x82\xf0\xc0x\xe1\xfa\xf6\xd4\xea\x0f\x97 \x15\x1ce\xd3\xc7/\xe4\x91\x0b\xbb'xI\xe5#\xe1\xf1\xbd5\xc8\x99\x06\xfcXQ\x99\x93\x04/\xd7]\xcb\xbe\xed\x9e\xc5\xa8Ts\xe6P\xed\x89J\xd9RQ\xa9$\x95\x19\xbab\xb1\x16\xac69\n\xc3S\xaa\x8b\xa3\xe1!\x95qE\xd7\x0d\xf9\x9a\x18\xe9\x17\xf4\xae\"\xf2\x07S\xd3\x08\xbd\xcb\xb1;1\xdb\x0b0\x94\xecL\xdd\xcf\x83\x92%0!M\x8e\xcaI\x96|\x9a\xb5\nU\xc9m\x7f\xf1\xcf\x0c\x05\xc7\xccV\xba\xcf\xef\xb0\xf7E\x19\xecRX\xca\x13^o\xcdwbN\xebn\x85d\xa8O\xab\x82Z\xef\x94+`\xd7!8\xa2yU 5u\xf5_\xcew\xae\x93\x1f\xbb\xe4\xb3x\xfb\xb5\xefK\xd2g\xc2\x85@\xf5\xd3\xdd\xaf\xaf\xe9=\x86\n\xe5\x1f\x07>\xe2_V\xa8r$\xad}\x05\x91-\x9f#\xdb&k\x9d`\xdco\xf3\xaa\x18vb\x9b\xca\xa1\xed,RW\xb0m\xf95\xab\xbe\x93\xda|\x0e\xdb\x8eS\x9aM7\xcfS\\\xaf\xe1\xef\xdc\xe4\xb26\xa3\x17(j\xca\x00\x9eP\x9d\xdb\x97\xaa%\x10[`,\x06\xa8P\xb5+\xc9\x0c\xf7\x0e\xcc\xf1~\xe0\x85\xa1w\x1bj&\xd3\x93\xd6\x9f\xcd\xc6\xcd\xe0\x85\xcb7O\xed\xc6^\x0b}P\x99=\x87\xc8\x10O_\x1f\xfd\xebr\x8b\xbf\x85\x9dkY{i\x9d\x92\xfdG\xafU}	.m2\xb1\xe6\xa7\x1c@.\x00\xf79\n\xe0\x06\x9cR5==\xd0\x9aFuB\xb5\x04L\x8e\x89\x83\xa1\xae\xb6\x14\x08H]\xfd\x97\xb6>\x1a<\x82%\xf0\xfd\xc1\xa0\x02\x06\x8aZ(\x82\xaa>\xdb\x7f\xd26tsvB\xd8#\n\xa0h\xc4lIO\x17&\xa7\x94d\xf9\xe0\x1a\\P`\x06L\xc3=\x16\xc50!M:\xba^\x03\xd456\xbd<\xb1\xfb\x8c\xd8\xbe\xe9a\xf0\xbc\xba\x97IK\xe3\x8d\xacU\x8aq\xb4vK\x14+qG\xe7[\x88\xbem\xbfv\xb4\x88\x82\x12\xe2\xef\xbf\xfd\xfcC{\x10\xb4\x00\x0b\xa0\xa7\xc8\x85\xa7H\xa1\xedu\x84Y\xf3T\xe2\x96\x1c\xadD\nY\xa1}E\x99u/J\xc4-\x82\xbe\xdf\xd1\x98&\x97\xeeV\xde\xa7\xda\xdd\xde/\x03\xda\x93\xe7\xa2\x88\x97\x95Tti\x8bG\x0d\x89\xf9\x86\xfc\x94\x89\xcbb \xdd\x94\x82\xbbG8\xce\x9f\x82\xad\x981\xa8D\x1f~\xf8p\xd1(\xa0\xa7\x97\xb97\x82F\x91\xfd\x07\xbc\x91*\xfd\xb6\x9e\x9b?\xf7\xb9\xaf\xbd4\x05\x01\xd3g\x91vq<\x87sH}\x94<\xfa\xd4m\niYls\xc9\x80i\x8fm\xd3\xfch\x17}\x83\x81R4\xc5\\\xf7,\xd3\xa3@\xa7\x0d3l07I\xd6\xff\x1e\x00PK\x07\x08\x87`\x17\xf7\xcd	\x00\x00Z \x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00!\x91R]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00resources/js/rpc/mymonies/service_twirp.d.tsUT\x05\x00\x01>\x0b\xd5j\xb4VM\x8b\xe46\x10\xbd\xfbW\xd4m>hf\xee\x1e\x92\x1cB\x06\x02Y\x08\x9b\xb9\x84e1\xd5R\xd9-bI\x9e\x92\xec]\xb3\xf4\xfe\xf6 \x8d\xba-\xbb\xed\xf4a's\x19\\\xf5\xf4\xea\xa9\xf4T\xea\x82\xbev\x96=\xd4\xbd\x11^Y\x03\x1fFm\x8d\"W\xa1\x94U\x87\xde\x13\x9b[G<\x10\x87\x10\x93s%8\xcf\xca4;\xc80\x15\xd3k	h\xc6\x1dX\xf3W/D\x04>'\xda\x10\xfc\x8d\xd9r	\xf1\xdf\xaf\xd8\xb6{\x14\xff\xdc\x950X%\x9f\xb6e\xb4\xca\xf9\n\x85\xb0\xbd\xf1nS\xc8\x0c\xf5\xbfJ\xf1\xd8\\\x91\x11\x10\xeb\x12n\x99\\	\x7f(\xe7_\xb0q\x1f\xc9u\xd68\xba\x83\x9f~\x8e]\xf8\xc1&y\xeb\xb1\xbd\xa6-b\xde\xd4E\x1d\xf1\xfb#\xbd\xf6\xe4\xfc\x96\xd6\x84yg\xb5\x8c\xc6a\xf4\xdc5\xcd\x192S>E\xaf\xc8\xcf\x81\xef\xb6\x87\xbe\x93\xe8)\x9c\xf5\xa6\xf8	\xf2\x83\x86,\x1e\xef\xbf\xc3\xdf\xb6\x07\x81\x06$\x89\x16\x99\xc0\x8f\x1d9\xf0\x07\xf4\x10>q@\xd5\xe2\xbe%\x18\x14\x82\xd2\xe16)\xd3\x80?\x10h+\xfb\x96\xe0\xfe\xb1P\xc6\x13\xd7(h~\x0b\xe1[\x01\x00pK%\xf8/\x8a\xbb\x98<_\xcdc\x91\xad\x9b\xf2i\x91\xb0\x92N\x03\xe1)\xd2h\xd7,\x02\xe4\xb1L\xf0\xf0'\xb0w\x8b5\xc7P%\xcd\x80\xa9\xd8\xc5\xf1E\x9b&\xa6Z\xb5\x9e\xb8\x84\xec|\x9fch\xa1x\xc3\x03\x89%7\xd7\x8c\xeb\xd3\xe7\x05O\x96Kk\x95\x9co\"\xe3\xaa\x82;\xe6\xd9\x01\xdb\x9eV\xe2\x1d\x8e\x9a\x8c_\xc9\xa0\x0eC\xef\x02MTu8\x12\xcf\x13i\xf8\xcd\xd1{%6%\xce\x13L51\x19\xb1\x10\x1d\x0bU\x1bIM\xcea\xb3\x08\ndY\x99^\xef\x97\x02=6\xd5\xb2co>\xbd\x08\x8b\x9e\x83\x96q\x02o\xba#\x9f_\xd7\x8d\x11\xd2{tT\x9d*\xfc2+\xb1\xce=\xf7K\x0c\x96\x10\x0b_z$D\x93\x0cm\x8d?L\xf4[-\xb8\xdc\xeb\xd6\xd1\xa7\xf3}\xeb\xedzK2\x8f\xbe\xdd\x85\xa4E\xc9l\xa3\x99[\x16\xd1(y\x11{\xed\x89\x17mZ\xbf\xa7\xd9\x8b\x96\xaa\x86\x87\xb0\x84\x17l>}^_\xf7\x82\xcdY\xe0\xbc\xaaA\x9d\xf9\xea\xb8>\x02;\xb6\x1d\xb1W\xe4\xc0\xd6\xf9\xa4\xeb]\x18}\xc2\x9a\xf0\xa6\xb5\x14^\x06\x86\x019\x8c\xc0$\"&A\x8f\xcf\x8aZ955\xd6\xf9=\x92\x85\xa1z\x9a\xb3\xbb\xacV$\xd3\xe4\x0fV:P\xc6)I \xad\xf7$\xa3jW\xc0\xfd\xf7\xb9\x9e\xdd4\xb4\x0f\xa4Ok\x10n\"\xbeCA7\x0fE\xa6\xed\x1c\x06\xd7\xef\xffd\xdb\xa5&\x05m\xcf\x96\x81\xbe\xa2\xee\x02m\xa3\x062\xe0\x0f\xca\x81\xa4Z\x19\x15\xee\xf5\x0e\x9c\xd5d\x0d\x05\xc3\xb4\x12\xbe\xb0\xf2T\xc6\xae\x06e\xa7K\x07\xdf\xce\xecG\xa8\xd9j\xb8\x19m\xcf\x1f\xa2\xe2\x9b\xa7\x0c\x9f`\x0f\xb5\xb5\xb7wS\xc2\xf2%\xe7=\xa0\x83D\xf3\xdf\xa4	\xf4\xb0J\xfe\x18\x99\x97?	c\xfd\x12\x06\xab\xe4Sq,\xfe\x1d\x00PK\x07\x08\xbf\xfd\x9cp\xfe\x02\x00\x00\xba\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x15\x98R]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00resources/js/rpc/mymonies/service_twirp.jsUT\x05\x00\x01Z\x17\xd5j\xcc\x96Oo\xe36\x10\xc5\xef\xfa\x14S\x9dl\xd4\x91\xd2b{\xa9\xe1C\x9a\x0d\xe0\x16\xc9&\x88\x15\xa07\x81\x96\xc6\x92b\x9aT\xc9a\x12\xa3\x9b\xef^\x90\x92\xd7^\xff\x91i\x14X'\x87@\x10\xdf<\xbe\xf9e\xc8(\x8e\xe1Z\xe6\x08\x05\nT\x8c0\x87\xe9\x12j%If\x17\x05\x8a\x0bz\xadT\x9dN\x95|\xd5\xa8\x9e5\xbc\\F\x97\xd1/\x03\xf8|\x0f_\xee\x13\xb8\xf9\xfcg\x12\x05q\x0cZ\x1a\x95\xe1\xef\xa0Q\xbdT\x19F\xce\"\xb0+\xa9\xc2\x7f\x0cj\x02bs\xd4@%\xc28I\x1e`\x81T\xca|\x00O\x8f\xb7P3*\xa1Ge\xa5\xe1\xb5\xe2\x1c\x8c6\x8c\xf3%dR\x10\xab\x84+\xca\xe5\xc2>\n\xb6\xc0\xbe\xf5}\xd6R\xc0T\xe6K\xa0\x92QS7E\xd0(\x08H\xba}l\x18T\x03`\x901\xce\xa7,\x9b\x83\x14\xa0M\x96\xa1\xd63\xc3\xa1\x8d\xa6\x81\x89\x1c\x98u\xfd&\x9cI\xb5^v;\xa0RR\x814\x14\x05/L\xad\xfb\x1a\xc1\xcc\x88\x8c*)z\xab\x9el?\x03\x17n\x00RL\x9a\x0d\xed\xe3\x8d\xf5\xe8\xc3\xbf\x01\x80\xf5x+\x15\x8c@\xe0+\xfc}w;&\xaa\x1f\x1b\xcf^\x7f\x18\x80]\x8dd\x8d\xdb\xb6\xa4\x0c~[\xd7Hm\xcd\x18Y\x8e\xaa\x17^e\x19\xd6\x14\x0eBV\xd7\xbc\xca\x98\x0d\x16[XaG\xd1\xb5\x14\x84\x82.\x92e\x8d\x07J\xdbZ)\x14\xb2|\xa9\x89\x11f%\x13\x05n\x00\x80\x1e6\xcd\x01T3\xe8Y\xbdSO\xac\x1aF#\xf8\xb4Z^\x0b\xac\x93\xd1v\xf1\xd7\xcbO\xf0\xf5+l\xbf\xfcm]\x03k\x9a\x0d\"\xfb\xf3\x0e\xc85\xee\xf5\xbb\xdc,\xb5\xc0_\x1876\xf0_\x93\xfb/Q\xcd\x94\xc66\xa4\xae\xa5\xd0\x98\xe0\x1b\xf5\x87{6su\xdb;\xfe?k7\n\xdb\xc6\xc1\xea\xf7\xbb\x03n{\xb2S\x04?\x8d@\x18\xceW\xedXc\x8d\"\xef\xb9>4\xa9J\x14\xd5l\xe9\xb4}\xe7\xd6B\xd9\x92;\x0f\xb7\x1c\xd8\x0d\xe2\xb8=\x85\xda\x8d\xfb\xddr!E\x85\xfa\x9aW((pS\xbez\x97\xb2<O\xf1\xad\xf9\x8b\xa7\xf6\xa6\xd0\x9b\x83\xdf\x9c4+Rn\xd2w\xd5\xf6\xb8t\x1f\x86\x99\xe1<m\xf2\xc0\x08\xbew\x84\x9f!\x8c\xdd]\x14\x87\xf69\x93\x8b\xa8\xa8\xa84\xd3\xe8Y\n\xd4s)\xa3E\x1b5Zev\xcaF\x7f\x95\xe77m\x9cG\x9b=\xb4\x10V'\xb8\x17>\xdcO\x92p\xb0\x99\xe0\xa4\x16\x86\x96\xe6\x0e\xad\x9a\x11\xa1\x12\xc70\xb5\xb2\xb3\xf3yhrx\x929\x96z?\x12b\xc51\x1c\xc4\x8a\xb3\xa3HX\xe1\x89\xa1+\xed.\x82\xa9\xe1\xf3\xd4\xd49#<\x86bKz^$\x7f\x18>\x7frY\xfc\xc8\xf8\x86\xdf%\xc4+M)\xcb2i\x04u^0\xdf	\xcfK\xe7\xb6\xd2t\xd5F\xf6\x18\x1b\xbf\xe4\x07\xd0\x94\xd2h,%\xcf\xd3\x05.\xa6\xa8\x8e3\xda\xa98?\xac\xf1*\xd2]\xd3\x83/4\xffV\x8e\xd1;\x01\xdbG\xe2u:\xa9\x93\x11\xd9\xe3ZV\x9a\xa4Z\x1ee\xb4\xa1=?\xa4\x84\x15\xe3&\xb7/$\x9f\xf8\x87)\x1d\x1f!b\xc5\x07\x18\x9e\x84\x15\xdec\xd3\x99\xf8\x10\nI\x8c{\xc0p\xb2\x0f\x80\xc3\xe5\xf0\x06\xd2\x9d\xfa\x10\x12\xc5\x84f\xee\xe0x\x80\xd9\x10\x7f\x00<\x1bi\xbc!\xf9t\xb0\x8bJ\xe3\xee\x8d\xdeEk\x9f\xfe\xbc\xc0&\xb8\xfd\xaf\xcc\x03\xd9Im\xecR3\"\x97]\x94\xec\xfay\xa9<\x89\\zp\xe8\x0c\xba\xa7o\xaf\xaff\x8fo\xce\x1fr\xf1\x9e\xf2\xb1\xec\x91y\x18\xbc\x0f\x83\xff\x06\x00PK\x07\x08\"'\xbf	\x7f\x03\x00\x00\xaa\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xc8\xa4iL\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00robots.txtUT\x05\x00\x01\xb9\xf0\xa2Z\x00\x1a\x00\xe5\xffUser-agent: *\nDisallow: /\n\x03\x00PK\x07\x08B\x84\xa4\x8f!\x00\x00\x00\x1a\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x18\x94R]\x8c\xa1N\xdd\x8a\x07\x00\x00\xb4\x16\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x00index.htmlUT\x05\x00\x01\xd1\x10\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd2\x9bR]\xb8\xb9\x87\x15B\x02\x00\x00q\x04\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcb\x07\x00\x00login.htmlUT\x05\x00\x01]\x1e\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xc8\xa4iL\xed\xce\xc9\xea\x19\x02\x00\x00\n\x04\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81N\n\x00\x00resources/css/mymonies.cssUT\x05\x00\x01\xb9\xf0\xa2ZPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xc6\x94R]\xdf\xc6\x03b/\x03\x00\x00\x82\x07\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8\x0c\x00\x00resources/js/auth.jsUT\x05\x00\x01\x14\x12\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd4\x9bR]\x8e%\xc6\xeem\x02\x00\x00\xb6\x05\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x812\x10\x00\x00resources/js/login.jsUT\x05\x00\x01a\x1e\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xf0\x96R]\x87`\x17\xf7\xcd	\x00\x00Z \x00\x00\x18\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xeb\x12\x00\x00resources/js/mymonies.jsUT\x05\x00\x01$\x16\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00!\x91R]\xbf\xfd\x9cp\xfe\x02\x00\x00\xba\n\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x07\x1d\x00\x00resources/js/rpc/mymonies/service_twirp.d.tsUT\x05\x00\x01>\x0b\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x15\x98R]\"'\xbf	\x7f\x03\x00\x00\xaa\x13\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81h \x00\x00resources/js/rpc/mymonies/service_twirp.jsUT\x05\x00\x01Z\x17\xd5jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xc8\xa4iLB\x84\xa4\x8f!\x00\x00\x00\x1a\x00\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81H$\x00\x00robots.txtUT\x05\x00\x01\xb9\xf0\xa2ZPK\x05\x06\x00\x00\x00\x00	\x00	\x00\xbe\x02\x00\x00\xaa$\x00\x00\x00\x00"
 	fs.Register(data)
 }
@@ -0,0 +1,91 @@
+// Package tlscert serves TLS certificates from files and reloads them when
+// the files change, e.g. when certbot renews the certificate, without
+// restarting the server.
+package tlscert
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckInterval is how often the files are checked for changes, at most.
+const CheckInterval = 10 * time.Second
+
+// Reloader holds a certificate loaded from a certificate file and a key
+// file, and reloads it when either file changes. If reloading fails, e.g.
+// when only one of the files has been replaced yet, the previous
+// certificate is kept.
+type Reloader struct {
+	certFile, keyFile string
+	logger            *slog.Logger
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time // latest modification time of the files of cert
+	checked time.Time
+}
+
+// NewReloader returns a reloader of the PEM encoded certificate chain and
+// private key of certFile and keyFile. Reloads are logged to logger.
+func NewReloader(certFile, keyFile string, logger *slog.Logger) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, logger: logger}
+	modTime, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the current certificate. It is a
+// tls.Config.GetCertificate function.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) >= CheckInterval {
+		r.checked = time.Now()
+		modTime, err := r.stat()
+		if err != nil {
+			r.logger.Error("failed to check TLS certificate", "error", err)
+		} else if !modTime.Equal(r.modTime) {
+			if err := r.load(modTime); err != nil {
+				r.logger.Error("failed to reload TLS certificate, keeping the previous one", "error", err)
+			} else {
+				r.logger.Info("reloaded TLS certificate", "file", r.certFile)
+			}
+		}
+	}
+	return r.cert, nil
+}
+
+// stat returns the latest modification time of the files.
+func (r *Reloader) stat() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// load loads the certificate of the files modified at modTime.
+func (r *Reloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %v", err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
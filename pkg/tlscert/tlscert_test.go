@@ -0,0 +1,103 @@
+package tlscert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joneskoo/mymonies/pkg/logging"
+)
+
+// writeCert writes a self-signed certificate for name and its key to the
+// files, modified at modTime.
+func writeCert(t *testing.T, certFile, keyFile, name string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{name},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	}
+	for name, block := range files {
+		if err := ioutil.WriteFile(name, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(name, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func commonName(t *testing.T, r *Reloader) string {
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal("GetCertificate() returned error:", err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	start := time.Now().Add(-time.Hour)
+	writeCert(t, certFile, keyFile, "old.example.com", start)
+
+	r, err := NewReloader(certFile, keyFile, logging.Discard())
+	if err != nil {
+		t.Fatal("NewReloader() returned error:", err)
+	}
+	if got := commonName(t, r); got != "old.example.com" {
+		t.Errorf("certificate = %v, want old.example.com", got)
+	}
+
+	writeCert(t, certFile, keyFile, "new.example.com", start.Add(time.Minute))
+	if got := commonName(t, r); got != "old.example.com" {
+		t.Errorf("certificate before check interval = %v, want old.example.com", got)
+	}
+	r.checked = time.Time{}
+	if got := commonName(t, r); got != "new.example.com" {
+		t.Errorf("certificate after change = %v, want new.example.com", got)
+	}
+
+	// A half-replaced pair keeps the previous certificate.
+	if err := ioutil.WriteFile(keyFile, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	r.checked = time.Time{}
+	if got := commonName(t, r); got != "new.example.com" {
+		t.Errorf("certificate after failed reload = %v, want new.example.com", got)
+	}
+
+	if _, err := NewReloader(filepath.Join(dir, "missing.pem"), keyFile, logging.Discard()); err == nil {
+		t.Error("NewReloader() of missing file returned no error")
+	}
+}
@@ -0,0 +1,59 @@
+// Session and CSRF handling shared by the app and the login page.
+
+// The server requires the CSRF token from the mymonies_csrf cookie in an
+// X-CSRF-Token header in every POST request, and the selected household is
+// sent in an X-Mymonies-Household header. The generated Twirp client can't
+// set headers, so they are added to every XMLHttpRequest.
+(function () {
+    const send = XMLHttpRequest.prototype.send;
+    XMLHttpRequest.prototype.send = function (body) {
+        const token = csrfToken();
+        if (token) {
+            this.setRequestHeader('X-CSRF-Token', token);
+        }
+        const household = localStorage.getItem('mymonies_household');
+        if (household) {
+            this.setRequestHeader('X-Mymonies-Household', household);
+        }
+        return send.call(this, body);
+    };
+})();
+
+function csrfToken() {
+    const match = document.cookie.match(/(?:^|;\s*)mymonies_csrf=([^;]*)/);
+    return match ? decodeURIComponent(match[1]) : '';
+}
+
+/*
+* Go to the login page, and back to the current page after login.
+*/
+function redirectToLogin() {
+    document.location = 'login.html?next=' + encodeURIComponent(document.location.pathname + document.location.hash);
+}
+
+/*
+* Call onSession with the logged in user, or with null if there is no
+* session or authentication is disabled.
+*/
+function getSession(onSession) {
+    const xhr = new XMLHttpRequest();
+    xhr.open('GET', '/auth/session');
+    xhr.onload = () => onSession(xhr.status === 200 ? JSON.parse(xhr.responseText) : null);
+    xhr.onerror = () => onSession(null);
+    xhr.send();
+}
+
+function logout() {
+    const xhr = new XMLHttpRequest();
+    xhr.open('POST', '/auth/logout');
+    xhr.onloadend = redirectToLogin;
+    xhr.send();
+}
+
+/*
+* Select the household of the following requests and reload the page.
+*/
+function selectHousehold(id) {
+    localStorage.setItem('mymonies_household', id);
+    document.location.reload();
+}
@@ -0,0 +1,39 @@
+// Login form of login.html.
+
+// Only redirect within this site.
+const next = new URLSearchParams(document.location.search).get('next') || '/';
+const safeNext = next.startsWith('/') && !next.startsWith('//') ? next : '/';
+
+const error = new URLSearchParams(document.location.search).get('error');
+if (error) {
+    document.getElementById('error').textContent = 'Kertakirjautuminen epäonnistui: ' + error;
+}
+
+const providers = new XMLHttpRequest();
+providers.open('GET', '/auth/providers');
+providers.onload = function () {
+    if (providers.status === 200 && JSON.parse(providers.responseText).oidc) {
+        const link = document.getElementById('oidc');
+        link.href = '/auth/oidc/login?next=' + encodeURIComponent(safeNext);
+        link.style.display = '';
+    }
+};
+providers.send();
+
+document.getElementById('login').addEventListener('submit', function (e) {
+    e.preventDefault();
+    const xhr = new XMLHttpRequest();
+    xhr.open('POST', '/auth/login');
+    xhr.setRequestHeader('Content-Type', 'application/json');
+    xhr.onload = function () {
+        if (xhr.status !== 200) {
+            document.getElementById('error').textContent = 'Kirjautuminen epäonnistui: ' + JSON.parse(xhr.responseText).msg;
+            return;
+        }
+        document.location = safeNext;
+    };
+    xhr.send(JSON.stringify({
+        username: document.getElementById('username').value,
+        password: document.getElementById('password').value,
+    }));
+});
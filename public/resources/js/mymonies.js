@@ -2,6 +2,9 @@ window.onload = init;
 
 let app;
 
+// Totals are converted to the base currency using exchange rates.
+const baseCurrency = 'EUR';
+
 function init() {
     app = new Vue({
         el: '#app',
@@ -10,7 +13,21 @@ function init() {
             account: '',
             accounts: [],
             tags: {},
+            totals: [],
             transactions: [],
+            user: null,
+            households: [],
+            household: localStorage.getItem('mymonies_household') || '',
+        },
+        methods: {
+            tagName: function (id) {
+                for (let name in this.tags) {
+                    if (this.tags[name] === id) {
+                        return name;
+                    }
+                }
+                return '';
+            }
         },
         watch: {
             account: function () {
@@ -27,6 +44,16 @@ function init() {
     });
 
 
+    getSession((session) => app.user = session);
+
+    Mymonies_list_households("", {}, gotHouseholds, onXhrFail);
+    function gotHouseholds(res) {
+        app.households = res.households || [];
+        if (!app.households.some((h) => h.id === app.household) && app.households.length > 0) {
+            app.household = app.households[0].id;
+        }
+    }
+
     Mymonies_list_tags("", {}, gotTags, onXhrFail);
     function gotTags(res) {
         console.log(res.tags);
@@ -34,10 +61,9 @@ function init() {
         console.log(app.tags);
     }
 
-    Mymonies_list_accounts("", {}, gotAccounts, onXhrFail);
-    function gotAccounts(res) {
-        app.accounts = res.accounts;
-    }
+    updateAccounts();
+    updateTotals();
+    subscribeEvents();
 }
 
 
@@ -52,6 +78,15 @@ Vue.component('tabs', {
                         <a class="nav-link" :href="tab.href" @click="selectTab(tab.id)">{{ tab.name }}</a>
                     </li>
                 </ul>
+                <select class="form-control form-control-sm mr-2" style="width: auto"
+                        v-if="$root.households.length > 1" :value="$root.household"
+                        @change="onSelectHousehold($event.target.value)">
+                    <option v-for="h in $root.households" :value="h.id">{{ h.name }}</option>
+                </select>
+                <span class="navbar-text" v-if="$root.user">
+                    {{ $root.user.username }}
+                    <button class="btn btn-sm btn-outline-secondary" @click="onLogout">Kirjaudu ulos</button>
+                </span>
             </nav>
             <div id="tabs-details">
                 <slot></slot>
@@ -63,6 +98,12 @@ Vue.component('tabs', {
             this.tabs.forEach((tab) => {
                 tab.isActive = (tab.id === id);
             });
+        },
+        onLogout() {
+            logout();
+        },
+        onSelectHousehold(id) {
+            selectHousehold(id);
         }
     },
     data() {
@@ -113,7 +154,7 @@ Vue.component('select-account', {
     template: `
         <select v-model="selected">
             <option>--</option>
-            <option v-for="a in accounts">{{ a.number }}</option>
+            <option v-for="a in accounts" :value="a.number">{{ a.number }} ({{ a.currency }})</option>
         </select>
     `,
     watch: {
@@ -179,13 +220,68 @@ Vue.component('transactiondetail', {
 
 
 /*
-* Replace the whole page with text "failed to load".
+* Replace the whole page with text "failed to load", or go to the login page
+* if the session has expired.
 */
 function onXhrFail(err) {
+    if (err && err.code === 'unauthenticated') {
+        redirectToLogin();
+        return;
+    }
+    // The selected household may be from another login.
+    if (err && err.code === 'permission_denied' && localStorage.getItem('mymonies_household')) {
+        localStorage.removeItem('mymonies_household');
+        document.location.reload();
+        return;
+    }
     let body = document.getElementsByTagName("body")[0];
     body.innerHTML = '<h1>Failed to load data: ' + err + '</h1>';
 }
 
+function updateAccounts() {
+    Mymonies_list_accounts("", {}, (res) => app.accounts = res.accounts, onXhrFail);
+}
+
+function updateTotals() {
+    // Without exchange rates for every currency, list totals per currency.
+    Mymonies_list_totals("", {filter: {}, base_currency: baseCurrency}, gotTotals, function () {
+        Mymonies_list_totals("", {filter: {}}, gotTotals, onXhrFail);
+    });
+    function gotTotals(res) {
+        app.totals = res.totals || [];
+    }
+}
+
+/*
+* Reload the data when it changes on the server, e.g. when someone else
+* tags transactions. After a reconnect events may have been missed, so the
+* data is reloaded as well.
+*/
+function subscribeEvents() {
+    const household = localStorage.getItem('mymonies_household');
+    const events = new EventSource('/events' + (household ? '?household=' + encodeURIComponent(household) : ''));
+    let connected = false;
+    events.onopen = function () {
+        if (connected) {
+            reload();
+        }
+        connected = true;
+    };
+    events.addEventListener('import', () => {
+        updateAccounts();
+        reload();
+    });
+    events.addEventListener('tag', reload);
+    events.addEventListener('pattern', reload);
+
+    function reload() {
+        updateTotals();
+        if (app.account) {
+            updateTransactions(app.account).catch(onXhrFail);
+        }
+    }
+}
+
 async function updateTransactions(account) {
     Mymonies_list_transactions("", {
         filter: {
@@ -2,6 +2,7 @@
 export function Mymonies_add_pattern(server_address: string, add_pattern_req: any, onSuccess: Function, onError: ErrorCallback): void;
 export function Mymonies_list_accounts(server_address: string, list_accounts_req: any, onSuccess: Function, onError: ErrorCallback): void;
 export function Mymonies_list_tags(server_address: string, list_tags_req: any, onSuccess: (res: ListTagsResponse) => void, onError: ErrorCallback): void;
+export function Mymonies_list_totals(server_address: string, list_totals_req: ListTotalsRequest, onSuccess: (res: ListTotalsResponse) => void, onError: ErrorCallback): void;
 export function Mymonies_list_transactions(server_address: string, list_transactions_req: ListTransactionRequest, onSuccess: (res: ListTransactionResponse) => void, onError: ErrorCallback): void;
 export function Mymonies_update_tag(server_address: string, update_tag_req: any, onSuccess: Function, onError: ErrorCallback): void;
 
@@ -31,7 +32,7 @@ interface Transaction {
     transaction_date: string;
     value_date: string;
     payment_date: string;
-    amount: number;
+    amount: string;
     payee_payer: string;
     account: string;
     bic: string;
@@ -42,6 +43,24 @@ interface Transaction {
     card_number: string;
     tag_id: string;
     import_id: string;
+    currency: string;
+}
+
+export interface ListTotalsRequest {
+    filter: TransactionFilter;
+    base_currency?: string;
+}
+
+interface ListTotalsResponse {
+    totals: Total[];
+}
+
+interface Total {
+    month: string;
+    tag_id: string;
+    currency: string;
+    amount: string;
+    count: number;
 }
 
 export interface TransactionFilter {
@@ -33,22 +33,58 @@ var _request = function(method, path, body, onSuccess, onError) {
 
 // methods for MymoniesClient
 
+var Mymonies_add_exchange_rates = function(server_address, add_exchange_rates_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "AddExchangeRates";
+  _request("POST", full_method, add_exchange_rates_req, onSuccess, onError);
+};
 var Mymonies_add_pattern = function(server_address, add_pattern_req, onSuccess, onError) {
   var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "AddPattern";
   _request("POST", full_method, add_pattern_req, onSuccess, onError);
 };
+var Mymonies_add_tag = function(server_address, add_tag_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "AddTag";
+  _request("POST", full_method, add_tag_req, onSuccess, onError);
+};
+var Mymonies_bulk_update_tag = function(server_address, bulk_update_tag_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "BulkUpdateTag";
+  _request("POST", full_method, bulk_update_tag_req, onSuccess, onError);
+};
 var Mymonies_list_accounts = function(server_address, list_accounts_req, onSuccess, onError) {
   var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListAccounts";
   _request("POST", full_method, list_accounts_req, onSuccess, onError);
 };
+var Mymonies_list_household_members = function(server_address, list_household_members_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListHouseholdMembers";
+  _request("POST", full_method, list_household_members_req, onSuccess, onError);
+};
+var Mymonies_list_households = function(server_address, list_households_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListHouseholds";
+  _request("POST", full_method, list_households_req, onSuccess, onError);
+};
+var Mymonies_list_tag_history = function(server_address, list_tag_history_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListTagHistory";
+  _request("POST", full_method, list_tag_history_req, onSuccess, onError);
+};
 var Mymonies_list_tags = function(server_address, list_tags_req, onSuccess, onError) {
   var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListTags";
   _request("POST", full_method, list_tags_req, onSuccess, onError);
 };
+var Mymonies_list_totals = function(server_address, list_totals_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListTotals";
+  _request("POST", full_method, list_totals_req, onSuccess, onError);
+};
 var Mymonies_list_transactions = function(server_address, list_transactions_req, onSuccess, onError) {
   var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "ListTransactions";
   _request("POST", full_method, list_transactions_req, onSuccess, onError);
 };
+var Mymonies_set_household_member = function(server_address, set_household_member_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "SetHouseholdMember";
+  _request("POST", full_method, set_household_member_req, onSuccess, onError);
+};
+var Mymonies_undo = function(server_address, undo_req, onSuccess, onError) {
+  var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "Undo";
+  _request("POST", full_method, undo_req, onSuccess, onError);
+};
 var Mymonies_update_tag = function(server_address, update_tag_req, onSuccess, onError) {
   var full_method = server_address + "/twirp/" + "com.github.joneskoo.mymonies.Mymonies" + "/" + "UpdateTag";
   _request("POST", full_method, update_tag_req, onSuccess, onError);